@@ -96,6 +96,10 @@ func New(opts ...Option) (*App, error) {
 
 	// 現在才能建立 resource service（依賴 clientFactory）
 	a.resources = resource.NewService(a.clientFactory, a.metrics, cfg.RequestTimeout, a.stateStore)
+	a.resources.SetCacheTTL(cfg.CacheTTL, cfg.CacheTTLByKind)
+	a.resources.SetS3Options(cfg.S3Concurrency, cfg.S3LazyEnrich)
+	a.resources.SetPageSize(cfg.PageSize)
+	a.resources.SetPreviewSize(int64(cfg.PreviewKB) << 10)
 
 	uiRoot, err := ui.NewRoot(cfg, themeMgr, a.stateStore, a.resources)
 	if err != nil {
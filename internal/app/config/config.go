@@ -23,6 +23,16 @@ type Config struct {
 	PageSize       int           `yaml:"page_size"`
 	RequestTimeout time.Duration `yaml:"request_timeout"`
 
+	// CacheTTL 為清單快取預設有效時間；CacheTTLByKind 依資源類型（ec2、s3…）覆寫
+	CacheTTL       time.Duration            `yaml:"cache_ttl"`
+	CacheTTLByKind map[string]time.Duration `yaml:"cache_ttl_by_kind"`
+
+	// S3Concurrency 為同時查詢 bucket 設定的上限；S3LazyEnrich 為 true 時先顯示 bucket 名稱再於背景補齊
+	S3Concurrency int  `yaml:"s3_concurrency"`
+	S3LazyEnrich  bool `yaml:"s3_lazy_enrich"`
+	// PreviewKB 為 S3 物件預覽讀取的 KB 數
+	PreviewKB int `yaml:"preview_kb"`
+
 	// Profiles 儲存從 ~/.aws/config 解析出的 profile 列表
 	Profiles *profile.List `yaml:"-"`
 }
@@ -34,6 +44,10 @@ func Default() Config {
 		Language:       lookupDefault(os.Getenv("AWS_TUI_LANGUAGE"), "en"), // 預設英文
 		PageSize:       parseIntWithDefault(os.Getenv("AWS_TUI_PAGE_SIZE"), 50),
 		RequestTimeout: parseDurationWithDefault(os.Getenv("AWS_TUI_TIMEOUT"), 5*time.Second),
+		CacheTTL:       parseDurationWithDefault(os.Getenv("AWS_TUI_CACHE_TTL"), time.Minute),
+		S3Concurrency:  parseIntWithDefault(os.Getenv("AWS_TUI_S3_CONCURRENCY"), 8),
+		S3LazyEnrich:   true,
+		PreviewKB:      parseIntWithDefault(os.Getenv("AWS_TUI_PREVIEW_KB"), 64),
 	}
 
 	// 從 AWS config 讀取 profiles
@@ -139,6 +153,28 @@ func applyFileOverride(path string, cfg *Config) error {
 	if fileCfg.RequestTimeout != "" {
 		cfg.RequestTimeout = parseDurationWithDefault(fileCfg.RequestTimeout, cfg.RequestTimeout)
 	}
+	if fileCfg.CacheTTL != "" {
+		cfg.CacheTTL = parseDurationWithDefault(fileCfg.CacheTTL, cfg.CacheTTL)
+	}
+	if fileCfg.S3Concurrency != 0 {
+		cfg.S3Concurrency = fileCfg.S3Concurrency
+	}
+	if fileCfg.S3LazyEnrich != nil {
+		cfg.S3LazyEnrich = *fileCfg.S3LazyEnrich
+	}
+	if fileCfg.PreviewKB != 0 {
+		cfg.PreviewKB = fileCfg.PreviewKB
+	}
+	if len(fileCfg.CacheTTLByKind) > 0 {
+		cfg.CacheTTLByKind = make(map[string]time.Duration, len(fileCfg.CacheTTLByKind))
+		for kind, value := range fileCfg.CacheTTLByKind {
+			ttl, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("parse cache_ttl_by_kind.%s in %s: %w", kind, path, err)
+			}
+			cfg.CacheTTLByKind[kind] = ttl
+		}
+	}
 
 	return nil
 }
@@ -146,20 +182,35 @@ func applyFileOverride(path string, cfg *Config) error {
 // fileConfig 定義 config.yaml 的結構。
 // 注意：profile/region 優先從 ~/.aws/config 讀取。
 type fileConfig struct {
-	Profile        string `yaml:"profile"`         // 選擇哪個 AWS profile（對應 ~/.aws/config 中的 profile）
-	Region         string `yaml:"region"`          // Fallback region（若 profile 沒有設定 region）
-	Theme          string `yaml:"theme"`           // UI 主題
-	Language       string `yaml:"language"`        // 介面語言：en, zh-TW
-	PageSize       int    `yaml:"page_size"`       // 分頁大小
-	RequestTimeout string `yaml:"request_timeout"` // 請求超時
+	Profile        string            `yaml:"profile"`           // 選擇哪個 AWS profile（對應 ~/.aws/config 中的 profile）
+	Region         string            `yaml:"region"`            // Fallback region（若 profile 沒有設定 region）
+	Theme          string            `yaml:"theme"`             // UI 主題
+	Language       string            `yaml:"language"`          // 介面語言：en, zh-TW
+	PageSize       int               `yaml:"page_size"`         // 分頁大小
+	RequestTimeout string            `yaml:"request_timeout"`   // 請求超時
+	CacheTTL       string            `yaml:"cache_ttl"`         // 清單快取預設有效時間
+	CacheTTLByKind map[string]string `yaml:"cache_ttl_by_kind"` // 依資源類型覆寫快取有效時間
+	S3Concurrency  int               `yaml:"s3_concurrency"`    // 同時查詢 bucket 設定的上限
+	S3LazyEnrich   *bool             `yaml:"s3_lazy_enrich"`    // 先顯示 bucket 名稱再背景補齊（未設定時為 true）
+	PreviewKB      int               `yaml:"preview_kb"`        // S3 物件預覽讀取的 KB 數
 }
 
-func defaultConfigPath() string {
+// Dir 回傳設定目錄（~/.config/aws-tui），config.yaml 與其他儲存的資料（例如 Lambda 測試事件）都放在這裡；
+// 無法取得家目錄時回傳空字串。
+func Dir() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return ""
 	}
-	return filepath.Join(homeDir, ".config", "aws-tui", "config.yaml")
+	return filepath.Join(homeDir, ".config", "aws-tui")
+}
+
+func defaultConfigPath() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "config.yaml")
 }
 
 func lookupDefault(value, fallback string) string {
@@ -4,6 +4,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
@@ -12,7 +13,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 
 	"github.com/vincent119/awsGUITools/internal/aws/session"
 )
@@ -20,11 +24,18 @@ import (
 // Factory 根據 profile/region 產生各 AWS 服務的 client。
 type Factory struct {
 	loader session.Loader
+
+	// S3 bucket 可能分散在多個 region，依 profile|region 快取 client
+	s3Mu      sync.Mutex
+	s3Clients map[string]*s3.Client
 }
 
 // NewFactory 建立 Factory 實例。
 func NewFactory(loader session.Loader) *Factory {
-	return &Factory{loader: loader}
+	return &Factory{
+		loader:    loader,
+		s3Clients: make(map[string]*s3.Client),
+	}
 }
 
 func (f *Factory) load(ctx context.Context, profile, region string) (awsCfg aws.Config, err error) {
@@ -52,13 +63,49 @@ func (f *Factory) RDS(ctx context.Context, profile, region string) (*rds.Client,
 	return rds.NewFromConfig(cfg), nil
 }
 
-// S3 回傳 s3.Client。
+// S3 回傳 s3.Client，同一 profile 與 region 會重複使用同一個 client。
+// 載入設定可能需要讀取檔案或取得 SSO credentials，不在持有鎖時進行，避免阻塞其他 region 的查詢。
 func (f *Factory) S3(ctx context.Context, profile, region string) (*s3.Client, error) {
+	key := profile + "|" + region
+	f.s3Mu.Lock()
+	client, ok := f.s3Clients[key]
+	f.s3Mu.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	cfg, err := f.load(ctx, profile, region)
+	if err != nil {
+		return nil, err
+	}
+	client = s3.NewFromConfig(cfg)
+
+	f.s3Mu.Lock()
+	defer f.s3Mu.Unlock()
+	// 同時建立時保留先存入的 client，所有呼叫端共用同一個
+	if existing, ok := f.s3Clients[key]; ok {
+		return existing, nil
+	}
+	f.s3Clients[key] = client
+	return client, nil
+}
+
+// S3Control 回傳 s3control.Client（帳號層級的 S3 設定）。
+func (f *Factory) S3Control(ctx context.Context, profile, region string) (*s3control.Client, error) {
 	cfg, err := f.load(ctx, profile, region)
 	if err != nil {
 		return nil, err
 	}
-	return s3.NewFromConfig(cfg), nil
+	return s3control.NewFromConfig(cfg), nil
+}
+
+// STS 回傳 sts.Client。
+func (f *Factory) STS(ctx context.Context, profile, region string) (*sts.Client, error) {
+	cfg, err := f.load(ctx, profile, region)
+	if err != nil {
+		return nil, err
+	}
+	return sts.NewFromConfig(cfg), nil
 }
 
 // Lambda 回傳 lambda.Client。
@@ -97,3 +144,12 @@ func (f *Factory) Route53(ctx context.Context, profile, _ string) (*route53.Clie
 	}
 	return route53.NewFromConfig(cfg), nil
 }
+
+// Route53Resolver 回傳 route53resolver.Client（Resolver 是 regional 服務）。
+func (f *Factory) Route53Resolver(ctx context.Context, profile, region string) (*route53resolver.Client, error) {
+	cfg, err := f.load(ctx, profile, region)
+	if err != nil {
+		return nil, err
+	}
+	return route53resolver.NewFromConfig(cfg), nil
+}
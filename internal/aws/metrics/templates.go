@@ -5,33 +5,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 )
 
-// ResourceKind 表示資源類型。
-type ResourceKind string
-
-const (
-	KindEC2    ResourceKind = "ec2"
-	KindRDS    ResourceKind = "rds"
-	KindS3     ResourceKind = "s3"
-	KindLambda ResourceKind = "lambda"
-)
-
-// DefaultQueries 根據資源類型與 ID 回傳預設 KPI 查詢。
-func DefaultQueries(kind ResourceKind, resourceID string) []Query {
-	switch kind {
-	case KindEC2:
-		return ec2Queries(resourceID)
-	case KindRDS:
-		return rdsQueries(resourceID)
-	case KindS3:
-		return s3Queries(resourceID)
-	case KindLambda:
-		return lambdaQueries(resourceID)
-	default:
-		return nil
-	}
-}
-
-func ec2Queries(instanceID string) []Query {
+// EC2Queries 回傳 EC2 instance 的預設 KPI 查詢。
+func EC2Queries(instanceID string) []Query {
 	dim := []types.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(instanceID)}}
 	return []Query{
 		{ID: "cpu", MetricName: "CPUUtilization", Namespace: "AWS/EC2", Stat: "Average", Dimensions: dim},
@@ -42,7 +17,8 @@ func ec2Queries(instanceID string) []Query {
 	}
 }
 
-func rdsQueries(dbInstanceID string) []Query {
+// RDSQueries 回傳 RDS instance 的預設 KPI 查詢。
+func RDSQueries(dbInstanceID string) []Query {
 	dim := []types.Dimension{{Name: aws.String("DBInstanceIdentifier"), Value: aws.String(dbInstanceID)}}
 	return []Query{
 		{ID: "cpu", MetricName: "CPUUtilization", Namespace: "AWS/RDS", Stat: "Average", Dimensions: dim},
@@ -53,7 +29,8 @@ func rdsQueries(dbInstanceID string) []Query {
 	}
 }
 
-func s3Queries(bucketName string) []Query {
+// S3Queries 回傳 S3 bucket 的預設 KPI 查詢。
+func S3Queries(bucketName string) []Query {
 	dim := []types.Dimension{
 		{Name: aws.String("BucketName"), Value: aws.String(bucketName)},
 		{Name: aws.String("StorageType"), Value: aws.String("StandardStorage")},
@@ -64,7 +41,8 @@ func s3Queries(bucketName string) []Query {
 	}
 }
 
-func lambdaQueries(functionName string) []Query {
+// LambdaQueries 回傳 Lambda function 的預設 KPI 查詢。
+func LambdaQueries(functionName string) []Query {
 	dim := []types.Dimension{{Name: aws.String("FunctionName"), Value: aws.String(functionName)}}
 	return []Query{
 		{ID: "invocations", MetricName: "Invocations", Namespace: "AWS/Lambda", Stat: "Sum", Dimensions: dim},
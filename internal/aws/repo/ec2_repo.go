@@ -46,6 +46,67 @@ func (r *EC2Repository) ListInstances(ctx context.Context, client *ec2.Client, i
 	return instances, nil
 }
 
+// GetInstance 以 DescribeInstances 查詢單一實例，並透過 DescribeVolumes 補齊 EBS 容量與類型。
+func (r *EC2Repository) GetInstance(ctx context.Context, client *ec2.Client, instanceID string) (models.EC2Instance, error) {
+	if client == nil {
+		return models.EC2Instance{}, fmt.Errorf("ec2 client is nil")
+	}
+
+	resp, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return models.EC2Instance{}, fmt.Errorf("describe instance %s: %w", instanceID, err)
+	}
+
+	var (
+		instance models.EC2Instance
+		found    bool
+	)
+	for _, reservation := range resp.Reservations {
+		for _, inst := range reservation.Instances {
+			if deref(inst.InstanceId) == instanceID {
+				instance, found = convertEC2Instance(inst), true
+			}
+		}
+	}
+	if !found {
+		return models.EC2Instance{}, fmt.Errorf("instance %s not found", instanceID)
+	}
+
+	volumeIDs := make([]string, 0, len(instance.Volumes))
+	for _, vol := range instance.Volumes {
+		if vol.ID != "" {
+			volumeIDs = append(volumeIDs, vol.ID)
+		}
+	}
+	if len(volumeIDs) == 0 {
+		return instance, nil
+	}
+
+	volResp, err := client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{
+		VolumeIds: volumeIDs,
+	})
+	if err != nil {
+		return models.EC2Instance{}, fmt.Errorf("describe volumes of %s: %w", instanceID, err)
+	}
+	byID := make(map[string]ec2types.Volume, len(volResp.Volumes))
+	for _, vol := range volResp.Volumes {
+		byID[deref(vol.VolumeId)] = vol
+	}
+	for i, vol := range instance.Volumes {
+		detail, ok := byID[vol.ID]
+		if !ok {
+			continue
+		}
+		instance.Volumes[i].SizeGiB = deref(detail.Size)
+		instance.Volumes[i].VolumeType = string(detail.VolumeType)
+		instance.Volumes[i].Encrypted = deref(detail.Encrypted)
+	}
+
+	return instance, nil
+}
+
 func convertEC2Instance(inst ec2types.Instance) models.EC2Instance {
 	var sg []string
 	for _, g := range inst.SecurityGroups {
@@ -81,6 +142,9 @@ func convertEC2Instance(inst ec2types.Instance) models.EC2Instance {
 		SubnetID:         deref(inst.SubnetId),
 		SecurityGroups:   sg,
 		IAMRole:          extractIAMRole(inst.IamInstanceProfile),
+		ImageID:          deref(inst.ImageId),
+		KeyName:          deref(inst.KeyName),
+		LaunchTime:       formatTime(inst.LaunchTime),
 		Volumes:          volumes,
 		Tags:             convertTags(inst.Tags),
 	}
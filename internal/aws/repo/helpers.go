@@ -1,5 +1,7 @@
 package repo
 
+import "time"
+
 // deref safely de-references AWS SDK pointers.
 func deref[T any](ptr *T) T {
 	var zero T
@@ -8,3 +10,11 @@ func deref[T any](ptr *T) T {
 	}
 	return *ptr
 }
+
+// formatTime formats an optional AWS timestamp for display.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// GetCode 以 GetFunction 取得函式（可含版本或 alias）部署套件的下載位置。
+func (r *LambdaRepository) GetCode(ctx context.Context, client *lambda.Client, functionName string) (models.LambdaCode, error) {
+	if client == nil {
+		return models.LambdaCode{}, fmt.Errorf("lambda client is nil")
+	}
+	resp, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return models.LambdaCode{}, fmt.Errorf("get function %s: %w", functionName, err)
+	}
+	if resp.Configuration == nil {
+		return models.LambdaCode{}, fmt.Errorf("function %s has no configuration", functionName)
+	}
+	cfg := resp.Configuration
+	code := models.LambdaCode{
+		Function:      deref(cfg.FunctionName),
+		Version:       deref(cfg.Version),
+		PackageType:   string(cfg.PackageType),
+		SHA256:        deref(cfg.CodeSha256),
+		CodeSizeBytes: cfg.CodeSize,
+	}
+	if resp.Code != nil {
+		code.Location = deref(resp.Code.Location)
+		code.ImageURI = deref(resp.Code.ImageUri)
+	}
+	return code, nil
+}
+
+// DownloadCode 自 GetFunction 回傳的 presigned URL 下載 zip 部署套件到 dest。
+// 先寫入暫存檔並比對 CodeSha256，相符才更名為 dest；失敗或取消時刪除暫存檔。
+func (r *LambdaRepository) DownloadCode(ctx context.Context, code models.LambdaCode, dest string, progress ProgressFunc) error {
+	if code.PackageType == string(lambdatypes.PackageTypeImage) {
+		return fmt.Errorf("function %s is deployed as container image %s; there is no zip package to download", code.Function, code.ImageURI)
+	}
+	if code.Location == "" {
+		return fmt.Errorf("function %s has no code location", code.Function)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.part")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	done := false
+	defer func() {
+		if !done {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, code.Location, nil)
+	if err != nil {
+		return fmt.Errorf("download code of %s: %w", code.Function, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download code of %s: %w", code.Function, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download code of %s: %s", code.Function, resp.Status)
+	}
+
+	hash := sha256.New()
+	w := &progressWriter{w: io.MultiWriter(tmp, hash), progress: progress}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("download code of %s: %w", code.Function, err)
+	}
+	if sum := base64.StdEncoding.EncodeToString(hash.Sum(nil)); code.SHA256 != "" && sum != code.SHA256 {
+		return fmt.Errorf("code of %s does not match CodeSha256 (got %s, want %s)", code.Function, sum, code.SHA256)
+	}
+	if err := tmp.Chmod(downloadFileMode); err != nil {
+		return fmt.Errorf("chmod %s: %w", dest, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", dest, err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return fmt.Errorf("rename %s: %w", dest, err)
+	}
+	done = true
+	return nil
+}
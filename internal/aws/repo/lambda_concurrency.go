@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// MinUnreservedConcurrency 為 Lambda 要求帳號保留給未設定保留並行數之函式的最小並行數。
+const MinUnreservedConcurrency = 100
+
+// GetAccountConcurrency 以 GetAccountSettings 取得目前 region 的並行數上限與未保留的容量。
+func (r *LambdaRepository) GetAccountConcurrency(ctx context.Context, client *lambda.Client) (models.LambdaAccountConcurrency, error) {
+	if client == nil {
+		return models.LambdaAccountConcurrency{}, fmt.Errorf("lambda client is nil")
+	}
+	resp, err := client.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return models.LambdaAccountConcurrency{}, fmt.Errorf("get account settings: %w", err)
+	}
+	if resp.AccountLimit == nil {
+		return models.LambdaAccountConcurrency{}, fmt.Errorf("account settings have no limits")
+	}
+	return models.LambdaAccountConcurrency{
+		Limit:      resp.AccountLimit.ConcurrentExecutions,
+		Unreserved: deref(resp.AccountLimit.UnreservedConcurrentExecutions),
+	}, nil
+}
+
+// GetReservedConcurrency 以 GetFunctionConcurrency 取得函式的保留並行數；未設定時回傳 nil。
+func (r *LambdaRepository) GetReservedConcurrency(ctx context.Context, client *lambda.Client, functionName string) (*int32, error) {
+	if client == nil {
+		return nil, fmt.Errorf("lambda client is nil")
+	}
+	resp, err := client.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get function concurrency %s: %w", functionName, err)
+	}
+	return resp.ReservedConcurrentExecutions, nil
+}
+
+// SetReservedConcurrency 以 PutFunctionConcurrency 設定保留並行數（0 表示停止所有呼叫）；
+// reserved 為 nil 時以 DeleteFunctionConcurrency 移除，函式改用未保留的容量。
+func (r *LambdaRepository) SetReservedConcurrency(ctx context.Context, client *lambda.Client, functionName string, reserved *int32) error {
+	if client == nil {
+		return fmt.Errorf("lambda client is nil")
+	}
+	if reserved == nil {
+		_, err := client.DeleteFunctionConcurrency(ctx, &lambda.DeleteFunctionConcurrencyInput{
+			FunctionName: aws.String(functionName),
+		})
+		if err != nil {
+			return fmt.Errorf("delete function concurrency %s: %w", functionName, err)
+		}
+		return nil
+	}
+	if *reserved < 0 {
+		return fmt.Errorf("reserved concurrency must not be negative")
+	}
+	_, err := client.PutFunctionConcurrency(ctx, &lambda.PutFunctionConcurrencyInput{
+		FunctionName:                 aws.String(functionName),
+		ReservedConcurrentExecutions: reserved,
+	})
+	if err != nil {
+		return fmt.Errorf("put function concurrency %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// ListProvisionedConcurrency 列出函式各 alias 與版本的 provisioned concurrency 設定。
+func (r *LambdaRepository) ListProvisionedConcurrency(ctx context.Context, client *lambda.Client, functionName string) ([]models.LambdaProvisionedConcurrency, error) {
+	if client == nil {
+		return nil, fmt.Errorf("lambda client is nil")
+	}
+	paginator := lambda.NewListProvisionedConcurrencyConfigsPaginator(client, &lambda.ListProvisionedConcurrencyConfigsInput{
+		FunctionName: aws.String(functionName),
+	})
+	var configs []models.LambdaProvisionedConcurrency
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list provisioned concurrency %s: %w", functionName, err)
+		}
+		for _, c := range page.ProvisionedConcurrencyConfigs {
+			arn := deref(c.FunctionArn)
+			configs = append(configs, models.LambdaProvisionedConcurrency{
+				Qualifier:    arn[strings.LastIndex(arn, ":")+1:],
+				Requested:    deref(c.RequestedProvisionedConcurrentExecutions),
+				Allocated:    deref(c.AllocatedProvisionedConcurrentExecutions),
+				Available:    deref(c.AvailableProvisionedConcurrentExecutions),
+				Status:       string(c.Status),
+				StatusReason: deref(c.StatusReason),
+				LastModified: deref(c.LastModified),
+			})
+		}
+	}
+	return configs, nil
+}
+
+// SetProvisionedConcurrency 以 PutProvisionedConcurrencyConfig 設定 alias 或版本的 provisioned concurrency；
+// count 為 0 時以 DeleteProvisionedConcurrencyConfig 移除。$LATEST 不能設定 provisioned concurrency。
+func (r *LambdaRepository) SetProvisionedConcurrency(ctx context.Context, client *lambda.Client, functionName, qualifier string, count int32) error {
+	if client == nil {
+		return fmt.Errorf("lambda client is nil")
+	}
+	if qualifier == "" || qualifier == latestVersion {
+		return fmt.Errorf("provisioned concurrency needs an alias or a published version")
+	}
+	if count < 0 {
+		return fmt.Errorf("provisioned concurrency must not be negative")
+	}
+	if count == 0 {
+		_, err := client.DeleteProvisionedConcurrencyConfig(ctx, &lambda.DeleteProvisionedConcurrencyConfigInput{
+			FunctionName: aws.String(functionName),
+			Qualifier:    aws.String(qualifier),
+		})
+		if err != nil {
+			return fmt.Errorf("delete provisioned concurrency %s:%s: %w", functionName, qualifier, err)
+		}
+		return nil
+	}
+	_, err := client.PutProvisionedConcurrencyConfig(ctx, &lambda.PutProvisionedConcurrencyConfigInput{
+		FunctionName:                    aws.String(functionName),
+		Qualifier:                       aws.String(qualifier),
+		ProvisionedConcurrentExecutions: aws.Int32(count),
+	})
+	if err != nil {
+		return fmt.Errorf("put provisioned concurrency %s:%s: %w", functionName, qualifier, err)
+	}
+	return nil
+}
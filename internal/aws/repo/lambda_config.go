@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// Lambda 允許的記憶體（MB）與逾時（秒）範圍。
+	minLambdaMemoryMB   = 128
+	maxLambdaMemoryMB   = 10240
+	maxLambdaTimeoutSec = 900
+	// maxLambdaEnvBytes 為所有環境變數的總大小上限。
+	maxLambdaEnvBytes = 4096
+)
+
+// lambdaEnvKey 為 Lambda 接受的環境變數名稱格式。
+var lambdaEnvKey = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// reservedLambdaEnvKeys 為 runtime 保留、不可設定的環境變數。
+var reservedLambdaEnvKeys = []string{
+	"_HANDLER", "_X_AMZN_TRACE_ID", "AWS_DEFAULT_REGION", "AWS_REGION", "AWS_EXECUTION_ENV",
+	"AWS_LAMBDA_FUNCTION_NAME", "AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "AWS_LAMBDA_FUNCTION_VERSION",
+	"AWS_LAMBDA_INITIALIZATION_TYPE", "AWS_LAMBDA_LOG_GROUP_NAME", "AWS_LAMBDA_LOG_STREAM_NAME",
+	"AWS_ACCESS_KEY", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN",
+	"AWS_LAMBDA_RUNTIME_API", "LAMBDA_TASK_ROOT", "LAMBDA_RUNTIME_DIR",
+}
+
+// ErrLambdaConfigChanged 表示函式設定或 alias 在載入後已被他人修改（RevisionId 不符）。
+var ErrLambdaConfigChanged = errors.New("changed since it was loaded (revision mismatch)")
+
+// ValidateLambdaConfig 檢查記憶體、逾時與環境變數是否符合 Lambda 的限制。
+func ValidateLambdaConfig(cfg models.LambdaConfig) error {
+	if cfg.MemoryMB < minLambdaMemoryMB || cfg.MemoryMB > maxLambdaMemoryMB {
+		return fmt.Errorf("memory_mb must be between %d and %d", minLambdaMemoryMB, maxLambdaMemoryMB)
+	}
+	if cfg.TimeoutSec < 1 || cfg.TimeoutSec > maxLambdaTimeoutSec {
+		return fmt.Errorf("timeout_sec must be between 1 and %d", maxLambdaTimeoutSec)
+	}
+	size := 0
+	for _, key := range slices.Sorted(maps.Keys(cfg.Environment)) {
+		if !lambdaEnvKey.MatchString(key) {
+			return fmt.Errorf("invalid environment variable name %q", key)
+		}
+		if slices.Contains(reservedLambdaEnvKeys, key) {
+			return fmt.Errorf("environment variable %s is reserved by the Lambda runtime", key)
+		}
+		size += len(key) + len(cfg.Environment[key])
+	}
+	if size > maxLambdaEnvBytes {
+		return fmt.Errorf("environment variables total %d bytes, exceeding the 4 KB limit", size)
+	}
+	return nil
+}
+
+// UpdateConfiguration 以 UpdateFunctionConfiguration 更新記憶體、逾時與環境變數；為 0 或 nil 的欄位不變更
+// （空的 Environment 會清除所有環境變數）。revisionID 不為空時，函式設定已被修改會回傳 ErrLambdaConfigChanged。
+func (r *LambdaRepository) UpdateConfiguration(ctx context.Context, client *lambda.Client, functionName, revisionID string, cfg models.LambdaConfig) error {
+	if client == nil {
+		return fmt.Errorf("lambda client is nil")
+	}
+	input := &lambda.UpdateFunctionConfigurationInput{FunctionName: aws.String(functionName)}
+	if revisionID != "" {
+		input.RevisionId = aws.String(revisionID)
+	}
+	if cfg.MemoryMB > 0 {
+		input.MemorySize = aws.Int32(cfg.MemoryMB)
+	}
+	if cfg.TimeoutSec > 0 {
+		input.Timeout = aws.Int32(cfg.TimeoutSec)
+	}
+	if cfg.Environment != nil {
+		input.Environment = &lambdatypes.Environment{Variables: cfg.Environment}
+	}
+
+	if _, err := client.UpdateFunctionConfiguration(ctx, input); err != nil {
+		return fmt.Errorf("update function configuration %s: %w", functionName, preconditionError(err))
+	}
+	return nil
+}
+
+// preconditionError 將 RevisionId 不符的 PreconditionFailedException 轉為 ErrLambdaConfigChanged。
+func preconditionError(err error) error {
+	var precondition *lambdatypes.PreconditionFailedException
+	if errors.As(err, &precondition) {
+		return ErrLambdaConfigChanged
+	}
+	return err
+}
+
+// WaitForUpdate 每 interval 以 GetFunctionConfiguration 查詢 LastUpdateStatus，直到 Successful；
+// Failed 時回傳失敗原因。onStatus（可為 nil）回報每次查詢結果。
+func (r *LambdaRepository) WaitForUpdate(ctx context.Context, client *lambda.Client, functionName string, interval time.Duration, onStatus func(status string)) error {
+	if client == nil {
+		return fmt.Errorf("lambda client is nil")
+	}
+	for {
+		output, err := client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{FunctionName: aws.String(functionName)})
+		if err != nil {
+			return fmt.Errorf("get function configuration %s: %w", functionName, err)
+		}
+		status := output.LastUpdateStatus
+		if onStatus != nil {
+			onStatus(string(status))
+		}
+		switch status {
+		case lambdatypes.LastUpdateStatusSuccessful, "":
+			return nil
+		case lambdatypes.LastUpdateStatusFailed:
+			return fmt.Errorf("update of %s failed (%s): %s", functionName, output.LastUpdateStatusReasonCode, aws.ToString(output.LastUpdateStatusReason))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
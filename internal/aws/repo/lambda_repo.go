@@ -3,7 +3,9 @@ package repo
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 
@@ -41,19 +43,109 @@ func (r *LambdaRepository) ListFunctions(ctx context.Context, client *lambda.Cli
 	return functions, nil
 }
 
+// GetFunction 以 GetFunction 查詢單一函式（含標籤與保留並行數），
+// 並以 ListEventSourceMappings 與 resource policy 取得觸發來源；觸發來源查詢失敗（例如沒有 lambda:GetPolicy 權限）
+// 記錄於 Errors，仍回傳函式設定。
+func (r *LambdaRepository) GetFunction(ctx context.Context, client *lambda.Client, functionName string) (models.LambdaFunction, error) {
+	fn, err := r.GetFunctionConfig(ctx, client, functionName)
+	if err != nil {
+		return models.LambdaFunction{}, err
+	}
+
+	mappings, err := r.ListEventSourceMappings(ctx, client, functionName)
+	recordLambdaLookup(&fn, "ListEventSourceMappings", err)
+	permissions, err := r.PolicyTriggers(ctx, client, functionName)
+	recordLambdaLookup(&fn, "GetPolicy", err)
+	fn.Triggers = append(mappings, permissions...)
+
+	return fn, nil
+}
+
+// GetFunctionConfig 以 GetFunction 查詢單一函式的設定、標籤與保留並行數，不查詢觸發來源。
+func (r *LambdaRepository) GetFunctionConfig(ctx context.Context, client *lambda.Client, functionName string) (models.LambdaFunction, error) {
+	if client == nil {
+		return models.LambdaFunction{}, fmt.Errorf("lambda client is nil")
+	}
+
+	resp, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return models.LambdaFunction{}, fmt.Errorf("get function %s: %w", functionName, err)
+	}
+	if resp.Configuration == nil {
+		return models.LambdaFunction{}, fmt.Errorf("function %s has no configuration", functionName)
+	}
+
+	fn := convertLambdaFunction(*resp.Configuration)
+	if len(resp.Tags) > 0 {
+		fn.Tags = models.TagMap(resp.Tags)
+	}
+	if resp.Concurrency != nil {
+		fn.ReservedConcurrency = resp.Concurrency.ReservedConcurrentExecutions
+	}
+	return fn, nil
+}
+
+// recordLambdaLookup 將觸發來源查詢失敗記錄於 fn.Errors。
+func recordLambdaLookup(fn *models.LambdaFunction, operation string, err error) {
+	if err == nil {
+		return
+	}
+	if fn.Errors == nil {
+		fn.Errors = make(map[string]string)
+	}
+	fn.Errors[operation] = err.Error()
+}
+
 func convertLambdaFunction(fn lambdatypes.FunctionConfiguration) models.LambdaFunction {
+	architectures := make([]string, 0, len(fn.Architectures))
+	for _, arch := range fn.Architectures {
+		architectures = append(architectures, string(arch))
+	}
+	layers := make([]models.LambdaLayer, 0, len(fn.Layers))
+	for _, layer := range fn.Layers {
+		layers = append(layers, convertLambdaLayer(layer))
+	}
+
 	return models.LambdaFunction{
-		Name:         deref(fn.FunctionName),
-		ARN:          deref(fn.FunctionArn),
-		Runtime:      string(fn.Runtime),
-		MemoryMB:     deref(fn.MemorySize),
-		TimeoutSec:   deref(fn.Timeout),
-		Role:         deref(fn.Role),
-		EnvVars:      convertEnv(fn.Environment),
-		Triggers:     []string{},
-		Tags:         nil,
-		LastModified: deref(fn.LastModified),
+		Name:             deref(fn.FunctionName),
+		ARN:              deref(fn.FunctionArn),
+		Version:          deref(fn.Version),
+		Runtime:          string(fn.Runtime),
+		Handler:          deref(fn.Handler),
+		Description:      deref(fn.Description),
+		PackageType:      string(fn.PackageType),
+		Architectures:    architectures,
+		CodeSizeBytes:    fn.CodeSize,
+		MemoryMB:         deref(fn.MemorySize),
+		TimeoutSec:       deref(fn.Timeout),
+		Role:             deref(fn.Role),
+		EnvVars:          convertEnv(fn.Environment),
+		Layers:           layers,
+		Triggers:         nil,
+		Tags:             nil,
+		LastModified:     deref(fn.LastModified),
+		State:            string(fn.State),
+		LastUpdateStatus: string(fn.LastUpdateStatus),
+		LastUpdateReason: deref(fn.LastUpdateStatusReason),
+		RevisionID:       deref(fn.RevisionId),
+	}
+}
+
+// convertLambdaLayer 由 layer version ARN（arn:aws:lambda:<region>:<account>:layer:<name>:<version>）取出名稱與版本。
+func convertLambdaLayer(layer lambdatypes.Layer) models.LambdaLayer {
+	result := models.LambdaLayer{
+		ARN:           deref(layer.Arn),
+		CodeSizeBytes: layer.CodeSize,
+	}
+	fields := strings.Split(result.ARN, ":")
+	if len(fields) == 8 && fields[5] == "layer" {
+		result.Name, result.Version = fields[6], fields[7]
+	} else {
+		result.Name = result.ARN
 	}
+	return result
 }
 
 func convertEnv(env *lambdatypes.EnvironmentResponse) map[string]string {
@@ -0,0 +1,222 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// triggerServices 將 ARN 的服務名稱或 service principal 的前綴對應到顯示名稱。
+var triggerServices = map[string]string{
+	"sqs":                  "SQS",
+	"kinesis":              "Kinesis",
+	"dynamodb":             "DynamoDB",
+	"kafka":                "MSK",
+	"mq":                   "Amazon MQ",
+	"docdb":                "DocumentDB",
+	"s3":                   "S3",
+	"events":               "EventBridge",
+	"scheduler":            "EventBridge Scheduler",
+	"execute-api":          "API Gateway",
+	"apigateway":           "API Gateway",
+	"sns":                  "SNS",
+	"logs":                 "CloudWatch Logs",
+	"elasticloadbalancing": "ALB",
+	"iot":                  "IoT",
+	"cognito-idp":          "Cognito",
+	"secretsmanager":       "Secrets Manager",
+	"config":               "Config",
+}
+
+// ListEventSourceMappings 以 ListEventSourceMappings 列出輪詢來源（SQS、Kinesis、DynamoDB streams 等）。
+func (r *LambdaRepository) ListEventSourceMappings(ctx context.Context, client *lambda.Client, functionName string) ([]models.LambdaTrigger, error) {
+	if client == nil {
+		return nil, fmt.Errorf("lambda client is nil")
+	}
+	paginator := lambda.NewListEventSourceMappingsPaginator(client, &lambda.ListEventSourceMappingsInput{
+		FunctionName: aws.String(functionName),
+	})
+	var triggers []models.LambdaTrigger
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list event source mappings of %s: %w", functionName, err)
+		}
+		for _, mapping := range page.EventSourceMappings {
+			triggers = append(triggers, convertEventSourceMapping(mapping))
+		}
+	}
+	return triggers, nil
+}
+
+// PolicyTriggers 以 GetPolicy 取得函式的 resource policy，解析允許呼叫函式的服務；沒有 policy 時回傳空清單。
+func (r *LambdaRepository) PolicyTriggers(ctx context.Context, client *lambda.Client, functionName string) ([]models.LambdaTrigger, error) {
+	if client == nil {
+		return nil, fmt.Errorf("lambda client is nil")
+	}
+	output, err := client.GetPolicy(ctx, &lambda.GetPolicyInput{FunctionName: aws.String(functionName)})
+	if err != nil {
+		var notFound *lambdatypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get policy of %s: %w", functionName, err)
+	}
+	return ParseLambdaPolicy(aws.ToString(output.Policy))
+}
+
+// ParseLambdaPolicy 解析函式 resource policy 的 Allow 陳述：來源取自 AWS:SourceArn 條件，
+// 沒有條件時以 principal 表示（例如整個帳號或任何 S3 bucket）。
+func ParseLambdaPolicy(policy string) ([]models.LambdaTrigger, error) {
+	statements, err := parsePolicyStatements(policy)
+	if err != nil {
+		return nil, fmt.Errorf("parse function policy: %w", err)
+	}
+
+	var triggers []models.LambdaTrigger
+	for _, st := range statements {
+		if !strings.EqualFold(st.Effect, "Allow") {
+			continue
+		}
+		trigger := models.LambdaTrigger{
+			ID:            st.Sid,
+			Actions:       stringOrList(st.Action),
+			Source:        conditionValue(st.Condition, "aws:sourcearn"),
+			SourceAccount: conditionValue(st.Condition, "aws:sourceaccount"),
+		}
+		principal := policyPrincipal(st.Principal)
+		switch {
+		case trigger.Source != "":
+			trigger.Service = triggerService(trigger.Source)
+		case len(trigger.Actions) == 1 && strings.EqualFold(trigger.Actions[0], "lambda:InvokeFunctionUrl"):
+			trigger.Service, trigger.Source = "Function URL", principal
+		default:
+			trigger.Source = principal
+		}
+		if trigger.Service == "" {
+			trigger.Service = triggerService(principal)
+		}
+		triggers = append(triggers, trigger)
+	}
+	return triggers, nil
+}
+
+// SetEventSourceMappingEnabled 以 UpdateEventSourceMapping 啟用或停用 mapping，回傳更新後的狀態（通常為 Enabling/Disabling）。
+func (r *LambdaRepository) SetEventSourceMappingEnabled(ctx context.Context, client *lambda.Client, uuid string, enabled bool) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("lambda client is nil")
+	}
+	output, err := client.UpdateEventSourceMapping(ctx, &lambda.UpdateEventSourceMappingInput{
+		UUID:    aws.String(uuid),
+		Enabled: aws.Bool(enabled),
+	})
+	if err != nil {
+		return "", fmt.Errorf("update event source mapping %s: %w", uuid, err)
+	}
+	return aws.ToString(output.State), nil
+}
+
+// WaitForMappingState 每 interval 以 GetEventSourceMapping 查詢狀態，直到成為 target（Enabled 或 Disabled）。
+func (r *LambdaRepository) WaitForMappingState(ctx context.Context, client *lambda.Client, uuid, target string, interval time.Duration) error {
+	if client == nil {
+		return fmt.Errorf("lambda client is nil")
+	}
+	for {
+		output, err := client.GetEventSourceMapping(ctx, &lambda.GetEventSourceMappingInput{UUID: aws.String(uuid)})
+		if err != nil {
+			return fmt.Errorf("get event source mapping %s: %w", uuid, err)
+		}
+		state := aws.ToString(output.State)
+		if state == target {
+			return nil
+		}
+		if reason := aws.ToString(output.StateTransitionReason); state != "Enabling" && state != "Disabling" && state != "Updating" {
+			return fmt.Errorf("event source mapping %s is %s: %s", uuid, state, reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func convertEventSourceMapping(mapping lambdatypes.EventSourceMappingConfiguration) models.LambdaTrigger {
+	trigger := models.LambdaTrigger{
+		ID:         aws.ToString(mapping.UUID),
+		Source:     aws.ToString(mapping.EventSourceArn),
+		Mapping:    true,
+		State:      aws.ToString(mapping.State),
+		BatchSize:  aws.ToInt32(mapping.BatchSize),
+		LastResult: aws.ToString(mapping.LastProcessingResult),
+	}
+	trigger.Service = triggerService(trigger.Source)
+	if trigger.Source == "" && mapping.SelfManagedEventSource != nil {
+		// 自行管理的 Kafka 沒有 ARN，以 bootstrap servers 表示
+		trigger.Service = "Kafka"
+		trigger.Source = strings.Join(mapping.SelfManagedEventSource.Endpoints[string(lambdatypes.EndPointTypeKafkaBootstrapServers)], ",")
+	}
+	return trigger
+}
+
+// triggerService 由 ARN（arn:aws:sqs:...）或 service principal（s3.amazonaws.com）判斷來源服務；
+// 其他 principal（帳號、"*"）回傳空字串。
+func triggerService(source string) string {
+	name := ""
+	switch {
+	case strings.HasPrefix(source, "arn:"):
+		if parts := strings.SplitN(source, ":", 4); len(parts) == 4 {
+			name = parts[2]
+		}
+	case strings.HasSuffix(source, ".amazonaws.com"):
+		name, _, _ = strings.Cut(source, ".")
+	}
+	if service, ok := triggerServices[name]; ok {
+		return service
+	}
+	return name
+}
+
+// policyPrincipal 回傳陳述的 principal："*"、service principal 或 AWS 帳號 ARN（多個以逗號分隔）。
+func policyPrincipal(raw json.RawMessage) string {
+	if principal := stringOrList(raw); len(principal) > 0 {
+		return strings.Join(principal, ",")
+	}
+	var m map[string]json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &m) != nil {
+		return ""
+	}
+	var values []string
+	for _, key := range []string{"Service", "AWS", "Federated"} {
+		values = append(values, stringOrList(m[key])...)
+	}
+	return strings.Join(values, ",")
+}
+
+// conditionValue 回傳任一運算子下指定條件鍵（不分大小寫）的第一個值。
+func conditionValue(raw json.RawMessage, key string) string {
+	var conditions map[string]map[string]json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &conditions) != nil {
+		return ""
+	}
+	for _, operands := range conditions {
+		for name, value := range operands {
+			if strings.EqualFold(name, key) {
+				if values := stringOrList(value); len(values) > 0 {
+					return values[0]
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// latestVersion 為未發佈、可編輯的函式版本。
+const latestVersion = "$LATEST"
+
+// ListVersions 以 ListVersionsByFunction 列出函式的版本（含 $LATEST）。
+func (r *LambdaRepository) ListVersions(ctx context.Context, client *lambda.Client, functionName string) ([]models.LambdaVersion, error) {
+	if client == nil {
+		return nil, fmt.Errorf("lambda client is nil")
+	}
+	paginator := lambda.NewListVersionsByFunctionPaginator(client, &lambda.ListVersionsByFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	var versions []models.LambdaVersion
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list versions of %s: %w", functionName, err)
+		}
+		for _, v := range page.Versions {
+			versions = append(versions, convertLambdaVersion(v))
+		}
+	}
+	return versions, nil
+}
+
+// ListAliases 以 ListAliases 列出函式的 aliases 與其 routing 設定。
+func (r *LambdaRepository) ListAliases(ctx context.Context, client *lambda.Client, functionName string) ([]models.LambdaAlias, error) {
+	if client == nil {
+		return nil, fmt.Errorf("lambda client is nil")
+	}
+	paginator := lambda.NewListAliasesPaginator(client, &lambda.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	})
+	var aliases []models.LambdaAlias
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list aliases of %s: %w", functionName, err)
+		}
+		for _, a := range page.Aliases {
+			aliases = append(aliases, convertLambdaAlias(a))
+		}
+	}
+	return aliases, nil
+}
+
+// GetAlias 以 GetAlias 查詢單一 alias（含最新的 RevisionId）。
+func (r *LambdaRepository) GetAlias(ctx context.Context, client *lambda.Client, functionName, name string) (models.LambdaAlias, error) {
+	if client == nil {
+		return models.LambdaAlias{}, fmt.Errorf("lambda client is nil")
+	}
+	output, err := client.GetAlias(ctx, &lambda.GetAliasInput{FunctionName: aws.String(functionName), Name: aws.String(name)})
+	if err != nil {
+		return models.LambdaAlias{}, fmt.Errorf("get alias %s of %s: %w", name, functionName, err)
+	}
+	return convertLambdaAlias(lambdatypes.AliasConfiguration{
+		Name:            output.Name,
+		AliasArn:        output.AliasArn,
+		FunctionVersion: output.FunctionVersion,
+		Description:     output.Description,
+		RoutingConfig:   output.RoutingConfig,
+		RevisionId:      output.RevisionId,
+	}), nil
+}
+
+// PublishVersion 以 PublishVersion 將 $LATEST 的程式碼與設定發佈為新版本；
+// revisionID 不為空時，$LATEST 已被修改會回傳 ErrLambdaConfigChanged。
+func (r *LambdaRepository) PublishVersion(ctx context.Context, client *lambda.Client, functionName, description, revisionID string) (models.LambdaVersion, error) {
+	if client == nil {
+		return models.LambdaVersion{}, fmt.Errorf("lambda client is nil")
+	}
+	input := &lambda.PublishVersionInput{FunctionName: aws.String(functionName)}
+	if description != "" {
+		input.Description = aws.String(description)
+	}
+	if revisionID != "" {
+		input.RevisionId = aws.String(revisionID)
+	}
+	output, err := client.PublishVersion(ctx, input)
+	if err != nil {
+		return models.LambdaVersion{}, fmt.Errorf("publish version of %s: %w", functionName, preconditionError(err))
+	}
+	return models.LambdaVersion{
+		Version:       aws.ToString(output.Version),
+		Description:   aws.ToString(output.Description),
+		Runtime:       string(output.Runtime),
+		CodeSHA256:    aws.ToString(output.CodeSha256),
+		CodeSizeBytes: output.CodeSize,
+		State:         string(output.State),
+		LastModified:  aws.ToString(output.LastModified),
+	}, nil
+}
+
+// ValidateAliasRouting 檢查 alias 的目標版本與加權設定：加權只能在兩個不同的已發佈版本之間，比例介於 0 與 1 之間（不含）。
+func ValidateAliasRouting(alias models.LambdaAlias) error {
+	if alias.Version == "" {
+		return fmt.Errorf("alias %s needs a target version", alias.Name)
+	}
+	if alias.AdditionalVersion == "" {
+		return nil
+	}
+	switch {
+	case alias.Version == latestVersion || alias.AdditionalVersion == latestVersion:
+		return fmt.Errorf("weighted aliases must point to published versions, not %s", latestVersion)
+	case alias.AdditionalVersion == alias.Version:
+		return fmt.Errorf("additional version must differ from the primary version %s", alias.Version)
+	case alias.AdditionalWeight <= 0 || alias.AdditionalWeight >= 1:
+		return fmt.Errorf("weight of version %s must be between 0%% and 100%% (exclusive)", alias.AdditionalVersion)
+	}
+	return nil
+}
+
+// UpdateAlias 以 UpdateAlias 將 alias 指向 alias.Version，並設定（或清除）加權的額外版本。
+// revisionID 不為空時，alias 已被修改會回傳 ErrLambdaConfigChanged。
+func (r *LambdaRepository) UpdateAlias(ctx context.Context, client *lambda.Client, functionName string, alias models.LambdaAlias, revisionID string) (models.LambdaAlias, error) {
+	if client == nil {
+		return models.LambdaAlias{}, fmt.Errorf("lambda client is nil")
+	}
+	if err := ValidateAliasRouting(alias); err != nil {
+		return models.LambdaAlias{}, err
+	}
+	// 空的 AdditionalVersionWeights 會清除既有的加權
+	weights := map[string]float64{}
+	if alias.AdditionalVersion != "" {
+		weights[alias.AdditionalVersion] = alias.AdditionalWeight
+	}
+	input := &lambda.UpdateAliasInput{
+		FunctionName:    aws.String(functionName),
+		Name:            aws.String(alias.Name),
+		FunctionVersion: aws.String(alias.Version),
+		RoutingConfig:   &lambdatypes.AliasRoutingConfiguration{AdditionalVersionWeights: weights},
+	}
+	if revisionID != "" {
+		input.RevisionId = aws.String(revisionID)
+	}
+	output, err := client.UpdateAlias(ctx, input)
+	if err != nil {
+		return models.LambdaAlias{}, fmt.Errorf("update alias %s of %s: %w", alias.Name, functionName, preconditionError(err))
+	}
+	return convertLambdaAlias(lambdatypes.AliasConfiguration{
+		Name:            output.Name,
+		AliasArn:        output.AliasArn,
+		FunctionVersion: output.FunctionVersion,
+		Description:     output.Description,
+		RoutingConfig:   output.RoutingConfig,
+		RevisionId:      output.RevisionId,
+	}), nil
+}
+
+func convertLambdaVersion(v lambdatypes.FunctionConfiguration) models.LambdaVersion {
+	return models.LambdaVersion{
+		Version:       aws.ToString(v.Version),
+		Description:   aws.ToString(v.Description),
+		Runtime:       string(v.Runtime),
+		CodeSHA256:    aws.ToString(v.CodeSha256),
+		CodeSizeBytes: v.CodeSize,
+		State:         string(v.State),
+		LastModified:  aws.ToString(v.LastModified),
+	}
+}
+
+func convertLambdaAlias(a lambdatypes.AliasConfiguration) models.LambdaAlias {
+	alias := models.LambdaAlias{
+		Name:        aws.ToString(a.Name),
+		ARN:         aws.ToString(a.AliasArn),
+		Version:     aws.ToString(a.FunctionVersion),
+		Description: aws.ToString(a.Description),
+		RevisionID:  aws.ToString(a.RevisionId),
+	}
+	if a.RoutingConfig != nil {
+		// Lambda 目前只允許一個額外版本
+		for _, version := range slices.Sorted(maps.Keys(a.RoutingConfig.AdditionalVersionWeights)) {
+			alias.AdditionalVersion = version
+			alias.AdditionalWeight = a.RoutingConfig.AdditionalVersionWeights[version]
+			break
+		}
+	}
+	return alias
+}
@@ -42,6 +42,24 @@ func (r *RDSRepository) ListInstances(ctx context.Context, client *rds.Client, i
 	return instances, nil
 }
 
+// GetInstance 以 DescribeDBInstances 查詢單一 DB 執行個體。
+func (r *RDSRepository) GetInstance(ctx context.Context, client *rds.Client, dbInstanceID string) (models.RDSInstance, error) {
+	if client == nil {
+		return models.RDSInstance{}, fmt.Errorf("rds client is nil")
+	}
+
+	resp, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(dbInstanceID),
+	})
+	if err != nil {
+		return models.RDSInstance{}, fmt.Errorf("describe db instance %s: %w", dbInstanceID, err)
+	}
+	if len(resp.DBInstances) == 0 {
+		return models.RDSInstance{}, fmt.Errorf("db instance %s not found", dbInstanceID)
+	}
+	return convertRDSInstance(resp.DBInstances[0]), nil
+}
+
 func convertRDSInstance(inst rdstypes.DBInstance) models.RDSInstance {
 	var parameterGroups []string
 	for _, grp := range inst.DBParameterGroups {
@@ -57,8 +75,20 @@ func convertRDSInstance(inst rdstypes.DBInstance) models.RDSInstance {
 		}
 	}
 
+	tags := make(models.TagMap, len(inst.TagList))
+	for _, tag := range inst.TagList {
+		if tag.Key != nil {
+			tags[*tag.Key] = deref(tag.Value)
+		}
+	}
+
 	return models.RDSInstance{
 		ID:             deref(inst.DBInstanceIdentifier),
+		ARN:            deref(inst.DBInstanceArn),
+		Status:         deref(inst.DBInstanceStatus),
+		InstanceClass:  deref(inst.DBInstanceClass),
+		StorageGiB:     deref(inst.AllocatedStorage),
+		StorageType:    deref(inst.StorageType),
 		Engine:         deref(inst.Engine),
 		EngineVersion:  deref(inst.EngineVersion),
 		MultiAZ:        aws.ToBool(inst.MultiAZ),
@@ -66,7 +96,7 @@ func convertRDSInstance(inst rdstypes.DBInstance) models.RDSInstance {
 		SubnetGroup:    extractSubnetGroup(inst.DBSubnetGroup),
 		ParameterGroup: parameterGroups,
 		SecurityGroups: sg,
-		Tags:           nil,
+		Tags:           tags,
 	}
 }
 
@@ -0,0 +1,165 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
+	resolvertypes "github.com/aws/aws-sdk-go-v2/service/route53resolver/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// ResolverRepository 封裝 Route53 Resolver 查詢邏輯（endpoints、rules 與 query logging）。
+type ResolverRepository struct{}
+
+// NewResolverRepository 建立 ResolverRepository。
+func NewResolverRepository() *ResolverRepository {
+	return &ResolverRepository{}
+}
+
+// ListEndpoints 列出 region 內的 Resolver endpoints 與各 endpoint 的 IP 位址。
+func (r *ResolverRepository) ListEndpoints(ctx context.Context, client *route53resolver.Client) ([]models.ResolverEndpoint, error) {
+	if client == nil {
+		return nil, fmt.Errorf("route53resolver client is nil")
+	}
+
+	var endpoints []models.ResolverEndpoint
+	paginator := route53resolver.NewListResolverEndpointsPaginator(client, &route53resolver.ListResolverEndpointsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list resolver endpoints: %w", err)
+		}
+		for _, ep := range page.ResolverEndpoints {
+			endpoints = append(endpoints, models.ResolverEndpoint{
+				ID:        aws.ToString(ep.Id),
+				Name:      aws.ToString(ep.Name),
+				Direction: string(ep.Direction),
+				Status:    string(ep.Status),
+				VPCID:     aws.ToString(ep.HostVPCId),
+			})
+		}
+	}
+
+	for i := range endpoints {
+		ips := route53resolver.NewListResolverEndpointIpAddressesPaginator(client, &route53resolver.ListResolverEndpointIpAddressesInput{
+			ResolverEndpointId: aws.String(endpoints[i].ID),
+		})
+		for ips.HasMorePages() {
+			page, err := ips.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("list resolver endpoint ip addresses %s: %w", endpoints[i].ID, err)
+			}
+			for _, ip := range page.IpAddresses {
+				address := aws.ToString(ip.Ip)
+				if address == "" {
+					address = aws.ToString(ip.Ipv6)
+				}
+				endpoints[i].IPAddresses = append(endpoints[i].IPAddresses, fmt.Sprintf("%s (%s)", address, aws.ToString(ip.SubnetId)))
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// ListRules 列出 region 內的 Resolver rules，並依 rule association 填入關聯的 VPC。
+func (r *ResolverRepository) ListRules(ctx context.Context, client *route53resolver.Client) ([]models.ResolverRule, error) {
+	if client == nil {
+		return nil, fmt.Errorf("route53resolver client is nil")
+	}
+
+	var rules []models.ResolverRule
+	paginator := route53resolver.NewListResolverRulesPaginator(client, &route53resolver.ListResolverRulesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list resolver rules: %w", err)
+		}
+		for _, rule := range page.ResolverRules {
+			rules = append(rules, resolverRule(rule))
+		}
+	}
+
+	vpcs := make(map[string][]string)
+	associations := route53resolver.NewListResolverRuleAssociationsPaginator(client, &route53resolver.ListResolverRuleAssociationsInput{})
+	for associations.HasMorePages() {
+		page, err := associations.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list resolver rule associations: %w", err)
+		}
+		for _, assoc := range page.ResolverRuleAssociations {
+			ruleID := aws.ToString(assoc.ResolverRuleId)
+			vpcs[ruleID] = append(vpcs[ruleID], aws.ToString(assoc.VPCId))
+		}
+	}
+	for i := range rules {
+		rules[i].VPCs = vpcs[rules[i].ID]
+	}
+	return rules, nil
+}
+
+// ListQueryLogConfigs 列出 Resolver query logging 設定，並依 association 填入記錄查詢的 VPC。
+func (r *ResolverRepository) ListQueryLogConfigs(ctx context.Context, client *route53resolver.Client) ([]models.ResolverQueryLogConfig, error) {
+	if client == nil {
+		return nil, fmt.Errorf("route53resolver client is nil")
+	}
+
+	var configs []models.ResolverQueryLogConfig
+	paginator := route53resolver.NewListResolverQueryLogConfigsPaginator(client, &route53resolver.ListResolverQueryLogConfigsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list resolver query log configs: %w", err)
+		}
+		for _, cfg := range page.ResolverQueryLogConfigs {
+			configs = append(configs, models.ResolverQueryLogConfig{
+				ID:          aws.ToString(cfg.Id),
+				Name:        aws.ToString(cfg.Name),
+				Destination: aws.ToString(cfg.DestinationArn),
+				Status:      string(cfg.Status),
+			})
+		}
+	}
+
+	vpcs := make(map[string][]string)
+	associations := route53resolver.NewListResolverQueryLogConfigAssociationsPaginator(client, &route53resolver.ListResolverQueryLogConfigAssociationsInput{})
+	for associations.HasMorePages() {
+		page, err := associations.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list resolver query log config associations: %w", err)
+		}
+		for _, assoc := range page.ResolverQueryLogConfigAssociations {
+			configID := aws.ToString(assoc.ResolverQueryLogConfigId)
+			vpcs[configID] = append(vpcs[configID], aws.ToString(assoc.ResourceId))
+		}
+	}
+	for i := range configs {
+		configs[i].VPCs = vpcs[configs[i].ID]
+	}
+	return configs, nil
+}
+
+func resolverRule(rule resolvertypes.ResolverRule) models.ResolverRule {
+	result := models.ResolverRule{
+		ID:         aws.ToString(rule.Id),
+		Name:       aws.ToString(rule.Name),
+		DomainName: aws.ToString(rule.DomainName),
+		RuleType:   string(rule.RuleType),
+		Status:     string(rule.Status),
+		EndpointID: aws.ToString(rule.ResolverEndpointId),
+	}
+	for _, target := range rule.TargetIps {
+		ip := aws.ToString(target.Ip)
+		if ip == "" {
+			ip = "[" + aws.ToString(target.Ipv6) + "]"
+		}
+		if target.Port != nil {
+			ip += ":" + strconv.Itoa(int(*target.Port))
+		}
+		result.TargetIPs = append(result.TargetIPs, ip)
+	}
+	return result
+}
@@ -0,0 +1,307 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// maxRecordTTL 為 Route53 允許的最大 TTL（秒）。
+	maxRecordTTL = 2147483647
+	// maxRecordWeight 為加權路由允許的最大權重。
+	maxRecordWeight = 255
+	// maxChangesPerBatch 為單一 ChangeResourceRecordSets 批次允許的變更數。
+	maxChangesPerBatch = 1000
+)
+
+// ChangeRecords 以單一 ChangeResourceRecordSets 批次送出變更（Route53 會整批成功或整批失敗）。
+func (r *Route53Repository) ChangeRecords(ctx context.Context, client *route53.Client, zoneID, comment string, changes []models.Route53Change) (models.Route53ChangeInfo, error) {
+	if client == nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("route53 client is nil")
+	}
+	if len(changes) == 0 {
+		return models.Route53ChangeInfo{}, fmt.Errorf("no changes to submit")
+	}
+	if len(changes) > maxChangesPerBatch {
+		return models.Route53ChangeInfo{}, fmt.Errorf("%d changes exceed the Route53 limit of %d per batch", len(changes), maxChangesPerBatch)
+	}
+
+	batch := &types.ChangeBatch{Changes: make([]types.Change, len(changes))}
+	if comment != "" {
+		batch.Comment = aws.String(comment)
+	}
+	for i, change := range changes {
+		if err := ValidateRecord(change.Record); err != nil {
+			return models.Route53ChangeInfo{}, fmt.Errorf("%s %s %s: %w", change.Action, change.Record.Name, change.Record.Type, err)
+		}
+		batch.Changes[i] = types.Change{
+			Action:            types.ChangeAction(change.Action),
+			ResourceRecordSet: recordSetInput(change.Record),
+		}
+	}
+
+	output, err := client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  batch,
+	})
+	if err != nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("change resource record sets: %w", err)
+	}
+	return changeInfo(output.ChangeInfo), nil
+}
+
+// GetChange 查詢變更批次的狀態（PENDING 或 INSYNC）。
+func (r *Route53Repository) GetChange(ctx context.Context, client *route53.Client, changeID string) (models.Route53ChangeInfo, error) {
+	if client == nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("route53 client is nil")
+	}
+	output, err := client.GetChange(ctx, &route53.GetChangeInput{Id: aws.String(changeID)})
+	if err != nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("get change %s: %w", changeID, err)
+	}
+	return changeInfo(output.ChangeInfo), nil
+}
+
+// WaitForChange 每 interval 以 GetChange 查詢一次，直到狀態為 INSYNC 或 ctx 結束；
+// 每次查詢後以 onStatus 回報目前狀態。
+func (r *Route53Repository) WaitForChange(ctx context.Context, client *route53.Client, changeID string, interval time.Duration, onStatus func(info models.Route53ChangeInfo)) (models.Route53ChangeInfo, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		info, err := r.GetChange(ctx, client, changeID)
+		if err != nil {
+			return info, err
+		}
+		if onStatus != nil {
+			onStatus(info)
+		}
+		if info.Status == string(types.ChangeStatusInsync) {
+			return info, nil
+		}
+		select {
+		case <-ctx.Done():
+			return info, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RecordTypes 回傳 Route53 支援的 record 類型。
+func RecordTypes() []string {
+	values := types.RRType("").Values()
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// ValidateRecord 檢查 Route53 會拒絕的 record：alias 與一般值互斥，一般 record 需有值與 TTL，
+// A/AAAA 需為對應版本的 IP，CNAME 只能有一個值，MX/SRV 需符合欄位格式；
+// 路由政策最多一種且需搭配 SetIdentifier。
+func ValidateRecord(record models.Route53Record) error {
+	if strings.TrimSpace(record.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if !slices.Contains(RecordTypes(), record.Type) {
+		return fmt.Errorf("unsupported record type %q", record.Type)
+	}
+	if err := validateRouting(record); err != nil {
+		return err
+	}
+
+	if record.AliasTarget != "" || record.AliasZoneID != "" {
+		switch {
+		case record.AliasTarget == "" || record.AliasZoneID == "":
+			return fmt.Errorf("alias records need both a target DNS name and its hosted zone ID")
+		case len(record.Values) > 0:
+			return fmt.Errorf("alias records cannot have values")
+		}
+		return nil
+	}
+
+	if len(record.Values) == 0 {
+		return fmt.Errorf("at least one value is required")
+	}
+	if record.TTL < 0 || record.TTL > maxRecordTTL {
+		return fmt.Errorf("TTL must be between 0 and %d", maxRecordTTL)
+	}
+	if (record.Type == string(types.RRTypeCname) || record.Type == string(types.RRTypeSoa)) && len(record.Values) > 1 {
+		return fmt.Errorf("%s records can have only one value", record.Type)
+	}
+	for _, value := range record.Values {
+		if err := validateRecordValue(record.Type, value); err != nil {
+			return fmt.Errorf("value %q: %w", value, err)
+		}
+	}
+	return nil
+}
+
+func validateRouting(record models.Route53Record) error {
+	var policies []string
+	if record.Weight != nil {
+		policies = append(policies, "weight")
+		if *record.Weight < 0 || *record.Weight > maxRecordWeight {
+			return fmt.Errorf("weight must be between 0 and %d", maxRecordWeight)
+		}
+	}
+	if record.Region != "" {
+		policies = append(policies, "latency region")
+	}
+	if record.GeoLocation != nil {
+		policies = append(policies, "geolocation")
+		if *record.GeoLocation == (models.Route53GeoLocation{}) {
+			return fmt.Errorf("geolocation needs a continent, country or subdivision code")
+		}
+	}
+	if record.Failover != "" {
+		policies = append(policies, "failover")
+		if !slices.Contains(types.ResourceRecordSetFailover("").Values(), types.ResourceRecordSetFailover(record.Failover)) {
+			return fmt.Errorf("failover must be PRIMARY or SECONDARY")
+		}
+	}
+	if record.MultiValueAnswer {
+		policies = append(policies, "multivalue answer")
+		if record.AliasTarget != "" {
+			return fmt.Errorf("multivalue answer records cannot be aliases")
+		}
+	}
+	if cidr := record.CidrRouting; cidr != nil {
+		policies = append(policies, "IP-based")
+		if cidr.CollectionID == "" || cidr.LocationName == "" {
+			return fmt.Errorf("IP-based routing needs a CIDR collection ID and location name")
+		}
+	}
+	if geo := record.GeoProximity; geo != nil {
+		policies = append(policies, "geoproximity")
+		if geo.AWSRegion == "" && geo.LocalZoneGroup == "" && (geo.Latitude == "" || geo.Longitude == "") {
+			return fmt.Errorf("geoproximity needs an AWS region, local zone group or coordinates")
+		}
+	}
+
+	switch {
+	case len(policies) > 1:
+		return fmt.Errorf("only one routing policy is allowed, got %s", strings.Join(policies, " and "))
+	case len(policies) == 1 && record.SetIdentifier == "":
+		return fmt.Errorf("%s routing needs a set identifier", policies[0])
+	case len(policies) == 0 && record.SetIdentifier != "":
+		return fmt.Errorf("set identifier %q needs a routing policy", record.SetIdentifier)
+	}
+	return nil
+}
+
+func validateRecordValue(recordType, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("value must not be empty")
+	}
+	fields := strings.Fields(value)
+	switch types.RRType(recordType) {
+	case types.RRTypeA:
+		if addr, err := netip.ParseAddr(value); err != nil || !addr.Is4() {
+			return fmt.Errorf("not an IPv4 address")
+		}
+	case types.RRTypeAaaa:
+		if addr, err := netip.ParseAddr(value); err != nil || !addr.Is6() || addr.Is4In6() {
+			return fmt.Errorf("not an IPv6 address")
+		}
+	case types.RRTypeMx:
+		if len(fields) != 2 || !isUint16(fields[0]) {
+			return fmt.Errorf("MX values must be \"priority host\"")
+		}
+	case types.RRTypeSrv:
+		if len(fields) != 4 || !isUint16(fields[0]) || !isUint16(fields[1]) || !isUint16(fields[2]) {
+			return fmt.Errorf("SRV values must be \"priority weight port host\"")
+		}
+	}
+	return nil
+}
+
+func isUint16(value string) bool {
+	_, err := strconv.ParseUint(value, 10, 16)
+	return err == nil
+}
+
+// recordSetInput 將 record 轉為 API 形式；alias record 不帶 TTL 與值。
+// 路由政策欄位需完整帶入，DELETE 時 Route53 要求與現有 record 完全相符。
+func recordSetInput(record models.Route53Record) *types.ResourceRecordSet {
+	set := &types.ResourceRecordSet{
+		Name:          aws.String(record.Name),
+		Type:          types.RRType(record.Type),
+		SetIdentifier: optionalString(record.SetIdentifier),
+		Weight:        record.Weight,
+		Region:        types.ResourceRecordSetRegion(record.Region),
+		Failover:      types.ResourceRecordSetFailover(record.Failover),
+		HealthCheckId: optionalString(record.HealthCheckID),
+	}
+	if record.MultiValueAnswer {
+		set.MultiValueAnswer = aws.Bool(true)
+	}
+	if geo := record.GeoLocation; geo != nil {
+		set.GeoLocation = &types.GeoLocation{
+			ContinentCode:   optionalString(geo.ContinentCode),
+			CountryCode:     optionalString(geo.CountryCode),
+			SubdivisionCode: optionalString(geo.SubdivisionCode),
+		}
+	}
+	if cidr := record.CidrRouting; cidr != nil {
+		set.CidrRoutingConfig = &types.CidrRoutingConfig{
+			CollectionId: aws.String(cidr.CollectionID),
+			LocationName: aws.String(cidr.LocationName),
+		}
+	}
+	if geo := record.GeoProximity; geo != nil {
+		set.GeoProximityLocation = &types.GeoProximityLocation{
+			AWSRegion:      optionalString(geo.AWSRegion),
+			LocalZoneGroup: optionalString(geo.LocalZoneGroup),
+			Bias:           geo.Bias,
+		}
+		if geo.Latitude != "" || geo.Longitude != "" {
+			set.GeoProximityLocation.Coordinates = &types.Coordinates{
+				Latitude:  aws.String(geo.Latitude),
+				Longitude: aws.String(geo.Longitude),
+			}
+		}
+	}
+	if record.AliasTarget != "" {
+		set.AliasTarget = &types.AliasTarget{
+			DNSName:              aws.String(record.AliasTarget),
+			HostedZoneId:         aws.String(record.AliasZoneID),
+			EvaluateTargetHealth: record.EvaluateTargetHealth,
+		}
+		return set
+	}
+	set.TTL = aws.Int64(record.TTL)
+	for _, value := range record.Values {
+		set.ResourceRecords = append(set.ResourceRecords, types.ResourceRecord{Value: aws.String(value)})
+	}
+	return set
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return aws.String(value)
+}
+
+func changeInfo(info *types.ChangeInfo) models.Route53ChangeInfo {
+	if info == nil {
+		return models.Route53ChangeInfo{}
+	}
+	return models.Route53ChangeInfo{
+		ID:          strings.TrimPrefix(aws.ToString(info.Id), "/change/"),
+		Status:      string(info.Status),
+		SubmittedAt: aws.ToTime(info.SubmittedAt),
+	}
+}
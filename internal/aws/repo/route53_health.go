@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// healthCheckConcurrency 為同時查詢 health check 狀態的上限（Route53 API 每秒請求數有限）。
+const healthCheckConcurrency = 4
+
+// ListHealthChecks 列出所有 health checks，並平行查詢每個 checker 的最新觀測結果。
+// 個別狀態查詢失敗（例如 calculated health check 不支援）記錄在 Report，不影響其他 health check。
+func (r *Route53Repository) ListHealthChecks(ctx context.Context, client *route53.Client) ([]models.Route53HealthCheck, error) {
+	if client == nil {
+		return nil, fmt.Errorf("route53 client is nil")
+	}
+
+	var checks []models.Route53HealthCheck
+	paginator := route53.NewListHealthChecksPaginator(client, &route53.ListHealthChecksInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list health checks: %w", err)
+		}
+		for _, hc := range page.HealthChecks {
+			checks = append(checks, healthCheck(hc))
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(healthCheckConcurrency, len(checks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r.healthCheckStatus(ctx, client, &checks[i])
+			}
+		}()
+	}
+feed:
+	for i := range checks {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return checks, ctx.Err()
+}
+
+// healthCheckStatus 以 GetHealthCheckStatus 統計回報成功的 checker 數，並保留第一個失敗的報告。
+func (r *Route53Repository) healthCheckStatus(ctx context.Context, client *route53.Client, check *models.Route53HealthCheck) {
+	output, err := client.GetHealthCheckStatus(ctx, &route53.GetHealthCheckStatusInput{HealthCheckId: aws.String(check.ID)})
+	if err != nil {
+		check.Report = fmt.Sprintf("status unavailable: %v", err)
+		return
+	}
+	for _, observation := range output.HealthCheckObservations {
+		if observation.StatusReport == nil {
+			continue
+		}
+		check.Checkers++
+		status := aws.ToString(observation.StatusReport.Status)
+		if strings.HasPrefix(status, "Success") {
+			check.Healthy++
+		} else if check.Report == "" {
+			check.Report = status
+		}
+	}
+}
+
+func healthCheck(hc types.HealthCheck) models.Route53HealthCheck {
+	check := models.Route53HealthCheck{ID: aws.ToString(hc.Id)}
+	cfg := hc.HealthCheckConfig
+	if cfg == nil {
+		return check
+	}
+	check.Type = string(cfg.Type)
+	check.Disabled = aws.ToBool(cfg.Disabled)
+	check.Inverted = aws.ToBool(cfg.Inverted)
+
+	switch cfg.Type {
+	case types.HealthCheckTypeCalculated:
+		check.Target = fmt.Sprintf("%d of %d child checks", aws.ToInt32(cfg.HealthThreshold), len(cfg.ChildHealthChecks))
+	case types.HealthCheckTypeCloudwatchMetric:
+		if cfg.AlarmIdentifier != nil {
+			check.Target = "alarm " + aws.ToString(cfg.AlarmIdentifier.Name)
+		}
+	case types.HealthCheckTypeRecoveryControl:
+		check.Target = aws.ToString(cfg.RoutingControlArn)
+	default:
+		host := aws.ToString(cfg.FullyQualifiedDomainName)
+		if host == "" {
+			host = aws.ToString(cfg.IPAddress)
+		}
+		if cfg.Port != nil {
+			host += ":" + strconv.Itoa(int(*cfg.Port))
+		}
+		switch cfg.Type {
+		case types.HealthCheckTypeHttp, types.HealthCheckTypeHttpStrMatch:
+			check.Target = "http://" + host + aws.ToString(cfg.ResourcePath)
+		case types.HealthCheckTypeHttps, types.HealthCheckTypeHttpsStrMatch:
+			check.Target = "https://" + host + aws.ToString(cfg.ResourcePath)
+		default:
+			check.Target = host
+		}
+	}
+	return check
+}
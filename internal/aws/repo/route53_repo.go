@@ -90,6 +90,40 @@ func (r *Route53Repository) ListRecords(ctx context.Context, client *route53.Cli
 			// 處理 Alias record
 			if rr.AliasTarget != nil {
 				record.AliasTarget = aws.ToString(rr.AliasTarget.DNSName)
+				record.AliasZoneID = aws.ToString(rr.AliasTarget.HostedZoneId)
+				record.EvaluateTargetHealth = rr.AliasTarget.EvaluateTargetHealth
+			}
+
+			// 路由政策
+			record.SetIdentifier = aws.ToString(rr.SetIdentifier)
+			record.Weight = rr.Weight
+			record.Region = string(rr.Region)
+			record.Failover = string(rr.Failover)
+			record.MultiValueAnswer = aws.ToBool(rr.MultiValueAnswer)
+			record.HealthCheckID = aws.ToString(rr.HealthCheckId)
+			if rr.GeoLocation != nil {
+				record.GeoLocation = &models.Route53GeoLocation{
+					ContinentCode:   aws.ToString(rr.GeoLocation.ContinentCode),
+					CountryCode:     aws.ToString(rr.GeoLocation.CountryCode),
+					SubdivisionCode: aws.ToString(rr.GeoLocation.SubdivisionCode),
+				}
+			}
+			if rr.CidrRoutingConfig != nil {
+				record.CidrRouting = &models.Route53CidrRouting{
+					CollectionID: aws.ToString(rr.CidrRoutingConfig.CollectionId),
+					LocationName: aws.ToString(rr.CidrRoutingConfig.LocationName),
+				}
+			}
+			if geo := rr.GeoProximityLocation; geo != nil {
+				record.GeoProximity = &models.Route53GeoProximity{
+					AWSRegion:      aws.ToString(geo.AWSRegion),
+					LocalZoneGroup: aws.ToString(geo.LocalZoneGroup),
+					Bias:           geo.Bias,
+				}
+				if geo.Coordinates != nil {
+					record.GeoProximity.Latitude = aws.ToString(geo.Coordinates.Latitude)
+					record.GeoProximity.Longitude = aws.ToString(geo.Coordinates.Longitude)
+				}
 			}
 
 			records = append(records, record)
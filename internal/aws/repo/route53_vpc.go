@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// GetHostedZone 取得單一 Hosted Zone；private zone 會一併回傳關聯的 VPC。
+func (r *Route53Repository) GetHostedZone(ctx context.Context, client *route53.Client, zoneID string) (models.Route53HostedZone, error) {
+	if client == nil {
+		return models.Route53HostedZone{}, fmt.Errorf("route53 client is nil")
+	}
+	output, err := client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return models.Route53HostedZone{}, fmt.Errorf("get hosted zone: %w", err)
+	}
+
+	zone := models.Route53HostedZone{ID: zoneID}
+	if hz := output.HostedZone; hz != nil {
+		zone.ID = strings.TrimPrefix(aws.ToString(hz.Id), "/hostedzone/")
+		zone.Name = aws.ToString(hz.Name)
+		zone.RecordCount = aws.ToInt64(hz.ResourceRecordSetCount)
+		if hz.Config != nil {
+			zone.IsPrivate = hz.Config.PrivateZone
+			zone.Comment = aws.ToString(hz.Config.Comment)
+		}
+	}
+	for _, vpc := range output.VPCs {
+		zone.VPCs = append(zone.VPCs, models.Route53VPC{ID: aws.ToString(vpc.VPCId), Region: string(vpc.VPCRegion)})
+	}
+	return zone, nil
+}
+
+// AssociateVPC 將 VPC 關聯到 private hosted zone；跨帳號的 VPC 需先由 zone 擁有者授權。
+func (r *Route53Repository) AssociateVPC(ctx context.Context, client *route53.Client, zoneID string, vpc models.Route53VPC) (models.Route53ChangeInfo, error) {
+	if client == nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("route53 client is nil")
+	}
+	if err := validateVPC(vpc); err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	output, err := client.AssociateVPCWithHostedZone(ctx, &route53.AssociateVPCWithHostedZoneInput{
+		HostedZoneId: aws.String(zoneID),
+		VPC:          &types.VPC{VPCId: aws.String(vpc.ID), VPCRegion: types.VPCRegion(vpc.Region)},
+	})
+	if err != nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("associate vpc with hosted zone: %w", err)
+	}
+	return changeInfo(output.ChangeInfo), nil
+}
+
+// DisassociateVPC 解除 VPC 與 private hosted zone 的關聯；Route53 不允許移除最後一個 VPC。
+func (r *Route53Repository) DisassociateVPC(ctx context.Context, client *route53.Client, zoneID string, vpc models.Route53VPC) (models.Route53ChangeInfo, error) {
+	if client == nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("route53 client is nil")
+	}
+	if err := validateVPC(vpc); err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	output, err := client.DisassociateVPCFromHostedZone(ctx, &route53.DisassociateVPCFromHostedZoneInput{
+		HostedZoneId: aws.String(zoneID),
+		VPC:          &types.VPC{VPCId: aws.String(vpc.ID), VPCRegion: types.VPCRegion(vpc.Region)},
+	})
+	if err != nil {
+		return models.Route53ChangeInfo{}, fmt.Errorf("disassociate vpc from hosted zone: %w", err)
+	}
+	return changeInfo(output.ChangeInfo), nil
+}
+
+func validateVPC(vpc models.Route53VPC) error {
+	if !strings.HasPrefix(vpc.ID, "vpc-") {
+		return fmt.Errorf("invalid VPC ID %q", vpc.ID)
+	}
+	if vpc.Region == "" {
+		return fmt.Errorf("VPC region is required")
+	}
+	return nil
+}
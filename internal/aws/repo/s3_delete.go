@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// maxDeleteBatch 為單次 DeleteObjects 可刪除的 key 數上限。
+const maxDeleteBatch = 1000
+
+// DeleteObjects 以每批最多 1000 個 key 呼叫 DeleteObjects（Quiet 模式），回傳已處理的 key 數與個別刪除失敗的 key。
+// progress 於每批完成後回報已處理的 key 數；API 呼叫本身失敗時立即回傳錯誤，已處理數與失敗項目只含先前的批次。
+func (r *S3Repository) DeleteObjects(ctx context.Context, client *s3.Client, bucket string, keys []string, progress func(done int)) (int, []models.S3DeleteError, error) {
+	if client == nil {
+		return 0, nil, fmt.Errorf("s3 client is nil")
+	}
+	var failed []models.S3DeleteError
+	for start := 0; start < len(keys); start += maxDeleteBatch {
+		batch := keys[start:min(start+maxDeleteBatch, len(keys))]
+		objects := make([]types.ObjectIdentifier, len(batch))
+		for i, key := range batch {
+			objects[i] = types.ObjectIdentifier{Key: aws.String(key)}
+		}
+		resp, err := client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return start, failed, fmt.Errorf("delete objects: %w", err)
+		}
+		for _, e := range resp.Errors {
+			failed = append(failed, models.S3DeleteError{
+				Key:     aws.ToString(e.Key),
+				Code:    aws.ToString(e.Code),
+				Message: aws.ToString(e.Message),
+			})
+		}
+		if progress != nil {
+			progress(start + len(batch))
+		}
+	}
+	return len(keys), failed, nil
+}
@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// MaxPresignExpiry 為 SigV4 presigned URL 的最長有效期限。
+const MaxPresignExpiry = 7 * 24 * time.Hour
+
+// PresignObject 為物件產生 GET（下載）或 PUT（上傳）presigned URL。
+// URL 於本機簽署，不會呼叫 S3；使用暫時性憑證時 URL 最晚在憑證到期時失效。
+func (r *S3Repository) PresignObject(ctx context.Context, client *s3.Client, bucket, key, method string, expires time.Duration) (models.S3PresignedURL, error) {
+	if client == nil {
+		return models.S3PresignedURL{}, fmt.Errorf("s3 client is nil")
+	}
+	if key == "" {
+		return models.S3PresignedURL{}, fmt.Errorf("object key is required")
+	}
+	if expires <= 0 || expires > MaxPresignExpiry {
+		return models.S3PresignedURL{}, fmt.Errorf("expiry must be between 1s and %s", MaxPresignExpiry)
+	}
+
+	presigner := s3.NewPresignClient(client, s3.WithPresignExpires(expires))
+	var (
+		req *v4.PresignedHTTPRequest
+		err error
+	)
+	switch method {
+	case http.MethodGet:
+		req, err = presigner.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	case http.MethodPut:
+		req, err = presigner.PresignPutObject(ctx, &s3.PutObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	default:
+		return models.S3PresignedURL{}, fmt.Errorf("unsupported presign method %q", method)
+	}
+	if err != nil {
+		return models.S3PresignedURL{}, fmt.Errorf("presign %s %s: %w", method, key, err)
+	}
+
+	url := models.S3PresignedURL{
+		URL:       req.URL,
+		Method:    method,
+		Key:       key,
+		ExpiresAt: time.Now().Add(expires),
+	}
+	// 憑證已由簽署流程取得並快取，再次取得不會發出額外請求
+	if provider := client.Options().Credentials; provider != nil {
+		if creds, err := provider.Retrieve(ctx); err == nil && creds.CanExpire {
+			url.CredentialsExpireAt = creds.Expires
+		}
+	}
+	return url, nil
+}
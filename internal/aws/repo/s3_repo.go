@@ -2,25 +2,66 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
 
 	"github.com/vincent119/awsGUITools/internal/models"
 )
 
-type S3Repository struct{}
+// defaultBucketConcurrency 為同時查詢 bucket 設定的預設上限。
+const defaultBucketConcurrency = 8
+
+// S3ClientFunc 回傳指定 region 的 S3 client，用於存取不在目前 region 的 bucket。
+type S3ClientFunc func(ctx context.Context, region string) (*s3.Client, error)
+
+type S3Repository struct {
+	concurrency int
+	partSize    int64
+}
 
 func NewS3Repository() *S3Repository {
-	return &S3Repository{}
+	return &S3Repository{concurrency: defaultBucketConcurrency, partSize: defaultPartSize}
+}
+
+// SetConcurrency 設定同時查詢 bucket 設定的上限；小於 1 時使用預設值。
+func (r *S3Repository) SetConcurrency(n int) {
+	if n < 1 {
+		n = defaultBucketConcurrency
+	}
+	r.concurrency = n
 }
 
-// ListObjects 列出 bucket 中指定 prefix 下的物件與子目錄。
+// ListObjects 列出 bucket 中指定 prefix 下的所有物件與子目錄（會跟隨 continuation token 取完所有分頁）。
 func (r *S3Repository) ListObjects(ctx context.Context, client *s3.Client, bucket, prefix string) ([]models.S3Object, error) {
+	var (
+		objects []models.S3Object
+		token   string
+	)
+	for {
+		page, err := r.ListObjectsPage(ctx, client, bucket, prefix, token, 1000)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Objects...)
+		if page.NextToken == "" {
+			return objects, nil
+		}
+		token = page.NextToken
+	}
+}
+
+// ListObjectsPage 列出 bucket 中指定 prefix 下的一頁物件與子目錄。
+// token 為空字串時取得第一頁；pageSize 同時計入子目錄與物件。
+func (r *S3Repository) ListObjectsPage(ctx context.Context, client *s3.Client, bucket, prefix, token string, pageSize int32) (models.S3ObjectPage, error) {
 	if client == nil {
-		return nil, fmt.Errorf("s3 client is nil")
+		return models.S3ObjectPage{}, fmt.Errorf("s3 client is nil")
 	}
 
 	// 確保 prefix 以 / 結尾（除了空字串）
@@ -28,17 +69,21 @@ func (r *S3Repository) ListObjects(ctx context.Context, client *s3.Client, bucke
 		prefix += "/"
 	}
 
-	resp, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+	input := &s3.ListObjectsV2Input{
 		Bucket:    aws.String(bucket),
 		Prefix:    aws.String(prefix),
 		Delimiter: aws.String("/"),
-		MaxKeys:   aws.Int32(1000),
-	})
+		MaxKeys:   aws.Int32(pageSize),
+	}
+	if token != "" {
+		input.ContinuationToken = aws.String(token)
+	}
+	resp, err := client.ListObjectsV2(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("list objects: %w", err)
+		return models.S3ObjectPage{}, fmt.Errorf("list objects: %w", err)
 	}
 
-	var objects []models.S3Object
+	var page models.S3ObjectPage
 
 	// 先加入目錄（CommonPrefixes）
 	for _, cp := range resp.CommonPrefixes {
@@ -47,7 +92,7 @@ func (r *S3Repository) ListObjects(ctx context.Context, client *s3.Client, bucke
 		displayName := strings.TrimPrefix(dirName, prefix)
 		displayName = strings.TrimSuffix(displayName, "/")
 		if displayName != "" {
-			objects = append(objects, models.S3Object{
+			page.Objects = append(page.Objects, models.S3Object{
 				Key:         dirName,
 				IsDirectory: true,
 			})
@@ -61,7 +106,7 @@ func (r *S3Repository) ListObjects(ctx context.Context, client *s3.Client, bucke
 		if key == prefix {
 			continue
 		}
-		objects = append(objects, models.S3Object{
+		page.Objects = append(page.Objects, models.S3Object{
 			Key:          key,
 			Size:         aws.ToInt64(obj.Size),
 			LastModified: obj.LastModified.Format("2006-01-02 15:04:05"),
@@ -70,10 +115,14 @@ func (r *S3Repository) ListObjects(ctx context.Context, client *s3.Client, bucke
 		})
 	}
 
-	return objects, nil
+	if aws.ToBool(resp.IsTruncated) {
+		page.NextToken = aws.ToString(resp.NextContinuationToken)
+	}
+	return page, nil
 }
 
-func (r *S3Repository) ListBuckets(ctx context.Context, client *s3.Client) ([]models.S3Bucket, error) {
+// ListBucketNames 只列出 bucket 名稱，不查詢各 bucket 的設定（Enriched 為 false）。
+func (r *S3Repository) ListBucketNames(ctx context.Context, client *s3.Client) ([]models.S3Bucket, error) {
 	if client == nil {
 		return nil, fmt.Errorf("s3 client is nil")
 	}
@@ -83,38 +132,225 @@ func (r *S3Repository) ListBuckets(ctx context.Context, client *s3.Client) ([]mo
 		return nil, fmt.Errorf("list buckets: %w", err)
 	}
 
-	var buckets []models.S3Bucket
+	buckets := make([]models.S3Bucket, 0, len(resp.Buckets))
 	for _, bucket := range resp.Buckets {
-		name := deref(bucket.Name)
-		location, _ := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
-			Bucket: bucket.Name,
-		})
-		versioning, _ := client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
-			Bucket: bucket.Name,
-		})
-		encryption, _ := client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
-			Bucket: bucket.Name,
-		})
-		lifecycle, _ := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
-			Bucket: bucket.Name,
-		})
-		policy, _ := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
-			Bucket: bucket.Name,
-		})
-
-		buckets = append(buckets, models.S3Bucket{
-			Name:       name,
-			Region:     resolveLocation(location),
-			Versioning: versioningStatus(versioning),
-			Encryption: encryptionAlgo(encryption),
-			Lifecycle:  lifecycleString(lifecycle),
-			Policy:     policyString(policy),
-		})
+		buckets = append(buckets, models.S3Bucket{Name: deref(bucket.Name)})
 	}
+	return buckets, nil
+}
 
+// ListBuckets 列出 bucket 並平行查詢各 bucket 的設定，輸出順序與 ListBuckets API 相同。
+// regional 用於取得 bucket 所在 region 的 client；nil 時全部使用 client。
+func (r *S3Repository) ListBuckets(ctx context.Context, client *s3.Client, regional S3ClientFunc) ([]models.S3Bucket, error) {
+	buckets, err := r.ListBucketNames(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	r.EnrichBuckets(ctx, client, regional, buckets, nil)
 	return buckets, nil
 }
 
+// EnrichBuckets 以最多 concurrency 個 worker 平行查詢 location、versioning、encryption、
+// lifecycle、policy、tags 與公開存取相關設定，結果直接寫回 buckets[i]，因此順序不變。
+// location 以 client 查詢，其餘設定改用 regional 取得的 bucket 所在 region client。
+// 每完成一個 bucket 會呼叫 onDone（可為 nil；呼叫不會重疊）。
+// 個別查詢失敗記錄在 S3Bucket.Errors，不影響其他 bucket；context 取消後未開始的 bucket 維持未補齊。
+func (r *S3Repository) EnrichBuckets(ctx context.Context, client *s3.Client, regional S3ClientFunc, buckets []models.S3Bucket, onDone func(index int, bucket models.S3Bucket)) {
+	if client == nil || len(buckets) == 0 {
+		return
+	}
+
+	workers := min(r.concurrency, len(buckets))
+	jobs := make(chan int)
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r.enrichBucket(ctx, client, regional, &buckets[i])
+				if onDone != nil {
+					mu.Lock()
+					onDone(i, buckets[i])
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range buckets {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// GetBucket 以 HeadBucket 確認 bucket 存在，再查詢設定與標籤。
+// client 需為 bucket 所在 region 的 client（可先以 BucketRegion 取得）。
+func (r *S3Repository) GetBucket(ctx context.Context, client *s3.Client, bucket string) (models.S3Bucket, error) {
+	if client == nil {
+		return models.S3Bucket{}, fmt.Errorf("s3 client is nil")
+	}
+
+	if _, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	}); err != nil {
+		return models.S3Bucket{}, fmt.Errorf("head bucket %s: %w", bucket, err)
+	}
+
+	info := models.S3Bucket{Name: bucket}
+	r.enrichBucket(ctx, client, nil, &info)
+	r.describeSecurity(ctx, client, &info)
+	r.describeRules(ctx, client, &info)
+	return info, nil
+}
+
+// BucketRegion 取得 bucket 所在 region。HeadBucket 可從任何 region 呼叫，
+// bucket 不在 client 的 region 時會回傳 301，但 x-amz-bucket-region header 仍帶有正確 region。
+func (r *S3Repository) BucketRegion(ctx context.Context, client *s3.Client, bucket string) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("s3 client is nil")
+	}
+
+	head, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err == nil && aws.ToString(head.BucketRegion) != "" {
+		return aws.ToString(head.BucketRegion), nil
+	}
+	if region, ok := RedirectRegion(err); ok {
+		return region, nil
+	}
+
+	location, locErr := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket),
+	})
+	if locErr != nil {
+		return "", fmt.Errorf("resolve region of bucket %s: %w", bucket, locErr)
+	}
+	return resolveLocation(location), nil
+}
+
+// RedirectRegion 從 301 / PermanentRedirect 錯誤取出 bucket 實際所在的 region。
+func RedirectRegion(err error) (string, bool) {
+	var respErr *awshttp.ResponseError
+	if !errors.As(err, &respErr) || respErr.Response == nil {
+		return "", false
+	}
+	region := respErr.Response.Header.Get("X-Amz-Bucket-Region")
+	return region, region != ""
+}
+
+// enrichBucket 依序查詢單一 bucket 的設定。
+// 未設定時 API 會回傳 NoSuchBucketPolicy 等錯誤，視為未設定；其餘錯誤記錄於 Errors。
+// 取得 location 後，若 bucket 不在 client 的 region，改用 regional 取得的 client。
+func (r *S3Repository) enrichBucket(ctx context.Context, client *s3.Client, regional S3ClientFunc, bucket *models.S3Bucket) {
+	name := aws.String(bucket.Name)
+	record := func(operation string, err error) {
+		recordLookup(bucket, operation, err)
+	}
+
+	location, err := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: name,
+	})
+	if region, ok := RedirectRegion(err); ok {
+		bucket.Region, err = region, nil
+	} else if err == nil {
+		bucket.Region = resolveLocation(location)
+	}
+	record("GetBucketLocation", err)
+	if err == nil && regional != nil && bucket.Region != client.Options().Region {
+		regionClient, err := regional(ctx, bucket.Region)
+		record("NewRegionalClient", err)
+		if err == nil {
+			client = regionClient
+		}
+	}
+
+	versioning, err := client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
+		Bucket: name,
+	})
+	record("GetBucketVersioning", err)
+	if err == nil {
+		bucket.Versioning = versioningStatus(versioning)
+	}
+
+	encryption, err := client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+		Bucket: name,
+	})
+	record("GetBucketEncryption", err)
+	bucket.Encryption = encryptionAlgo(encryption)
+
+	lifecycle, err := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: name,
+	})
+	record("GetBucketLifecycleConfiguration", err)
+	bucket.Lifecycle = lifecycleString(lifecycle)
+	if lifecycle != nil {
+		bucket.LifecycleRules = lifecycleRules(lifecycle.Rules)
+	}
+
+	policy, err := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: name,
+	})
+	record("GetBucketPolicy", err)
+	bucket.Policy = policyString(policy)
+
+	tagging, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: name,
+	})
+	record("GetBucketTagging", err)
+	if tagging != nil && len(tagging.TagSet) > 0 {
+		bucket.Tags = make(models.TagMap, len(tagging.TagSet))
+		for _, tag := range tagging.TagSet {
+			bucket.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+
+	r.enrichSecurity(ctx, client, bucket)
+	bucket.Enriched = true
+}
+
+// recordLookup 將查詢失敗記錄於 bucket.Errors；未設定（NoSuchBucketPolicy 等）不算失敗。
+func recordLookup(bucket *models.S3Bucket, operation string, err error) {
+	if err == nil || isNotConfigured(err) {
+		return
+	}
+	if bucket.Errors == nil {
+		bucket.Errors = make(map[string]string)
+	}
+	bucket.Errors[operation] = err.Error()
+}
+
+// notConfiguredCodes 為「尚未設定」而非真正失敗的錯誤代碼。
+var notConfiguredCodes = map[string]bool{
+	"NoSuchBucketPolicy":                             true,
+	"NoSuchLifecycleConfiguration":                   true,
+	"NoSuchTagSet":                                   true,
+	"ServerSideEncryptionConfigurationNotFoundError": true,
+	"NoSuchPublicAccessBlockConfiguration":           true,
+	"OwnershipControlsNotFoundError":                 true,
+	"ReplicationConfigurationNotFoundError":          true,
+	"ObjectLockConfigurationNotFoundError":           true,
+	"NoSuchCORSConfiguration":                        true,
+}
+
+func isNotConfigured(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return notConfiguredCodes[apiErr.ErrorCode()]
+	}
+	return false
+}
+
 func resolveLocation(output *s3.GetBucketLocationOutput) string {
 	if output == nil || output.LocationConstraint == "" {
 		return "us-east-1"
@@ -123,7 +359,7 @@ func resolveLocation(output *s3.GetBucketLocationOutput) string {
 }
 
 func versioningStatus(output *s3.GetBucketVersioningOutput) string {
-	if output == nil {
+	if output == nil || output.Status == "" {
 		return "Disabled"
 	}
 	return string(output.Status)
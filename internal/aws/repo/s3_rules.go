@@ -0,0 +1,536 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// maxLifecycleRules 與 maxCORSRules 為 S3 允許的規則數上限。
+	maxLifecycleRules = 1000
+	maxCORSRules      = 100
+	maxRuleIDLength   = 255
+)
+
+// corsMethods 為 CORS 規則允許的 HTTP 方法。
+var corsMethods = []string{"GET", "PUT", "POST", "DELETE", "HEAD"}
+
+// describeRules 查詢只在詳情顯示的 CORS 規則；lifecycle 規則於 enrichBucket 取得，複寫規則於 describeSecurity 取得。
+func (r *S3Repository) describeRules(ctx context.Context, client *s3.Client, bucket *models.S3Bucket) {
+	rules, err := r.GetCORSRules(ctx, client, bucket.Name)
+	recordLookup(bucket, "GetBucketCors", err)
+	bucket.CORSRules = rules
+}
+
+// GetLifecycleRules 取得 bucket 的 lifecycle 規則；未設定時回傳空切片。
+func (r *S3Repository) GetLifecycleRules(ctx context.Context, client *s3.Client, bucket string) ([]models.S3LifecycleRule, error) {
+	if client == nil {
+		return nil, fmt.Errorf("s3 client is nil")
+	}
+	output, err := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if isNotConfigured(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get lifecycle of %s: %w", bucket, err)
+	}
+	return lifecycleRules(output.Rules), nil
+}
+
+// PutLifecycleRules 驗證後以 PutBucketLifecycleConfiguration 取代全部 lifecycle 規則；
+// rules 為空時刪除 lifecycle 設定。bucket 原有的 TransitionDefaultMinimumObjectSize 會保留。
+func (r *S3Repository) PutLifecycleRules(ctx context.Context, client *s3.Client, bucket string, rules []models.S3LifecycleRule) error {
+	if client == nil {
+		return fmt.Errorf("s3 client is nil")
+	}
+	if err := ValidateLifecycleRules(rules); err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		if _, err := client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(bucket)}); err != nil {
+			return fmt.Errorf("delete lifecycle of %s: %w", bucket, err)
+		}
+		return nil
+	}
+
+	apiRules := make([]types.LifecycleRule, len(rules))
+	for i, rule := range rules {
+		apiRules[i] = lifecycleRuleInput(rule)
+	}
+	input := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucket),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: apiRules},
+		ChecksumAlgorithm:      types.ChecksumAlgorithmCrc32,
+	}
+	current, err := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucket)})
+	if err == nil {
+		input.TransitionDefaultMinimumObjectSize = current.TransitionDefaultMinimumObjectSize
+	}
+	if _, err := client.PutBucketLifecycleConfiguration(ctx, input); err != nil {
+		return fmt.Errorf("put lifecycle of %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// GetCORSRules 取得 bucket 的 CORS 規則；未設定時回傳空切片。
+func (r *S3Repository) GetCORSRules(ctx context.Context, client *s3.Client, bucket string) ([]models.S3CORSRule, error) {
+	if client == nil {
+		return nil, fmt.Errorf("s3 client is nil")
+	}
+	output, err := client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: aws.String(bucket)})
+	if isNotConfigured(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get cors of %s: %w", bucket, err)
+	}
+	rules := make([]models.S3CORSRule, len(output.CORSRules))
+	for i, rule := range output.CORSRules {
+		rules[i] = models.S3CORSRule{
+			ID:             aws.ToString(rule.ID),
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  aws.ToInt32(rule.MaxAgeSeconds),
+		}
+	}
+	return rules, nil
+}
+
+// PutCORSRules 驗證後以 PutBucketCors 取代全部 CORS 規則；rules 為空時刪除 CORS 設定。
+func (r *S3Repository) PutCORSRules(ctx context.Context, client *s3.Client, bucket string, rules []models.S3CORSRule) error {
+	if client == nil {
+		return fmt.Errorf("s3 client is nil")
+	}
+	if err := ValidateCORSRules(rules); err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		if _, err := client.DeleteBucketCors(ctx, &s3.DeleteBucketCorsInput{Bucket: aws.String(bucket)}); err != nil {
+			return fmt.Errorf("delete cors of %s: %w", bucket, err)
+		}
+		return nil
+	}
+
+	apiRules := make([]types.CORSRule, len(rules))
+	for i, rule := range rules {
+		apiRules[i] = types.CORSRule{
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+		}
+		if rule.ID != "" {
+			apiRules[i].ID = aws.String(rule.ID)
+		}
+		if rule.MaxAgeSeconds > 0 {
+			apiRules[i].MaxAgeSeconds = aws.Int32(rule.MaxAgeSeconds)
+		}
+	}
+	if _, err := client.PutBucketCors(ctx, &s3.PutBucketCorsInput{
+		Bucket:            aws.String(bucket),
+		CORSConfiguration: &types.CORSConfiguration{CORSRules: apiRules},
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+	}); err != nil {
+		return fmt.Errorf("put cors of %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// ValidateLifecycleRules 檢查 S3 會拒絕的 lifecycle 設定，錯誤訊息指出規則 ID 與欄位。
+func ValidateLifecycleRules(rules []models.S3LifecycleRule) error {
+	if len(rules) > maxLifecycleRules {
+		return fmt.Errorf("at most %d lifecycle rules are allowed, got %d", maxLifecycleRules, len(rules))
+	}
+	seen := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if err := validateRuleID(rule.ID, true, seen); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		if err := validateLifecycleRule(rule); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+func validateLifecycleRule(rule models.S3LifecycleRule) error {
+	if rule.Status != string(types.ExpirationStatusEnabled) && rule.Status != string(types.ExpirationStatusDisabled) {
+		return fmt.Errorf("status must be Enabled or Disabled, got %q", rule.Status)
+	}
+	if rule.ObjectSizeGreaterThan < 0 || rule.ObjectSizeLessThan < 0 {
+		return fmt.Errorf("object size filters must not be negative")
+	}
+	if rule.ObjectSizeGreaterThan > 0 && rule.ObjectSizeLessThan > 0 && rule.ObjectSizeGreaterThan >= rule.ObjectSizeLessThan {
+		return fmt.Errorf("object_size_greater_than must be less than object_size_less_than")
+	}
+
+	hasExpiration := rule.ExpirationDays != 0 || rule.ExpirationDate != "" || rule.ExpiredObjectDeleteMarker
+	if len(rule.Transitions) == 0 && !hasExpiration && len(rule.NoncurrentTransitions) == 0 &&
+		rule.NoncurrentExpirationDays == 0 && rule.AbortIncompleteUploadDays == 0 {
+		return fmt.Errorf("at least one action (transition, expiration or abort_incomplete_upload_days) is required")
+	}
+
+	set := 0
+	for _, present := range []bool{rule.ExpirationDays != 0, rule.ExpirationDate != "", rule.ExpiredObjectDeleteMarker} {
+		if present {
+			set++
+		}
+	}
+	if set > 1 {
+		return fmt.Errorf("expiration_days, expiration_date and expired_object_delete_marker are mutually exclusive")
+	}
+	if rule.ExpirationDays < 0 {
+		return fmt.Errorf("expiration_days must be positive")
+	}
+	if rule.ExpirationDate != "" {
+		if _, err := parseRuleDate(rule.ExpirationDate); err != nil {
+			return fmt.Errorf("expiration_date: %w", err)
+		}
+	}
+	if len(rule.Tags) > 0 && rule.ExpiredObjectDeleteMarker {
+		return fmt.Errorf("expired_object_delete_marker cannot be used with a tag filter")
+	}
+	if len(rule.Tags) > 0 && rule.AbortIncompleteUploadDays != 0 {
+		return fmt.Errorf("abort_incomplete_upload_days cannot be used with a tag filter")
+	}
+	if rule.AbortIncompleteUploadDays < 0 {
+		return fmt.Errorf("abort_incomplete_upload_days must be positive")
+	}
+
+	// days 省略（0）表示建立後立即轉換
+	dated := 0
+	for i, t := range rule.Transitions {
+		if t.Days != 0 && t.Date != "" {
+			return fmt.Errorf("transition %d: days and date are mutually exclusive", i+1)
+		}
+		if t.Days < 0 {
+			return fmt.Errorf("transition %d: days must not be negative", i+1)
+		}
+		if t.Date != "" {
+			if _, err := parseRuleDate(t.Date); err != nil {
+				return fmt.Errorf("transition %d date: %w", i+1, err)
+			}
+			dated++
+		}
+		if t.NewerNoncurrentVersions != 0 {
+			return fmt.Errorf("transition %d: newer_noncurrent_versions is only valid for noncurrent transitions", i+1)
+		}
+		if err := validateTransitionClass(t.StorageClass, t.Days); err != nil {
+			return fmt.Errorf("transition %d: %w", i+1, err)
+		}
+		if rule.ExpirationDays != 0 && t.Date == "" && t.Days >= rule.ExpirationDays {
+			return fmt.Errorf("transition %d: days (%d) must be less than expiration_days (%d)", i+1, t.Days, rule.ExpirationDays)
+		}
+	}
+	if dated > 0 && dated < len(rule.Transitions) {
+		return fmt.Errorf("transitions cannot mix days and dates")
+	}
+
+	for i, t := range rule.NoncurrentTransitions {
+		if t.Date != "" {
+			return fmt.Errorf("noncurrent transition %d: date is not supported, use days", i+1)
+		}
+		if t.Days <= 0 {
+			return fmt.Errorf("noncurrent transition %d: days must be positive", i+1)
+		}
+		if t.NewerNoncurrentVersions < 0 || t.NewerNoncurrentVersions > 100 {
+			return fmt.Errorf("noncurrent transition %d: newer_noncurrent_versions must be between 1 and 100", i+1)
+		}
+		if err := validateTransitionClass(t.StorageClass, t.Days); err != nil {
+			return fmt.Errorf("noncurrent transition %d: %w", i+1, err)
+		}
+		if rule.NoncurrentExpirationDays != 0 && t.Days >= rule.NoncurrentExpirationDays {
+			return fmt.Errorf("noncurrent transition %d: days (%d) must be less than noncurrent_expiration_days (%d)", i+1, t.Days, rule.NoncurrentExpirationDays)
+		}
+	}
+	if rule.NoncurrentExpirationDays < 0 {
+		return fmt.Errorf("noncurrent_expiration_days must be positive")
+	}
+	if rule.NewerNoncurrentVersions != 0 {
+		if rule.NoncurrentExpirationDays == 0 {
+			return fmt.Errorf("newer_noncurrent_versions requires noncurrent_expiration_days")
+		}
+		if rule.NewerNoncurrentVersions < 1 || rule.NewerNoncurrentVersions > 100 {
+			return fmt.Errorf("newer_noncurrent_versions must be between 1 and 100")
+		}
+	}
+	return nil
+}
+
+// validateTransitionClass 檢查 storage class 是否可作為轉換目標；IA 類別至少需 30 天。
+func validateTransitionClass(class string, days int32) error {
+	valid := types.TransitionStorageClass("").Values()
+	if !slices.Contains(valid, types.TransitionStorageClass(class)) {
+		names := make([]string, len(valid))
+		for i, v := range valid {
+			names[i] = string(v)
+		}
+		return fmt.Errorf("storage_class %q is not one of %s", class, strings.Join(names, ", "))
+	}
+	ia := class == string(types.TransitionStorageClassStandardIa) || class == string(types.TransitionStorageClassOnezoneIa)
+	if ia && days != 0 && days < 30 {
+		return fmt.Errorf("transition to %s requires at least 30 days, got %d", class, days)
+	}
+	return nil
+}
+
+// ValidateCORSRules 檢查 S3 會拒絕的 CORS 設定。
+func ValidateCORSRules(rules []models.S3CORSRule) error {
+	if len(rules) > maxCORSRules {
+		return fmt.Errorf("at most %d CORS rules are allowed, got %d", maxCORSRules, len(rules))
+	}
+	seen := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		name := fmt.Sprintf("rule %d", i+1)
+		if rule.ID != "" {
+			name = fmt.Sprintf("rule %q", rule.ID)
+		}
+		if err := validateRuleID(rule.ID, false, seen); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if len(rule.AllowedOrigins) == 0 {
+			return fmt.Errorf("%s: allowed_origins is required", name)
+		}
+		if len(rule.AllowedMethods) == 0 {
+			return fmt.Errorf("%s: allowed_methods is required", name)
+		}
+		for _, method := range rule.AllowedMethods {
+			if !slices.Contains(corsMethods, method) {
+				return fmt.Errorf("%s: method %q is not one of %s", name, method, strings.Join(corsMethods, ", "))
+			}
+		}
+		for _, value := range append(slices.Clone(rule.AllowedOrigins), rule.AllowedHeaders...) {
+			if strings.Count(value, "*") > 1 {
+				return fmt.Errorf("%s: %q may contain at most one wildcard", name, value)
+			}
+		}
+		if rule.MaxAgeSeconds < 0 {
+			return fmt.Errorf("%s: max_age_seconds must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// validateRuleID 檢查規則 ID 長度與重複；required 為 false 時允許空白（由 S3 自動產生）。
+func validateRuleID(id string, required bool, seen map[string]bool) error {
+	switch {
+	case id == "" && required:
+		return fmt.Errorf("id is required")
+	case len(id) > maxRuleIDLength:
+		return fmt.Errorf("id must be at most %d characters", maxRuleIDLength)
+	case id != "" && seen[id]:
+		return fmt.Errorf("duplicate id %q", id)
+	}
+	seen[id] = true
+	return nil
+}
+
+// parseRuleDate 解析 YYYY-MM-DD；S3 要求日期為 UTC 午夜。
+func parseRuleDate(value string) (time.Time, error) {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a YYYY-MM-DD date", value)
+	}
+	return t, nil
+}
+
+func formatRuleDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.DateOnly)
+}
+
+// lifecycleRules 將 API 規則轉為可編輯的形式；舊式的規則層 Prefix 視同 Filter.Prefix。
+func lifecycleRules(apiRules []types.LifecycleRule) []models.S3LifecycleRule {
+	rules := make([]models.S3LifecycleRule, len(apiRules))
+	for i, api := range apiRules {
+		rule := models.S3LifecycleRule{
+			ID:     aws.ToString(api.ID),
+			Status: string(api.Status),
+			Prefix: aws.ToString(api.Prefix),
+		}
+		if f := api.Filter; f != nil {
+			if f.Prefix != nil {
+				rule.Prefix = aws.ToString(f.Prefix)
+			}
+			if f.Tag != nil {
+				rule.Tags = map[string]string{aws.ToString(f.Tag.Key): aws.ToString(f.Tag.Value)}
+			}
+			rule.ObjectSizeGreaterThan = aws.ToInt64(f.ObjectSizeGreaterThan)
+			rule.ObjectSizeLessThan = aws.ToInt64(f.ObjectSizeLessThan)
+			if and := f.And; and != nil {
+				rule.Prefix = aws.ToString(and.Prefix)
+				rule.ObjectSizeGreaterThan = aws.ToInt64(and.ObjectSizeGreaterThan)
+				rule.ObjectSizeLessThan = aws.ToInt64(and.ObjectSizeLessThan)
+				if len(and.Tags) > 0 {
+					rule.Tags = make(map[string]string, len(and.Tags))
+					for _, tag := range and.Tags {
+						rule.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+					}
+				}
+			}
+		}
+		for _, t := range api.Transitions {
+			rule.Transitions = append(rule.Transitions, models.S3LifecycleTransition{
+				Days:         aws.ToInt32(t.Days),
+				Date:         formatRuleDate(t.Date),
+				StorageClass: string(t.StorageClass),
+			})
+		}
+		if exp := api.Expiration; exp != nil {
+			rule.ExpirationDays = aws.ToInt32(exp.Days)
+			rule.ExpirationDate = formatRuleDate(exp.Date)
+			rule.ExpiredObjectDeleteMarker = aws.ToBool(exp.ExpiredObjectDeleteMarker)
+		}
+		for _, t := range api.NoncurrentVersionTransitions {
+			rule.NoncurrentTransitions = append(rule.NoncurrentTransitions, models.S3LifecycleTransition{
+				Days:                    aws.ToInt32(t.NoncurrentDays),
+				StorageClass:            string(t.StorageClass),
+				NewerNoncurrentVersions: aws.ToInt32(t.NewerNoncurrentVersions),
+			})
+		}
+		if exp := api.NoncurrentVersionExpiration; exp != nil {
+			rule.NoncurrentExpirationDays = aws.ToInt32(exp.NoncurrentDays)
+			rule.NewerNoncurrentVersions = aws.ToInt32(exp.NewerNoncurrentVersions)
+		}
+		if abort := api.AbortIncompleteMultipartUpload; abort != nil {
+			rule.AbortIncompleteUploadDays = aws.ToInt32(abort.DaysAfterInitiation)
+		}
+		rules[i] = rule
+	}
+	return rules
+}
+
+// lifecycleRuleInput 將已驗證的規則轉為 API 形式；Filter 只有單一條件時直接設定，多個條件時使用 And。
+func lifecycleRuleInput(rule models.S3LifecycleRule) types.LifecycleRule {
+	api := types.LifecycleRule{
+		ID:     aws.String(rule.ID),
+		Status: types.ExpirationStatus(rule.Status),
+		Filter: lifecycleFilter(rule),
+	}
+	for _, t := range rule.Transitions {
+		transition := types.Transition{StorageClass: types.TransitionStorageClass(t.StorageClass)}
+		if t.Date != "" {
+			date, _ := parseRuleDate(t.Date)
+			transition.Date = aws.Time(date)
+		} else {
+			transition.Days = aws.Int32(t.Days)
+		}
+		api.Transitions = append(api.Transitions, transition)
+	}
+	switch {
+	case rule.ExpirationDays != 0:
+		api.Expiration = &types.LifecycleExpiration{Days: aws.Int32(rule.ExpirationDays)}
+	case rule.ExpirationDate != "":
+		date, _ := parseRuleDate(rule.ExpirationDate)
+		api.Expiration = &types.LifecycleExpiration{Date: aws.Time(date)}
+	case rule.ExpiredObjectDeleteMarker:
+		api.Expiration = &types.LifecycleExpiration{ExpiredObjectDeleteMarker: aws.Bool(true)}
+	}
+	for _, t := range rule.NoncurrentTransitions {
+		transition := types.NoncurrentVersionTransition{
+			NoncurrentDays: aws.Int32(t.Days),
+			StorageClass:   types.TransitionStorageClass(t.StorageClass),
+		}
+		if t.NewerNoncurrentVersions != 0 {
+			transition.NewerNoncurrentVersions = aws.Int32(t.NewerNoncurrentVersions)
+		}
+		api.NoncurrentVersionTransitions = append(api.NoncurrentVersionTransitions, transition)
+	}
+	if rule.NoncurrentExpirationDays != 0 {
+		api.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{NoncurrentDays: aws.Int32(rule.NoncurrentExpirationDays)}
+		if rule.NewerNoncurrentVersions != 0 {
+			api.NoncurrentVersionExpiration.NewerNoncurrentVersions = aws.Int32(rule.NewerNoncurrentVersions)
+		}
+	}
+	if rule.AbortIncompleteUploadDays != 0 {
+		api.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int32(rule.AbortIncompleteUploadDays)}
+	}
+	return api
+}
+
+func lifecycleFilter(rule models.S3LifecycleRule) *types.LifecycleRuleFilter {
+	keys := make([]string, 0, len(rule.Tags))
+	for key := range rule.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]types.Tag, len(keys))
+	for i, key := range keys {
+		tags[i] = types.Tag{Key: aws.String(key), Value: aws.String(rule.Tags[key])}
+	}
+
+	var greater, less *int64
+	if rule.ObjectSizeGreaterThan > 0 {
+		greater = aws.Int64(rule.ObjectSizeGreaterThan)
+	}
+	if rule.ObjectSizeLessThan > 0 {
+		less = aws.Int64(rule.ObjectSizeLessThan)
+	}
+
+	conditions := len(tags)
+	for _, set := range []bool{rule.Prefix != "", greater != nil, less != nil} {
+		if set {
+			conditions++
+		}
+	}
+	switch {
+	case conditions > 1:
+		and := &types.LifecycleRuleAndOperator{Tags: tags, ObjectSizeGreaterThan: greater, ObjectSizeLessThan: less}
+		if rule.Prefix != "" {
+			and.Prefix = aws.String(rule.Prefix)
+		}
+		return &types.LifecycleRuleFilter{And: and}
+	case len(tags) == 1:
+		return &types.LifecycleRuleFilter{Tag: &tags[0]}
+	case greater != nil || less != nil:
+		return &types.LifecycleRuleFilter{ObjectSizeGreaterThan: greater, ObjectSizeLessThan: less}
+	}
+	// 空的 Prefix 代表套用到整個 bucket
+	return &types.LifecycleRuleFilter{Prefix: aws.String(rule.Prefix)}
+}
+
+// replicationRules 將複寫設定轉為唯讀摘要。
+func replicationRules(cfg *types.ReplicationConfiguration) []models.S3ReplicationRule {
+	rules := make([]models.S3ReplicationRule, len(cfg.Rules))
+	for i, api := range cfg.Rules {
+		rule := models.S3ReplicationRule{
+			ID:       aws.ToString(api.ID),
+			Status:   string(api.Status),
+			Priority: aws.ToInt32(api.Priority),
+			Prefix:   aws.ToString(api.Prefix),
+		}
+		if f := api.Filter; f != nil {
+			switch {
+			case f.Prefix != nil:
+				rule.Prefix = aws.ToString(f.Prefix)
+			case f.And != nil:
+				rule.Prefix = aws.ToString(f.And.Prefix)
+			}
+		}
+		if dest := api.Destination; dest != nil {
+			rule.Destination = strings.TrimPrefix(aws.ToString(dest.Bucket), "arn:aws:s3:::")
+			rule.StorageClass = string(dest.StorageClass)
+		}
+		if dm := api.DeleteMarkerReplication; dm != nil {
+			rule.DeleteMarkerReplication = dm.Status == types.DeleteMarkerReplicationStatusEnabled
+		}
+		rules[i] = rule
+	}
+	return rules
+}
@@ -0,0 +1,361 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	allUsersURI           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersURI = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// enrichSecurity 查詢判斷 bucket 是否公開所需的設定（Block Public Access、ownership、ACL、policy status），
+// 需在 bucket.Policy 取得後呼叫。
+func (r *S3Repository) enrichSecurity(ctx context.Context, client *s3.Client, bucket *models.S3Bucket) {
+	name := aws.String(bucket.Name)
+	sec := &bucket.Security
+
+	pab, err := client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: name})
+	recordLookup(bucket, "GetPublicAccessBlock", err)
+	if err == nil && pab.PublicAccessBlockConfiguration != nil {
+		cfg := pab.PublicAccessBlockConfiguration
+		sec.PublicAccessBlock = models.S3PublicAccessBlock{
+			Configured:            true,
+			BlockPublicAcls:       aws.ToBool(cfg.BlockPublicAcls),
+			IgnorePublicAcls:      aws.ToBool(cfg.IgnorePublicAcls),
+			BlockPublicPolicy:     aws.ToBool(cfg.BlockPublicPolicy),
+			RestrictPublicBuckets: aws.ToBool(cfg.RestrictPublicBuckets),
+		}
+	}
+
+	ownership, err := client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{Bucket: name})
+	recordLookup(bucket, "GetBucketOwnershipControls", err)
+	if err == nil && ownership.OwnershipControls != nil && len(ownership.OwnershipControls.Rules) > 0 {
+		sec.ObjectOwnership = string(ownership.OwnershipControls.Rules[0].ObjectOwnership)
+	}
+
+	acl, err := client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: name})
+	recordLookup(bucket, "GetBucketAcl", err)
+	if err == nil {
+		sec.Owner = ownerName(acl.Owner)
+		sec.Grants = aclGrants(acl.Grants)
+	}
+
+	if bucket.Policy != "" {
+		findings, err := AnalyzePolicy(bucket.Policy)
+		recordLookup(bucket, "ParseBucketPolicy", err)
+		sec.PolicyFindings = findings
+
+		status, err := client.GetBucketPolicyStatus(ctx, &s3.GetBucketPolicyStatusInput{Bucket: name})
+		recordLookup(bucket, "GetBucketPolicyStatus", err)
+		if err == nil && status.PolicyStatus != nil && status.PolicyStatus.IsPublic != nil {
+			sec.PolicyIsPublic = aws.Bool(aws.ToBool(status.PolicyStatus.IsPublic))
+		}
+	}
+
+	EvaluatePublic(sec)
+}
+
+// CallerAccount 以 GetCallerIdentity 取得目前 credentials 所屬的帳號 ID。
+func CallerAccount(ctx context.Context, client *sts.Client) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("sts client is nil")
+	}
+	output, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("get caller identity: %w", err)
+	}
+	return aws.ToString(output.Account), nil
+}
+
+// GetAccountPublicAccessBlock 查詢帳號層級的 Block Public Access；未設定時回傳 Configured 為 false。
+func (r *S3Repository) GetAccountPublicAccessBlock(ctx context.Context, client *s3control.Client, accountID string) (models.S3PublicAccessBlock, error) {
+	if client == nil {
+		return models.S3PublicAccessBlock{}, fmt.Errorf("s3control client is nil")
+	}
+	output, err := client.GetPublicAccessBlock(ctx, &s3control.GetPublicAccessBlockInput{AccountId: aws.String(accountID)})
+	if isNotConfigured(err) {
+		return models.S3PublicAccessBlock{}, nil
+	}
+	if err != nil {
+		return models.S3PublicAccessBlock{}, fmt.Errorf("get account public access block: %w", err)
+	}
+	cfg := output.PublicAccessBlockConfiguration
+	if cfg == nil {
+		return models.S3PublicAccessBlock{}, nil
+	}
+	return models.S3PublicAccessBlock{
+		Configured:            true,
+		BlockPublicAcls:       aws.ToBool(cfg.BlockPublicAcls),
+		IgnorePublicAcls:      aws.ToBool(cfg.IgnorePublicAcls),
+		BlockPublicPolicy:     aws.ToBool(cfg.BlockPublicPolicy),
+		RestrictPublicBuckets: aws.ToBool(cfg.RestrictPublicBuckets),
+	}, nil
+}
+
+// EffectivePublicAccessBlock 合併 bucket 與帳號層級的 Block Public Access：任一層級開啟的項目即生效。
+func EffectivePublicAccessBlock(bucket, account models.S3PublicAccessBlock) models.S3PublicAccessBlock {
+	return models.S3PublicAccessBlock{
+		Configured:            bucket.Configured || account.Configured,
+		BlockPublicAcls:       bucket.BlockPublicAcls || account.BlockPublicAcls,
+		IgnorePublicAcls:      bucket.IgnorePublicAcls || account.IgnorePublicAcls,
+		BlockPublicPolicy:     bucket.BlockPublicPolicy || account.BlockPublicPolicy,
+		RestrictPublicBuckets: bucket.RestrictPublicBuckets || account.RestrictPublicBuckets,
+	}
+}
+
+// describeSecurity 查詢只在詳情顯示的設定：存取記錄、複寫與 Object Lock。
+func (r *S3Repository) describeSecurity(ctx context.Context, client *s3.Client, bucket *models.S3Bucket) {
+	name := aws.String(bucket.Name)
+	sec := &bucket.Security
+
+	logging, err := client.GetBucketLogging(ctx, &s3.GetBucketLoggingInput{Bucket: name})
+	recordLookup(bucket, "GetBucketLogging", err)
+	if err == nil {
+		sec.Logging = "Disabled"
+		if target := logging.LoggingEnabled; target != nil {
+			sec.Logging = fmt.Sprintf("s3://%s/%s", aws.ToString(target.TargetBucket), aws.ToString(target.TargetPrefix))
+		}
+	}
+
+	replication, err := client.GetBucketReplication(ctx, &s3.GetBucketReplicationInput{Bucket: name})
+	recordLookup(bucket, "GetBucketReplication", err)
+	if err == nil && replication.ReplicationConfiguration != nil {
+		sec.Replication = replicationSummary(replication.ReplicationConfiguration)
+		bucket.ReplicationRules = replicationRules(replication.ReplicationConfiguration)
+	}
+
+	lock, err := client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{Bucket: name})
+	recordLookup(bucket, "GetObjectLockConfiguration", err)
+	if err == nil && lock.ObjectLockConfiguration != nil {
+		sec.ObjectLock = objectLockSummary(lock.ObjectLockConfiguration)
+	}
+	sec.Described = true
+}
+
+// AnalyzePolicy 解析 bucket policy JSON，回傳 Principal 為 "*"（或 AWS: "*"）以及以 NotPrincipal 允許的 Allow 陳述。
+func AnalyzePolicy(policy string) ([]models.S3PolicyFinding, error) {
+	statements, err := parsePolicyStatements(policy)
+	if err != nil {
+		return nil, fmt.Errorf("parse bucket policy: %w", err)
+	}
+
+	var findings []models.S3PolicyFinding
+	for _, st := range statements {
+		if !strings.EqualFold(st.Effect, "Allow") {
+			continue
+		}
+		if !isWildcardPrincipal(st.Principal) && len(st.NotPrincipal) == 0 {
+			continue
+		}
+		finding := models.S3PolicyFinding{
+			Sid:         st.Sid,
+			Effect:      st.Effect,
+			Actions:     stringOrList(st.Action),
+			Conditional: len(st.Condition) > 0 && string(st.Condition) != "{}",
+		}
+		if !isWildcardPrincipal(st.Principal) {
+			finding.NotPrincipal, finding.Excluded = true, principalValues(st.NotPrincipal)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// EvaluatePublic 依 ACL、policy 與 Block Public Access（bucket 與帳號層級合併）判斷 bucket 是否實際公開。
+// ACL 在 IgnorePublicAcls 或 BucketOwnerEnforced（停用 ACL）時無效；
+// 公開的 policy 在 RestrictPublicBuckets 時只允許 AWS 服務與同帳號存取。
+// S3 的 GetBucketPolicyStatus 結果優先於自行解析的 policy；有 Condition 的陳述不視為公開。
+func EvaluatePublic(sec *models.S3BucketSecurity) {
+	sec.Public, sec.PublicReasons = false, nil
+	pab := EffectivePublicAccessBlock(sec.PublicAccessBlock, sec.AccountPublicAccessBlock)
+
+	aclsApply := !pab.IgnorePublicAcls && sec.ObjectOwnership != string(types.ObjectOwnershipBucketOwnerEnforced)
+	for _, grant := range sec.Grants {
+		if grant.Public && aclsApply {
+			sec.PublicReasons = append(sec.PublicReasons, fmt.Sprintf("ACL grants %s to %s", grant.Permission, grant.Grantee))
+		}
+	}
+
+	policyPublic := false
+	if sec.PolicyIsPublic != nil {
+		policyPublic = *sec.PolicyIsPublic
+	} else {
+		for _, f := range sec.PolicyFindings {
+			if !f.Conditional {
+				policyPublic = true
+				break
+			}
+		}
+	}
+	if policyPublic && !pab.RestrictPublicBuckets {
+		sec.PublicReasons = append(sec.PublicReasons, "bucket policy allows access to any principal")
+	}
+	sec.Public = len(sec.PublicReasons) > 0
+}
+
+type policyStatement struct {
+	Sid          string
+	Effect       string
+	Principal    json.RawMessage
+	NotPrincipal json.RawMessage
+	Action       json.RawMessage
+	Condition    json.RawMessage
+}
+
+// parsePolicyStatements 解析 IAM policy JSON 的陳述；Statement 可為單一物件或陣列。
+func parsePolicyStatements(policy string) ([]policyStatement, error) {
+	var doc struct {
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, err
+	}
+	var statements []policyStatement
+	if err := json.Unmarshal(doc.Statement, &statements); err != nil {
+		var single policyStatement
+		if err := json.Unmarshal(doc.Statement, &single); err != nil {
+			return nil, fmt.Errorf("statements: %w", err)
+		}
+		statements = []policyStatement{single}
+	}
+	return statements, nil
+}
+
+// isWildcardPrincipal 判斷 Principal 是否為 "*" 或 {"AWS": "*"}（或包含 "*" 的陣列）。
+func isWildcardPrincipal(raw json.RawMessage) bool {
+	if len(raw) == 0 {
+		return false
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s == "*"
+	}
+	var m map[string]json.RawMessage
+	if json.Unmarshal(raw, &m) != nil {
+		return false
+	}
+	for _, value := range stringOrList(m["AWS"]) {
+		if value == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// principalValues 回傳 Principal/NotPrincipal 中列出的所有主體（"*" 或各類型 {"AWS": ..., "Service": ...} 的值）。
+func principalValues(raw json.RawMessage) []string {
+	if values := stringOrList(raw); values != nil {
+		return values
+	}
+	var m map[string]json.RawMessage
+	if json.Unmarshal(raw, &m) != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var values []string
+	for _, key := range keys {
+		values = append(values, stringOrList(m[key])...)
+	}
+	return values
+}
+
+func stringOrList(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return []string{s}
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	return nil
+}
+
+func ownerName(owner *types.Owner) string {
+	if owner == nil {
+		return ""
+	}
+	if name := aws.ToString(owner.DisplayName); name != "" {
+		return name
+	}
+	return aws.ToString(owner.ID)
+}
+
+func aclGrants(grants []types.Grant) []models.S3Grant {
+	out := make([]models.S3Grant, 0, len(grants))
+	for _, grant := range grants {
+		if grant.Grantee == nil {
+			continue
+		}
+		g := models.S3Grant{Permission: string(grant.Permission)}
+		switch uri := aws.ToString(grant.Grantee.URI); {
+		case uri == allUsersURI:
+			g.Grantee, g.Public = "AllUsers", true
+		case uri == authenticatedUsersURI:
+			g.Grantee, g.Public = "AuthenticatedUsers", true
+		case uri != "":
+			g.Grantee = uri
+		case aws.ToString(grant.Grantee.DisplayName) != "":
+			g.Grantee = aws.ToString(grant.Grantee.DisplayName)
+		case aws.ToString(grant.Grantee.EmailAddress) != "":
+			g.Grantee = aws.ToString(grant.Grantee.EmailAddress)
+		default:
+			g.Grantee = aws.ToString(grant.Grantee.ID)
+		}
+		out = append(out, g)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Public && !out[j].Public })
+	return out
+}
+
+func replicationSummary(cfg *types.ReplicationConfiguration) string {
+	destinations := make([]string, 0, len(cfg.Rules))
+	enabled := 0
+	for _, rule := range cfg.Rules {
+		if rule.Status == types.ReplicationRuleStatusEnabled {
+			enabled++
+		}
+		if rule.Destination != nil {
+			destinations = append(destinations, strings.TrimPrefix(aws.ToString(rule.Destination.Bucket), "arn:aws:s3:::"))
+		}
+	}
+	return fmt.Sprintf("%d/%d rules enabled → %s", enabled, len(cfg.Rules), strings.Join(destinations, ", "))
+}
+
+func objectLockSummary(cfg *types.ObjectLockConfiguration) string {
+	if cfg.ObjectLockEnabled == "" {
+		return ""
+	}
+	summary := string(cfg.ObjectLockEnabled)
+	if cfg.Rule == nil || cfg.Rule.DefaultRetention == nil {
+		return summary
+	}
+	retention := cfg.Rule.DefaultRetention
+	switch {
+	case aws.ToInt32(retention.Days) > 0:
+		return fmt.Sprintf("%s (%s, %d days)", summary, retention.Mode, aws.ToInt32(retention.Days))
+	case aws.ToInt32(retention.Years) > 0:
+		return fmt.Sprintf("%s (%s, %d years)", summary, retention.Mode, aws.ToInt32(retention.Years))
+	}
+	return summary
+}
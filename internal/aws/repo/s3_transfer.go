@@ -0,0 +1,402 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// defaultPartSize 為分段上傳/下載每段的預設大小。
+	defaultPartSize int64 = 8 << 20
+	// minPartSize 為 S3 分段上傳除最後一段外的最小段大小。
+	minPartSize int64 = 5 << 20
+	// maxParts 為 S3 分段上傳的段數上限。
+	maxParts = 10000
+	// transferConcurrency 為單一物件同時傳輸的段數。
+	transferConcurrency = 4
+	// downloadFileMode 為下載檔案的權限；os.CreateTemp 建立的暫存檔為 0600，更名前改為一般檔案的權限。
+	downloadFileMode os.FileMode = 0o644
+)
+
+// ProgressFunc 回報新傳輸的位元組數；分段傳輸時可能由多個 goroutine 同時呼叫。
+type ProgressFunc func(n int64)
+
+// SetPartSize 設定分段傳輸的段大小；超過段大小的物件才會分段，小於 1 時使用預設值。
+func (r *S3Repository) SetPartSize(size int64) {
+	if size < 1 {
+		size = defaultPartSize
+	}
+	r.partSize = size
+}
+
+// HeadObject 取得單一物件的大小、Content-Type 與使用者自訂 metadata。
+func (r *S3Repository) HeadObject(ctx context.Context, client *s3.Client, bucket, key string) (models.S3ObjectInfo, error) {
+	if client == nil {
+		return models.S3ObjectInfo{}, fmt.Errorf("s3 client is nil")
+	}
+	resp, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return models.S3ObjectInfo{}, fmt.Errorf("head object %s: %w", key, err)
+	}
+	return models.S3ObjectInfo{
+		S3Object: models.S3Object{
+			Key:          key,
+			Size:         aws.ToInt64(resp.ContentLength),
+			LastModified: formatTime(resp.LastModified),
+			StorageClass: string(resp.StorageClass),
+		},
+		ContentType:     aws.ToString(resp.ContentType),
+		ContentEncoding: aws.ToString(resp.ContentEncoding),
+		ETag:            aws.ToString(resp.ETag),
+		VersionID:       aws.ToString(resp.VersionId),
+		Encryption:      string(resp.ServerSideEncryption),
+		Metadata:        resp.Metadata,
+	}, nil
+}
+
+// GetObjectHead 以 Range 讀取物件的前 n 個位元組（物件較小時回傳完整內容）。
+func (r *S3Repository) GetObjectHead(ctx context.Context, client *s3.Client, bucket, key string, n int64) ([]byte, error) {
+	if client == nil {
+		return nil, fmt.Errorf("s3 client is nil")
+	}
+	resp, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=0-%d", n-1)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, n))
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// ListKeys 遞迴列出 prefix 下的所有物件（不含目錄標記），依 key 排序。
+func (r *S3Repository) ListKeys(ctx context.Context, client *s3.Client, bucket, prefix string) ([]models.S3Object, error) {
+	return r.listKeys(ctx, client, bucket, prefix, false)
+}
+
+// ListAllKeys 與 ListKeys 相同，但包含目錄標記（IsDirectory 為 true），供刪除整個 prefix 使用。
+func (r *S3Repository) ListAllKeys(ctx context.Context, client *s3.Client, bucket, prefix string) ([]models.S3Object, error) {
+	return r.listKeys(ctx, client, bucket, prefix, true)
+}
+
+func (r *S3Repository) listKeys(ctx context.Context, client *s3.Client, bucket, prefix string, markers bool) ([]models.S3Object, error) {
+	if client == nil {
+		return nil, fmt.Errorf("s3 client is nil")
+	}
+	var objects []models.S3Object
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list keys: %w", err)
+		}
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if key == "" {
+				continue
+			}
+			// 主控台建立的資料夾會以 0 byte、/ 結尾的物件表示
+			marker := key[len(key)-1] == '/'
+			if marker && !markers {
+				continue
+			}
+			objects = append(objects, models.S3Object{
+				Key:          key,
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: formatTime(obj.LastModified),
+				StorageClass: string(obj.StorageClass),
+				IsDirectory:  marker,
+			})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// DownloadObject 下載物件到 dest；versionID 為空字串時下載最新版本。
+// size 超過段大小時以 Range 分段平行下載。先寫入暫存檔，完成後才更名為 dest，失敗或取消時刪除暫存檔。
+func (r *S3Repository) DownloadObject(ctx context.Context, client *s3.Client, bucket, key, versionID string, size int64, dest string, progress ProgressFunc) error {
+	if client == nil {
+		return fmt.Errorf("s3 client is nil")
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.part")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	done := false
+	defer func() {
+		if !done {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	partSize := r.effectivePartSize(size)
+	if size <= partSize {
+		err = r.downloadRange(ctx, client, bucket, key, versionID, "", tmp, 0, progress)
+	} else {
+		err = forEachPart(ctx, size, partSize, func(ctx context.Context, _ int32, offset, length int64) error {
+			rng := fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+			return r.downloadRange(ctx, client, bucket, key, versionID, rng, tmp, offset, progress)
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if err := tmp.Chmod(downloadFileMode); err != nil {
+		return fmt.Errorf("chmod %s: %w", dest, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", dest, err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return fmt.Errorf("rename %s: %w", dest, err)
+	}
+	done = true
+	return nil
+}
+
+func (r *S3Repository) downloadRange(ctx context.Context, client *s3.Client, bucket, key, versionID, rng string, file *os.File, offset int64, progress ProgressFunc) error {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+	if rng != "" {
+		input.Range = aws.String(rng)
+	}
+	resp, err := client.GetObject(ctx, input)
+	if err != nil {
+		return fmt.Errorf("get object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	w := &progressWriter{w: io.NewOffsetWriter(file, offset), progress: progress}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("download %s: %w", key, err)
+	}
+	return nil
+}
+
+// UploadFile 上傳本機檔案為 key；檔案超過段大小時使用分段上傳，失敗或取消時會中止分段上傳。
+func (r *S3Repository) UploadFile(ctx context.Context, client *s3.Client, bucket, key, src string, progress ProgressFunc) error {
+	if client == nil {
+		return fmt.Errorf("s3 client is nil")
+	}
+	file, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", src, err)
+	}
+
+	size := info.Size()
+	partSize := r.effectivePartSize(size)
+	if size <= partSize {
+		_, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:        aws.String(bucket),
+			Key:           aws.String(key),
+			Body:          &progressReader{r: io.NewSectionReader(file, 0, size), progress: progress},
+			ContentLength: aws.Int64(size),
+		})
+		if err != nil {
+			return fmt.Errorf("put object %s: %w", key, err)
+		}
+		return nil
+	}
+	return r.uploadMultipart(ctx, client, bucket, key, file, size, partSize, progress)
+}
+
+func (r *S3Repository) uploadMultipart(ctx context.Context, client *s3.Client, bucket, key string, file *os.File, size, partSize int64, progress ProgressFunc) error {
+	created, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("create multipart upload %s: %w", key, err)
+	}
+	uploadID := created.UploadId
+
+	var (
+		mu    sync.Mutex
+		parts []types.CompletedPart
+	)
+	err = forEachPart(ctx, size, partSize, func(ctx context.Context, number int32, offset, length int64) error {
+		resp, err := client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(bucket),
+			Key:           aws.String(key),
+			UploadId:      uploadID,
+			PartNumber:    aws.Int32(number),
+			Body:          &progressReader{r: io.NewSectionReader(file, offset, length), progress: progress},
+			ContentLength: aws.Int64(length),
+		})
+		if err != nil {
+			return fmt.Errorf("upload part %d of %s: %w", number, key, err)
+		}
+		mu.Lock()
+		parts = append(parts, types.CompletedPart{ETag: resp.ETag, PartNumber: aws.Int32(number)})
+		mu.Unlock()
+		return nil
+	})
+	if err == nil {
+		sort.Slice(parts, func(i, j int) bool { return aws.ToInt32(parts[i].PartNumber) < aws.ToInt32(parts[j].PartNumber) })
+		_, err = client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(key),
+			UploadId:        uploadID,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		})
+		if err == nil {
+			return nil
+		}
+		err = fmt.Errorf("complete multipart upload %s: %w", key, err)
+	}
+
+	// 取消時 ctx 已失效，中止分段上傳需另用不受取消影響的 context，避免留下計費的未完成分段
+	_, abortErr := client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: uploadID,
+	})
+	if abortErr != nil {
+		return errors.Join(err, fmt.Errorf("abort multipart upload %s: %w", key, abortErr))
+	}
+	return err
+}
+
+// effectivePartSize 回傳 size 使用的段大小，確保段數不超過 S3 上限。
+func (r *S3Repository) effectivePartSize(size int64) int64 {
+	partSize := r.partSize
+	if partSize < 1 {
+		partSize = defaultPartSize
+	}
+	if size > partSize*maxParts {
+		partSize = max((size+maxParts-1)/maxParts, minPartSize)
+	}
+	return partSize
+}
+
+// forEachPart 以 transferConcurrency 個 worker 處理每一段，任一段失敗即取消其餘段並回傳第一個錯誤。
+// 段編號從 1 開始。
+func forEachPart(ctx context.Context, size, partSize int64, fn func(ctx context.Context, number int32, offset, length int64) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type part struct {
+		number         int32
+		offset, length int64
+	}
+	jobs := make(chan part)
+	go func() {
+		defer close(jobs)
+		var number int32 = 1
+		for offset := int64(0); offset < size; offset += partSize {
+			select {
+			case jobs <- part{number: number, offset: offset, length: min(partSize, size-offset)}:
+			case <-ctx.Done():
+				return
+			}
+			number++
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for range transferConcurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				if err := fn(ctx, p.number, p.offset, p.length); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// progressReader 在讀取時回報進度；SDK 重送請求時會 Seek 回開頭，已回報的位元組會扣回。
+type progressReader struct {
+	r        *io.SectionReader
+	progress ProgressFunc
+	read     int64
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.report(int64(n))
+	return n, err
+}
+
+func (p *progressReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := p.r.Seek(offset, whence)
+	if err == nil {
+		p.report(pos - p.read)
+	}
+	return pos, err
+}
+
+func (p *progressReader) report(n int64) {
+	p.read += n
+	if n != 0 && p.progress != nil {
+		p.progress(n)
+	}
+}
+
+type progressWriter struct {
+	w        io.Writer
+	progress ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	if n > 0 && p.progress != nil {
+		p.progress(int64(n))
+	}
+	return n, err
+}
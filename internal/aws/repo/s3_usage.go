@@ -0,0 +1,156 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// defaultStorageClass 為 ListObjectsV2 未回傳 storage class 時使用的值。
+const defaultStorageClass = "STANDARD"
+
+// AnalyzePrefix 以 Delimiter 逐層列出 prefix 下所有子 prefix，由 concurrency 個 worker 從佇列取出 prefix 查詢，
+// 回傳每個 prefix 的大小、物件數與 storage class 分佈（du 風格，含所有子 prefix）。
+// progress 於每頁完成後回報累計掃描的物件數，可能由多個 goroutine 同時呼叫。
+func (r *S3Repository) AnalyzePrefix(ctx context.Context, client *s3.Client, bucket, prefix string, progress func(objects int64)) (*models.S3PrefixUsage, error) {
+	if client == nil {
+		return nil, fmt.Errorf("s3 client is nil")
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	root := newPrefixUsage(prefix)
+	var (
+		mu       sync.Mutex
+		cond     = sync.NewCond(&mu)
+		queue    = []*models.S3PrefixUsage{root}
+		pending  = 1 // 佇列中與查詢中的 prefix 數，歸零表示整棵樹已走完
+		scanned  atomic.Int64
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	worker := func() {
+		defer wg.Done()
+		for {
+			mu.Lock()
+			for len(queue) == 0 && pending > 0 && firstErr == nil {
+				cond.Wait()
+			}
+			if len(queue) == 0 || firstErr != nil {
+				mu.Unlock()
+				return
+			}
+			// 由佇列尾端取出（深度優先），佇列只保留尚未查詢的 prefix
+			node := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			mu.Unlock()
+
+			children, err := r.scanLevel(ctx, client, bucket, node, &scanned, progress)
+
+			mu.Lock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+			} else {
+				node.Children = children
+				queue = append(queue, children...)
+				pending += len(children)
+			}
+			pending--
+			cond.Broadcast()
+			mu.Unlock()
+		}
+	}
+
+	for range max(r.concurrency, 1) {
+		wg.Add(1)
+		go worker()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	sumUsage(root)
+	return root, nil
+}
+
+func newPrefixUsage(prefix string) *models.S3PrefixUsage {
+	return &models.S3PrefixUsage{Prefix: prefix, ByClass: make(map[string]models.S3StorageUsage)}
+}
+
+// scanLevel 列出單一 prefix 的所有分頁，逐頁將直接位於其下的物件累計到 node，只回傳子 prefix。
+func (r *S3Repository) scanLevel(ctx context.Context, client *s3.Client, bucket string, node *models.S3PrefixUsage, scanned *atomic.Int64, progress func(int64)) ([]*models.S3PrefixUsage, error) {
+	var (
+		children []*models.S3PrefixUsage
+		token    string
+	)
+	for {
+		page, err := r.ListObjectsPage(ctx, client, bucket, node.Prefix, token, 1000)
+		if err != nil {
+			return nil, err
+		}
+		var files int64
+		for _, obj := range page.Objects {
+			if obj.IsDirectory {
+				children = append(children, newPrefixUsage(obj.Key))
+				continue
+			}
+			files++
+			class := obj.StorageClass
+			if class == "" {
+				class = defaultStorageClass
+			}
+			node.Direct.Size += obj.Size
+			node.Direct.Objects++
+			usage := node.ByClass[class]
+			usage.Size += obj.Size
+			usage.Objects++
+			node.ByClass[class] = usage
+		}
+		total := scanned.Add(files)
+		if progress != nil {
+			progress(total)
+		}
+		if page.NextToken == "" {
+			return children, nil
+		}
+		token = page.NextToken
+	}
+}
+
+// sumUsage 由下而上加總子 prefix，並將子 prefix 依大小由大到小排序。
+func sumUsage(node *models.S3PrefixUsage) {
+	node.S3StorageUsage = node.Direct
+	node.DirectByClass = maps.Clone(node.ByClass)
+	for _, child := range node.Children {
+		sumUsage(child)
+		node.Size += child.Size
+		node.Objects += child.Objects
+		for class, usage := range child.ByClass {
+			total := node.ByClass[class]
+			total.Size += usage.Size
+			total.Objects += usage.Objects
+			node.ByClass[class] = total
+		}
+	}
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		if node.Children[i].Size != node.Children[j].Size {
+			return node.Children[i].Size > node.Children[j].Size
+		}
+		return node.Children[i].Prefix < node.Children[j].Prefix
+	})
+}
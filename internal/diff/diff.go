@@ -0,0 +1,161 @@
+// Package diff 提供逐行比較文字的功能（Myers 演算法）。
+package diff
+
+import (
+	"slices"
+	"strings"
+)
+
+// Op 為單行的差異種類。
+type Op int
+
+const (
+	// Equal 表示兩邊相同的行。
+	Equal Op = iota
+	// Delete 表示只存在於舊內容的行。
+	Delete
+	// Insert 表示只存在於新內容的行。
+	Insert
+)
+
+// Line 為差異結果中的一行。
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// maxEdits 為 Myers 搜尋的編輯距離上限；超過時改為整段刪除後整段新增，避免大量變更耗用過多記憶體。
+const maxEdits = 2000
+
+// Text 逐行比較 a 與 b（以 \n 分行，忽略結尾換行）。
+func Text(a, b string) []Line {
+	return Lines(splitLines(a), splitLines(b))
+}
+
+// Lines 比較兩組行，回傳最短的編輯序列；相同的開頭與結尾不參與搜尋。
+func Lines(a, b []string) []Line {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	out := make([]Line, 0, len(a)+len(b)-prefix-suffix)
+	for _, text := range a[:prefix] {
+		out = append(out, Line{Equal, text})
+	}
+	out = append(out, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		out = append(out, Line{Equal, text})
+	}
+	return out
+}
+
+// Changed 回傳差異中是否有新增或刪除的行。
+func Changed(lines []Line) bool {
+	return slices.ContainsFunc(lines, func(l Line) bool { return l.Op != Equal })
+}
+
+// Stats 回傳新增與刪除的行數。
+func Stats(lines []Line) (inserted, deleted int) {
+	for _, l := range lines {
+		switch l.Op {
+		case Insert:
+			inserted++
+		case Delete:
+			deleted++
+		}
+	}
+	return inserted, deleted
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// myers 以 Myers O(ND) 演算法找出最短編輯序列。trace[d] 保存第 d 步開始前
+// 對角線 k ∈ [-d-1, d+1] 的最遠 x，供回溯使用。
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replaceAll(a, b)
+	}
+
+	limit := min(n+m, maxEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, slices.Clone(v[offset-d-1:offset+d+2]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+	return replaceAll(a, b)
+}
+
+func backtrack(trace [][]int, a, b []string) []Line {
+	var out []Line
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+		k := x - y
+		var prevK int
+		if k == -d || k != d && at(k-1) < at(k+1) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			out = append(out, Line{Equal, a[x-1]})
+			x--
+			y--
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			out = append(out, Line{Insert, b[y-1]})
+		} else {
+			out = append(out, Line{Delete, a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	slices.Reverse(out)
+	return out
+}
+
+func replaceAll(a, b []string) []Line {
+	out := make([]Line, 0, len(a)+len(b))
+	for _, text := range a {
+		out = append(out, Line{Delete, text})
+	}
+	for _, text := range b {
+		out = append(out, Line{Insert, text})
+	}
+	return out
+}
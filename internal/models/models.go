@@ -1,6 +1,8 @@
 // Package models 提供 AWS 資源的模型定義。
 package models
 
+import "time"
+
 // TagMap represents AWS resource tags.
 type TagMap map[string]string
 
@@ -17,6 +19,9 @@ type EC2Instance struct {
 	SubnetID         string
 	SecurityGroups   []string
 	IAMRole          string
+	ImageID          string
+	KeyName          string
+	LaunchTime       string
 	Volumes          []EBSVolume
 	Tags             TagMap
 }
@@ -26,12 +31,19 @@ type EBSVolume struct {
 	ID         string
 	DeviceName string
 	SizeGiB    int32
+	VolumeType string
+	Encrypted  bool
 	State      string
 }
 
 // RDSInstance describes core metadata for DB instances.
 type RDSInstance struct {
 	ID             string
+	ARN            string
+	Status         string
+	InstanceClass  string
+	StorageGiB     int32
+	StorageType    string
 	Engine         string
 	EngineVersion  string
 	MultiAZ        bool
@@ -51,6 +63,124 @@ type S3Bucket struct {
 	Policy     string
 	Lifecycle  string
 	Tags       TagMap
+	Security   S3BucketSecurity
+	// LifecycleRules are filled in during list enrichment; CORSRules and
+	// ReplicationRules only when describing a bucket.
+	LifecycleRules   []S3LifecycleRule
+	CORSRules        []S3CORSRule
+	ReplicationRules []S3ReplicationRule
+	// Enriched reports whether the per-bucket Get* lookups have completed.
+	Enriched bool
+	// Errors records failed lookups keyed by API operation (e.g. GetBucketPolicy).
+	Errors map[string]string
+}
+
+// S3BucketSecurity is the access-control posture of a bucket. PublicAccessBlock,
+// ObjectOwnership, Grants, PolicyFindings and Public are filled in during list
+// enrichment; Logging, Replication and ObjectLock only when describing a bucket.
+type S3BucketSecurity struct {
+	PublicAccessBlock S3PublicAccessBlock
+	ObjectOwnership   string // empty when ownership controls are not configured
+	Owner             string
+	Grants            []S3Grant
+	PolicyFindings    []S3PolicyFinding
+	// AccountPublicAccessBlock is the account-level setting; a flag applies if either level sets it.
+	AccountPublicAccessBlock S3PublicAccessBlock
+	// PolicyIsPublic is S3's own evaluation from GetBucketPolicyStatus; nil when unknown.
+	PolicyIsPublic *bool
+	Logging        string
+	Replication    string
+	ObjectLock     string
+	// Described reports whether Logging, Replication and ObjectLock were looked up.
+	Described bool
+	// Public reports whether the bucket is effectively public after Block Public
+	// Access and ACL ownership settings; PublicReasons explains why.
+	Public        bool
+	PublicReasons []string
+}
+
+// S3PublicAccessBlock holds bucket- or account-level Block Public Access settings.
+type S3PublicAccessBlock struct {
+	Configured            bool
+	BlockPublicAcls       bool
+	IgnorePublicAcls      bool
+	BlockPublicPolicy     bool
+	RestrictPublicBuckets bool
+}
+
+// S3Grant is a single ACL grant.
+type S3Grant struct {
+	Grantee    string // canonical user display name/ID, e-mail, or group URI
+	Permission string
+	Public     bool // grantee is the AllUsers or AuthenticatedUsers group
+}
+
+// S3PolicyFinding is a bucket policy statement that grants access to any principal.
+type S3PolicyFinding struct {
+	Sid         string
+	Effect      string
+	Actions     []string
+	Conditional bool // the statement has a Condition block and may not be public
+	// NotPrincipal is set when the statement allows everyone except the principals in Excluded,
+	// instead of naming "*" as the principal.
+	NotPrincipal bool
+	Excluded     []string
+}
+
+// S3LifecycleRule is a bucket lifecycle rule in the form edited as YAML.
+// Days and dates are mutually exclusive per action, as in the S3 API.
+type S3LifecycleRule struct {
+	ID     string            `yaml:"id"`
+	Status string            `yaml:"status"` // Enabled or Disabled
+	Prefix string            `yaml:"prefix,omitempty"`
+	Tags   map[string]string `yaml:"tags,omitempty"`
+	// ObjectSizeGreaterThan and ObjectSizeLessThan limit the rule to objects by size in bytes.
+	ObjectSizeGreaterThan int64 `yaml:"object_size_greater_than,omitempty"`
+	ObjectSizeLessThan    int64 `yaml:"object_size_less_than,omitempty"`
+
+	Transitions               []S3LifecycleTransition `yaml:"transitions,omitempty"`
+	ExpirationDays            int32                   `yaml:"expiration_days,omitempty"`
+	ExpirationDate            string                  `yaml:"expiration_date,omitempty"` // YYYY-MM-DD
+	ExpiredObjectDeleteMarker bool                    `yaml:"expired_object_delete_marker,omitempty"`
+
+	NoncurrentTransitions    []S3LifecycleTransition `yaml:"noncurrent_transitions,omitempty"`
+	NoncurrentExpirationDays int32                   `yaml:"noncurrent_expiration_days,omitempty"`
+	// NewerNoncurrentVersions keeps that many noncurrent versions from expiring.
+	NewerNoncurrentVersions int32 `yaml:"newer_noncurrent_versions,omitempty"`
+
+	AbortIncompleteUploadDays int32 `yaml:"abort_incomplete_upload_days,omitempty"`
+}
+
+// S3LifecycleTransition moves objects to StorageClass after Days (or on Date,
+// YYYY-MM-DD). For noncurrent transitions Days counts from when the version
+// became noncurrent, Date is not allowed, and NewerNoncurrentVersions keeps
+// that many noncurrent versions in place.
+type S3LifecycleTransition struct {
+	Days                    int32  `yaml:"days,omitempty"`
+	Date                    string `yaml:"date,omitempty"`
+	StorageClass            string `yaml:"storage_class"`
+	NewerNoncurrentVersions int32  `yaml:"newer_noncurrent_versions,omitempty"`
+}
+
+// S3CORSRule is a bucket CORS rule in the form edited as YAML.
+type S3CORSRule struct {
+	ID             string   `yaml:"id,omitempty"`
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	AllowedMethods []string `yaml:"allowed_methods"`
+	AllowedHeaders []string `yaml:"allowed_headers,omitempty"`
+	ExposeHeaders  []string `yaml:"expose_headers,omitempty"`
+	MaxAgeSeconds  int32    `yaml:"max_age_seconds,omitempty"`
+}
+
+// S3ReplicationRule is a read-only summary of a bucket replication rule.
+type S3ReplicationRule struct {
+	ID                      string
+	Status                  string
+	Priority                int32
+	Prefix                  string
+	Destination             string // destination bucket name
+	StorageClass            string
+	DeleteMarkerReplication bool
 }
 
 // S3Object describes an object or prefix (directory) in a bucket.
@@ -62,6 +192,70 @@ type S3Object struct {
 	IsDirectory  bool // true if this is a common prefix (folder)
 }
 
+// S3ObjectInfo is the HeadObject view of a single object.
+type S3ObjectInfo struct {
+	S3Object
+	ContentType     string
+	ContentEncoding string
+	ETag            string
+	VersionID       string
+	Encryption      string
+	Metadata        map[string]string // user-defined x-amz-meta-* headers
+}
+
+// S3ObjectVersion is one version or delete marker of an object key.
+type S3ObjectVersion struct {
+	Key            string
+	VersionID      string // "null" for objects written before versioning was enabled
+	Size           int64
+	LastModified   string
+	ETag           string
+	StorageClass   string
+	IsLatest       bool
+	IsDeleteMarker bool
+}
+
+// S3ObjectPage is one ListObjectsV2 page; NextToken is empty on the last page.
+type S3ObjectPage struct {
+	Objects   []S3Object
+	NextToken string
+}
+
+// S3StorageUsage is the total size and object count of a set of objects.
+type S3StorageUsage struct {
+	Size    int64 `json:"size"`
+	Objects int64 `json:"objects"`
+}
+
+// S3PrefixUsage is the recursive storage usage of a prefix. The embedded totals and
+// ByClass include all sub-prefixes; Direct only counts objects directly under Prefix.
+type S3PrefixUsage struct {
+	Prefix string `json:"prefix"`
+	S3StorageUsage
+	Direct        S3StorageUsage            `json:"direct"`
+	ByClass       map[string]S3StorageUsage `json:"by_class"`
+	DirectByClass map[string]S3StorageUsage `json:"direct_by_class"`
+	Children      []*S3PrefixUsage          `json:"children,omitempty"` // largest first
+}
+
+// S3PresignedURL is a presigned GET or PUT URL for a single object.
+type S3PresignedURL struct {
+	URL       string
+	Method    string
+	Key       string
+	ExpiresAt time.Time
+	// CredentialsExpireAt is when the signing credentials expire (zero for long-lived keys);
+	// the URL stops working at whichever comes first.
+	CredentialsExpireAt time.Time
+}
+
+// S3DeleteError is a key that DeleteObjects failed to delete.
+type S3DeleteError struct {
+	Key     string
+	Code    string
+	Message string
+}
+
 // Route53HostedZone describes a Route53 hosted zone.
 type Route53HostedZone struct {
 	ID          string
@@ -69,6 +263,14 @@ type Route53HostedZone struct {
 	RecordCount int64
 	IsPrivate   bool
 	Comment     string
+	// VPCs are the VPCs associated with a private zone; only filled by GetHostedZone.
+	VPCs []Route53VPC
+}
+
+// Route53VPC is a VPC associated with a private hosted zone.
+type Route53VPC struct {
+	ID     string
+	Region string
 }
 
 // Route53Record describes a DNS record in a hosted zone.
@@ -78,22 +280,208 @@ type Route53Record struct {
 	TTL         int64
 	Values      []string
 	AliasTarget string // if alias record
+	// AliasZoneID is the hosted zone ID of the alias target (e.g. the ELB or CloudFront zone).
+	AliasZoneID          string
+	EvaluateTargetHealth bool
+	// SetIdentifier distinguishes records that share name and type under a routing policy.
+	SetIdentifier    string
+	Weight           *int64 // weighted routing; nil when not weighted (0 is a valid weight)
+	Region           string // latency routing
+	GeoLocation      *Route53GeoLocation
+	Failover         string // PRIMARY or SECONDARY
+	MultiValueAnswer bool
+	CidrRouting      *Route53CidrRouting  // IP-based routing
+	GeoProximity     *Route53GeoProximity // geoproximity routing
+	HealthCheckID    string
+}
+
+// Route53CidrRouting selects the CIDR collection location an IP-based record answers for.
+type Route53CidrRouting struct {
+	CollectionID string
+	LocationName string // "*" is the default location
+}
+
+// Route53GeoProximity is the location and bias of a geoproximity record.
+// Exactly one of AWSRegion, LocalZoneGroup or Latitude/Longitude is set.
+type Route53GeoProximity struct {
+	AWSRegion      string
+	LocalZoneGroup string
+	Latitude       string
+	Longitude      string
+	Bias           *int32
+}
+
+// Route53GeoLocation is the location a geolocation record answers for.
+type Route53GeoLocation struct {
+	ContinentCode   string
+	CountryCode     string // "*" is the default location
+	SubdivisionCode string
+}
+
+// Route53HealthCheck describes a Route53 health check and the latest checker observations.
+type Route53HealthCheck struct {
+	ID       string
+	Type     string // HTTP, HTTPS, TCP, CALCULATED, CLOUDWATCH_METRIC, ...
+	Target   string // endpoint such as "https://example.com:443/health"; empty for calculated checks
+	Disabled bool
+	Inverted bool
+	Healthy  int    // checkers whose last observation succeeded
+	Checkers int    // checkers that reported an observation
+	Report   string // latest failure report, if any checker failed
+}
+
+// Route53Change is a single change in a ChangeResourceRecordSets batch.
+type Route53Change struct {
+	Action string // CREATE, UPSERT or DELETE
+	Record Route53Record
+}
+
+// Route53ChangeInfo is the status of a submitted change batch.
+type Route53ChangeInfo struct {
+	ID          string
+	Status      string // PENDING or INSYNC
+	SubmittedAt time.Time
+}
+
+// ResolverEndpoint describes a Route53 Resolver inbound or outbound endpoint.
+type ResolverEndpoint struct {
+	ID          string
+	Name        string
+	Direction   string // INBOUND, OUTBOUND or INBOUND_DELEGATION
+	Status      string
+	VPCID       string
+	IPAddresses []string // "10.0.1.10 (subnet-123)"
+}
+
+// ResolverRule describes a Route53 Resolver rule and the VPCs it is associated with.
+type ResolverRule struct {
+	ID         string
+	Name       string
+	DomainName string
+	RuleType   string // FORWARD, SYSTEM, RECURSIVE or DELEGATE
+	Status     string
+	EndpointID string
+	TargetIPs  []string // "192.0.2.53:53"
+	VPCs       []string
+}
+
+// ResolverQueryLogConfig describes a Resolver query logging configuration and the VPCs logging to it.
+type ResolverQueryLogConfig struct {
+	ID          string
+	Name        string
+	Destination string // CloudWatch Logs, S3 or Firehose ARN
+	Status      string
+	VPCs        []string
 }
 
 // LambdaFunction describes AWS Lambda metadata.
 type LambdaFunction struct {
-	Name         string
-	ARN          string
-	Runtime      string
-	MemoryMB     int32
-	TimeoutSec   int32
-	Role         string
-	EnvVars      map[string]string
-	Triggers     []string
-	Tags         TagMap
+	Name                string
+	ARN                 string
+	Version             string // $LATEST unless the function was fetched by version or alias
+	Runtime             string
+	Handler             string
+	Description         string
+	PackageType         string
+	Architectures       []string
+	CodeSizeBytes       int64
+	MemoryMB            int32
+	TimeoutSec          int32
+	ReservedConcurrency *int32 // nil if no reserved concurrency is configured
+	Role                string
+	EnvVars             map[string]string
+	Layers              []LambdaLayer
+	Triggers            []LambdaTrigger
+	Tags                TagMap
+	LastModified        string
+	// State and LastUpdateStatus track function readiness and the latest configuration/code update.
+	State            string
+	LastUpdateStatus string // InProgress, Successful or Failed
+	LastUpdateReason string
+	RevisionID       string
+	// Errors records failed trigger lookups keyed by API operation (e.g. GetPolicy).
+	Errors map[string]string
+}
+
+// LambdaTrigger is an event source that invokes a function: an event source mapping polled by
+// Lambda (SQS, Kinesis, DynamoDB streams...) or a resource policy statement allowing a service to push events.
+type LambdaTrigger struct {
+	ID            string // mapping UUID or policy statement ID
+	Service       string // SQS, Kinesis, DynamoDB, S3, EventBridge, API Gateway...
+	Source        string // event source ARN, or the principal when the statement has no source ARN
+	Mapping       bool   // event source mappings can be enabled or disabled
+	State         string // mapping state (Enabled, Disabled, Enabling...); empty for policy statements
+	BatchSize     int32
+	LastResult    string
+	SourceAccount string
+	Actions       []string // actions granted by the policy statement
+}
+
+// LambdaLayer is a layer version attached to a function.
+type LambdaLayer struct {
+	ARN           string
+	Name          string
+	Version       string
+	CodeSizeBytes int64
+}
+
+// LambdaCode describes the deployment package of a function version.
+type LambdaCode struct {
+	Function      string
+	Version       string
+	PackageType   string // Zip or Image
+	Location      string // presigned URL of the zip package, valid for about 10 minutes
+	ImageURI      string // container image of Image functions
+	SHA256        string // base64 SHA-256 of the zip package
+	CodeSizeBytes int64
+}
+
+// LambdaVersion is a published version of a function; $LATEST is the unpublished, editable version.
+type LambdaVersion struct {
+	Version       string
+	Description   string
+	Runtime       string
+	CodeSHA256    string
+	CodeSizeBytes int64
+	State         string
+	LastModified  string
+}
+
+// LambdaAlias points to a version and can send part of its traffic to an additional version.
+type LambdaAlias struct {
+	Name              string
+	ARN               string
+	Version           string
+	Description       string
+	AdditionalVersion string  // empty when the alias routes all traffic to Version
+	AdditionalWeight  float64 // share of traffic (0-1) routed to AdditionalVersion
+	RevisionID        string
+}
+
+// LambdaAccountConcurrency is the regional concurrency quota of the account.
+type LambdaAccountConcurrency struct {
+	Limit      int32 // concurrent executions allowed in the region
+	Unreserved int32 // part of Limit not reserved by any function; shared by functions without reserved concurrency
+}
+
+// LambdaProvisionedConcurrency is the provisioned concurrency configured on an alias or version.
+type LambdaProvisionedConcurrency struct {
+	Qualifier    string // alias name or version number
+	Requested    int32
+	Allocated    int32
+	Available    int32
+	Status       string // IN_PROGRESS, READY or FAILED
+	StatusReason string
 	LastModified string
 }
 
+// LambdaConfig holds the editable part of a function configuration.
+type LambdaConfig struct {
+	MemoryMB    int32             `yaml:"memory_mb"`
+	TimeoutSec  int32             `yaml:"timeout_sec"`
+	Environment map[string]string `yaml:"environment"`
+}
+
 // ListItem aggregates cross-resource info for list UI.
 type ListItem struct {
 	ID       string
@@ -106,6 +494,12 @@ type ListItem struct {
 	Metadata map[string]string
 }
 
+// Column describes a list column: an i18n title key and how to render a row.
+type Column struct {
+	Title string
+	Value func(ListItem) string
+}
+
 // DetailView groups detailed info for UI tabs.
 type DetailView struct {
 	Overview  map[string]string
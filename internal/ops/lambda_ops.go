@@ -3,6 +3,7 @@ package ops
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,7 +34,9 @@ type InvokeResult struct {
 	ExecutedVersion string
 	Payload         string
 	FunctionError   string
-	LogResult       string
+	// LogResult 為 Lambda 回傳的 base64 執行記錄；Log 為解碼後的內容（最後 4 KB）
+	LogResult string
+	Log       string
 }
 
 // TestInvoke 測試呼叫 Lambda 函式。
@@ -62,6 +65,14 @@ func (o *LambdaOps) TestInvoke(ctx context.Context, functionName string, payload
 		FunctionError:   aws.ToString(resp.FunctionError),
 		LogResult:       aws.ToString(resp.LogResult),
 	}
+	if result.LogResult != "" {
+		// 函式已執行，記錄無法解碼時仍回傳 payload 與錯誤資訊，改以說明取代記錄內容
+		log, err := DecodeLogResult(result.LogResult)
+		if err != nil {
+			log = fmt.Sprintf("(log unavailable: %v)", err)
+		}
+		result.Log = log
+	}
 
 	if resp.Payload != nil {
 		result.Payload = string(resp.Payload)
@@ -70,6 +81,15 @@ func (o *LambdaOps) TestInvoke(ctx context.Context, functionName string, payload
 	return result, nil
 }
 
+// DecodeLogResult 解碼 Invoke 回傳的 base64 tail log。
+func DecodeLogResult(encoded string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("decode log result: %w", err)
+	}
+	return string(raw), nil
+}
+
 // TestInvokeWithJSON 使用 JSON 物件作為 payload 測試呼叫。
 func (o *LambdaOps) TestInvokeWithJSON(ctx context.Context, functionName string, payloadObj any) (*InvokeResult, error) {
 	var payload []byte
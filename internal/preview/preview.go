@@ -0,0 +1,236 @@
+// Package preview 將物件內容的前段轉為適合終端顯示的預覽文字。
+package preview
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format 表示預覽的呈現方式。
+type Format string
+
+const (
+	FormatEmpty Format = "empty"
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatCSV   Format = "csv"
+	FormatHex   Format = "hex"
+)
+
+const (
+	// maxDecompressed 為解壓縮後保留的最大位元組數，避免壓縮炸彈佔用記憶體。
+	maxDecompressed = 1 << 20
+	// maxCellWidth 為 CSV 表格單欄的最大顯示寬度。
+	maxCellWidth = 32
+	// maxHexBytes 為十六進位顯示的最大位元組數。
+	maxHexBytes = 4096
+)
+
+// Input 為產生預覽所需的物件資訊。
+type Input struct {
+	Key         string
+	ContentType string
+	Data        []byte
+	Truncated   bool // Data 只是物件的前段
+}
+
+// Result 為預覽結果；Text 為純文字（未加入顏色標籤）。
+type Result struct {
+	Format       Format
+	Text         string
+	Decompressed bool
+	Truncated    bool // 只顯示內容的前段
+	BrokenJSON   bool // 內容標示為 JSON 但無法解析（通常因截斷），改以文字顯示
+}
+
+// Render 依副檔名、Content-Type 與內容判斷格式並產生預覽。
+// gzip 內容（.gz 或 Content-Encoding: gzip）會先解壓縮，截斷的壓縮檔只解出可取得的部分。
+func Render(in Input) Result {
+	data := in.Data
+	key := strings.ToLower(in.Key)
+	contentType := strings.ToLower(in.ContentType)
+	var result Result
+
+	if isGzip(data) {
+		plain, complete, err := gunzip(data)
+		if err == nil {
+			data = plain
+			key = strings.TrimSuffix(key, ".gz")
+			result.Decompressed = true
+			in.Truncated = in.Truncated || !complete
+		}
+	}
+
+	result.Truncated = in.Truncated
+	if len(data) == 0 {
+		result.Format = FormatEmpty
+		return result
+	}
+
+	switch {
+	case isJSON(key, contentType, data):
+		if text, ok := prettyJSON(data); ok {
+			result.Format, result.Text = FormatJSON, text
+			return result
+		}
+		result.BrokenJSON = true
+	case isCSV(key, contentType):
+		if text, ok := csvTable(data, separator(key, contentType), in.Truncated); ok {
+			result.Format, result.Text = FormatCSV, text
+			return result
+		}
+	}
+
+	if isText(data, in.Truncated) {
+		result.Format, result.Text = FormatText, string(data)
+		return result
+	}
+	result.Format = FormatHex
+	if len(data) > maxHexBytes {
+		data = data[:maxHexBytes]
+		result.Truncated = true
+	}
+	result.Text = hex.Dump(data)
+	return result
+}
+
+// isGzip 以 magic number 判斷；.gz 副檔名或 Content-Encoding 未標示的 gzip 內容同樣會解壓縮。
+func isGzip(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// gunzip 解壓縮 data；輸入被截斷時回傳已解出的部分，complete 為 false。
+func gunzip(data []byte) ([]byte, bool, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, err
+	}
+	defer zr.Close()
+
+	var out bytes.Buffer
+	_, err = io.Copy(&out, io.LimitReader(zr, maxDecompressed+1))
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return out.Bytes(), false, nil
+	case err != nil && out.Len() == 0:
+		return nil, false, err
+	case out.Len() > maxDecompressed:
+		return out.Bytes()[:maxDecompressed], false, nil
+	}
+	return out.Bytes(), err == nil, nil
+}
+
+func isJSON(key, contentType string, data []byte) bool {
+	if strings.HasSuffix(key, ".json") || strings.Contains(contentType, "json") {
+		return true
+	}
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed)
+}
+
+func prettyJSON(data []byte) (string, bool) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, bytes.TrimSpace(data), "", "  "); err != nil {
+		return "", false
+	}
+	return out.String(), true
+}
+
+func isCSV(key, contentType string) bool {
+	switch path.Ext(key) {
+	case ".csv", ".tsv":
+		return true
+	}
+	return strings.Contains(contentType, "csv") || strings.Contains(contentType, "tab-separated")
+}
+
+func separator(key, contentType string) rune {
+	if path.Ext(key) == ".tsv" || strings.Contains(contentType, "tab-separated") {
+		return '\t'
+	}
+	return ','
+}
+
+// csvTable 將 CSV 轉為等寬對齊的表格；截斷的最後一列會被捨棄。
+func csvTable(data []byte, sep rune, truncated bool) (string, bool) {
+	if truncated {
+		if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+			data = data[:i+1]
+		}
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = sep
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	records, err := r.ReadAll()
+	if err != nil || len(records) == 0 {
+		return "", false
+	}
+
+	var widths []int
+	for _, record := range records {
+		for i, field := range record {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], min(utf8.RuneCountInString(field), maxCellWidth))
+		}
+	}
+
+	var b strings.Builder
+	for n, record := range records {
+		for i, field := range record {
+			if i > 0 {
+				b.WriteString(" │ ")
+			}
+			b.WriteString(pad(field, widths[i]))
+		}
+		b.WriteString("\n")
+		if n == 0 {
+			for i, w := range widths {
+				if i > 0 {
+					b.WriteString("─┼─")
+				}
+				b.WriteString(strings.Repeat("─", w))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), true
+}
+
+func pad(s string, width int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// isText 判斷內容是否為可顯示的 UTF-8 文字；截斷可能切在多位元組字元中間，允許結尾不完整。
+func isText(data []byte, truncated bool) bool {
+	if truncated {
+		for i := 0; i < utf8.UTFMax && len(data) > 0 && !utf8.Valid(data); i++ {
+			data = data[:len(data)-1]
+		}
+	}
+	if !utf8.Valid(data) {
+		return false
+	}
+	control := 0
+	for _, c := range data {
+		if c < 0x20 && c != '\n' && c != '\r' && c != '\t' {
+			control++
+		}
+	}
+	return control*100 <= len(data) // 控制字元不超過 1%
+}
@@ -0,0 +1,82 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Action 表示可對資源執行的操作。
+type Action string
+
+const (
+	ActionStart  Action = "start"
+	ActionStop   Action = "stop"
+	ActionReboot Action = "reboot"
+	ActionInvoke Action = "invoke"
+)
+
+// actionWaitTimeout 為等待資源達到目標狀態的上限。
+const actionWaitTimeout = 15 * time.Minute
+
+// ActionResult 描述操作執行結果。
+type ActionResult struct {
+	Action      Action
+	ResourceID  string
+	TargetState string // 等待達到的狀態；空字串表示操作不需等待
+	Message     string // 額外輸出（例如 Lambda 回應內容）
+	Refresh     bool   // 操作改變了清單內容，完成後需重新查詢
+	// MessageKey 為需由 UI 依目前語言顯示的說明（i18n key），MessageArgs 為其參數
+	MessageKey  string
+	MessageArgs []any
+}
+
+// Notice 為顯示給使用者的提示：Key 為 i18n key，由 UI 依目前語言以 Args 格式化；
+// Key 為空字串時直接顯示 Text（例如 ops 檢查回傳的說明）。
+type Notice struct {
+	Key  string
+	Args []any
+	Text string
+}
+
+// PrecheckAction 在確認前檢查操作是否允許，回傳是否允許與需顯示的警告（不允許時為原因）。
+// 未註冊 Precheck 的資源類型一律允許。
+func (s *Service) PrecheckAction(ctx context.Context, kind Kind, id string, action Action) (bool, Notice, error) {
+	def, ok := s.registry.Lookup(kind)
+	if !ok || def.Precheck == nil {
+		return true, Notice{}, nil
+	}
+	if s.factory == nil {
+		return false, Notice{}, errors.New("aws client factory is nil")
+	}
+	return def.Precheck(ctx, s, id, action)
+}
+
+// ExecuteAction 執行操作，並輪詢等待資源達到目標狀態。
+// 開始等待時會呼叫 onWait（可為 nil），讓 UI 顯示進度。
+func (s *Service) ExecuteAction(ctx context.Context, kind Kind, id string, action Action, onWait func(target string)) (ActionResult, error) {
+	if s.factory == nil {
+		return ActionResult{}, errors.New("aws client factory is nil")
+	}
+	if s.state == nil {
+		return ActionResult{}, errors.New("state store is nil")
+	}
+
+	def, ok := s.registry.Lookup(kind)
+	if !ok || def.Execute == nil {
+		return ActionResult{}, fmt.Errorf("action %s not supported for %s", action, kind)
+	}
+	return def.Execute(ctx, s, id, action, onWait)
+}
+
+// runOp 以 request timeout 執行單次 API 呼叫並記錄指標。
+func (s *Service) runOp(ctx context.Context, service, operation string, run func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := run(ctx)
+	s.observe(ctx, service, operation, start, err)
+	return err
+}
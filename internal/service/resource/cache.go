@@ -0,0 +1,299 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/search"
+)
+
+// DefaultCacheTTL 為未設定時的清單快取有效時間。
+const DefaultCacheTTL = time.Minute
+
+// defaultPageSize 為未設定時分頁清單的每頁筆數。
+const defaultPageSize = 50
+
+// maxScopedEntries 為同一資源類型保留的瀏覽範圍快取上限（例如 S3 prefix、Route53 zone），
+// 超過時淘汰最早取得的範圍，避免長時間瀏覽後快取無限成長。
+const maxScopedEntries = 32
+
+const (
+	// enrichTimeout 為背景補齊整份清單的時間上限。
+	enrichTimeout = 5 * time.Minute
+	// enrichNotifyInterval 為背景補齊期間通知 UI 重繪的最短間隔。
+	enrichNotifyInterval = 500 * time.Millisecond
+)
+
+// ListSnapshot 為快取中的清單（已套用搜尋條件）與資料取得時間。
+type ListSnapshot struct {
+	Items     []models.ListItem
+	FetchedAt time.Time
+	Stale     bool // 已超過 TTL，需要重新查詢
+	More      bool // 分頁清單尚有下一頁
+}
+
+// cacheKey 以 profile、region、資源類型與瀏覽範圍識別一份清單快取，
+// 避免切換 profile/region 或 bucket/zone 後誤用其他範圍的資料。
+type cacheKey struct {
+	profile string
+	region  string
+	kind    Kind
+	scope   string
+}
+
+// cacheEntry 保存一次清單查詢的完整結果（未套用搜尋條件）。
+type cacheEntry struct {
+	items     []models.ListItem
+	details   map[string]cachedDetail
+	fetchedAt time.Time
+	next      string // 分頁清單的下一頁 token；空字串表示已取完
+}
+
+// cachedDetail 為快取中的詳情；full 表示已透過 Describe 取得完整資訊。
+type cachedDetail struct {
+	view models.DetailView
+	full bool
+}
+
+// SetCacheTTL 設定清單快取有效時間；perKind 以資源類型名稱覆寫預設值。
+// TTL 小於等於 0 表示每次切換都重新查詢（仍會先顯示快取內容）。
+func (s *Service) SetCacheTTL(defaultTTL time.Duration, perKind map[string]time.Duration) {
+	ttls := make(map[Kind]time.Duration, len(perKind))
+	for kind, ttl := range perKind {
+		ttls[Kind(kind)] = ttl
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultTTL = defaultTTL
+	s.ttls = ttls
+}
+
+// Cached 回傳目前 profile/region 下該資源類型的快取清單；沒有快取時 ok 為 false。
+func (s *Service) Cached(kind Kind, matcher search.Matcher) (ListSnapshot, bool) {
+	def, ok := s.registry.Lookup(kind)
+	if !ok || s.state == nil {
+		return ListSnapshot{}, false
+	}
+	key := s.cacheKey(def)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.cache[key]
+	if !ok {
+		return ListSnapshot{}, false
+	}
+	return ListSnapshot{
+		Items:     filterItems(def, entry.items, matcher),
+		FetchedAt: entry.fetchedAt,
+		Stale:     time.Since(entry.fetchedAt) >= s.ttlLocked(kind),
+		More:      entry.next != "",
+	}, true
+}
+
+// SetOnUpdate 設定快取在背景更新（例如補齊欄位）時的通知，fn 會在背景 goroutine 中呼叫。
+func (s *Service) SetOnUpdate(fn func(kind Kind)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onUpdate = fn
+}
+
+// enrich 於背景執行 def.Enrich 並更新快取；快取被其他範圍取代時停止。
+func (s *Service) enrich(ctx context.Context, def Definition, key cacheKey, items []models.ListItem) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), enrichTimeout)
+	defer cancel()
+
+	var lastNotify time.Time
+	def.Enrich(ctx, s, items, func(item models.ListItem, detail models.DetailView) {
+		if !s.updateCached(key, item, detail) {
+			cancel()
+			return
+		}
+		if time.Since(lastNotify) >= enrichNotifyInterval {
+			lastNotify = time.Now()
+			s.notifyUpdate(key.kind)
+		}
+	})
+	s.notifyUpdate(key.kind)
+}
+
+func (s *Service) notifyUpdate(kind Kind) {
+	s.mu.RLock()
+	fn := s.onUpdate
+	s.mu.RUnlock()
+	if fn != nil {
+		fn(kind)
+	}
+}
+
+func (s *Service) cacheKey(def Definition) cacheKey {
+	key := cacheKey{
+		profile: s.state.Profile(),
+		region:  s.state.Region(),
+		kind:    def.Kind,
+	}
+	if def.Scope != nil {
+		key.scope = def.Scope(s)
+	}
+	return key
+}
+
+// ttlLocked 回傳資源類型的快取有效時間，呼叫前需持有 s.mu。
+func (s *Service) ttlLocked(kind Kind) time.Duration {
+	if ttl, ok := s.ttls[kind]; ok {
+		return ttl
+	}
+	return s.defaultTTL
+}
+
+func (s *Service) storeList(key cacheKey, items []models.ListItem, details map[string]models.DetailView, next string) {
+	entries := make(map[string]cachedDetail, len(details))
+	for id, view := range details {
+		entries[id] = cachedDetail{view: view}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.cache[key]; !exists && key.scope != "" {
+		s.evictScopeLocked(key.kind)
+	}
+	s.cache[key] = &cacheEntry{
+		items:     items,
+		details:   entries,
+		fetchedAt: time.Now(),
+		next:      next,
+	}
+}
+
+// evictScopeLocked 在資源類型的範圍快取達到上限時淘汰最早取得的一份，呼叫前需持有 s.mu。
+func (s *Service) evictScopeLocked(kind Kind) {
+	var (
+		oldest   cacheKey
+		oldestAt time.Time
+		count    int
+	)
+	for key, entry := range s.cache {
+		if key.kind != kind || key.scope == "" {
+			continue
+		}
+		count++
+		if count == 1 || entry.fetchedAt.Before(oldestAt) {
+			oldest, oldestAt = key, entry.fetchedAt
+		}
+	}
+	if count >= maxScopedEntries {
+		delete(s.cache, oldest)
+	}
+}
+
+// replaceList 以重新產生的內容取代快取中的清單與詳情，保留取得時間；
+// 用於只有本地狀態改變（例如暫存的變更）時更新清單。快取不存在時回傳 false。
+func (s *Service) replaceList(key cacheKey, items []models.ListItem, details map[string]models.DetailView) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok {
+		return false
+	}
+	entries := make(map[string]cachedDetail, len(details))
+	for id, view := range details {
+		entries[id] = cachedDetail{view: view}
+	}
+	entry.items = items
+	entry.details = entries
+	return true
+}
+
+// appendPage 將下一頁附加到快取，回傳是否還有下一頁。
+// 快取已被重新查詢或其他呼叫先附加同一頁時（token 不符）不做任何事。
+func (s *Service) appendPage(key cacheKey, token string, page ListPage) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok || entry.next != token {
+		return ok && entry.next != ""
+	}
+	entry.items = append(entry.items, page.Items...)
+	for id, view := range page.Details {
+		entry.details[id] = cachedDetail{view: view}
+	}
+	entry.next = page.Next
+	return entry.next != ""
+}
+
+func (s *Service) nextToken(key cacheKey) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if entry, ok := s.cache[key]; ok {
+		return entry.next
+	}
+	return ""
+}
+
+// storeDetail 以完整詳情更新單一資源的快取；清單尚未快取時不保存。
+func (s *Service) storeDetail(key cacheKey, id string, detail models.DetailView) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.cache[key]; ok {
+		entry.details[id] = cachedDetail{view: detail, full: true}
+	}
+}
+
+// forgetDetail 將快取中的完整詳情標示為需重新查詢（資源設定已變更），下次 Detail 會重新 Describe。
+func (s *Service) forgetDetail(kind Kind, id string) {
+	def, ok := s.registry.Lookup(kind)
+	if !ok {
+		return
+	}
+	key := s.cacheKey(def)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.cache[key]; ok {
+		if detail, exists := entry.details[id]; exists {
+			entry.details[id] = cachedDetail{view: detail.view}
+		}
+	}
+}
+
+// updateCached 以補齊後的內容取代快取中同 ID 的清單列，已由 Describe 取得的完整詳情會保留。
+// 快取不存在或已不含該 ID 時回傳 false。
+func (s *Service) updateCached(key cacheKey, item models.ListItem, detail models.DetailView) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok {
+		return false
+	}
+	for i := range entry.items {
+		if entry.items[i].ID != item.ID {
+			continue
+		}
+		// Cached 與 ListItems 回傳的都是過濾後的新 slice，可直接修改
+		entry.items[i] = item
+		if !entry.details[item.ID].full {
+			entry.details[item.ID] = cachedDetail{view: detail}
+		}
+		return true
+	}
+	return false
+}
+
+func (s *Service) getDetail(key cacheKey, id string) (models.DetailView, bool, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if entry, ok := s.cache[key]; ok {
+		detail, exists := entry.details[id]
+		return detail.view, detail.full, exists
+	}
+	return models.DetailView{}, false, false
+}
+
+func filterItems(def Definition, items []models.ListItem, matcher search.Matcher) []models.ListItem {
+	result := make([]models.ListItem, 0, len(items))
+	for _, item := range items {
+		if matcher.Match(def.searchText(item)) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/vincent119/awsGUITools/internal/aws/metrics"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/ops"
+)
+
+// KindEC2 表示 EC2 執行個體。
+const KindEC2 Kind = "ec2"
+
+func init() {
+	Register(Definition{
+		Kind:   KindEC2,
+		Label:  "resource.ec2",
+		Hotkey: '1',
+		Columns: []models.Column{
+			nameColumn,
+			metadataColumn("column.instance_type", "type"),
+			column("column.state", func(item models.ListItem) string { return item.Status }),
+			column("column.az", func(item models.ListItem) string { return item.Region }),
+		},
+		List:       listEC2,
+		SearchText: func(item models.ListItem) string { return item.Name + item.ID },
+		Describe:   describeEC2,
+		Metrics:    metrics.EC2Queries,
+		Actions:    []Action{ActionStart, ActionStop, ActionReboot},
+		Execute:    executeEC2Action,
+	})
+}
+
+func listEC2(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	client, err := s.factory.EC2(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	instances, err := s.ec2Repo.ListInstances(ctx, client, nil)
+	s.observe(ctx, "ec2", "DescribeInstances", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, details := buildEC2List(instances)
+	return items, details, nil
+}
+
+func buildEC2List(instances []models.EC2Instance) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(instances))
+	details := make(map[string]models.DetailView, len(instances))
+
+	for _, inst := range instances {
+		items = append(items, models.ListItem{
+			ID:     inst.ID,
+			Name:   fallback(inst.Name, inst.ID),
+			Type:   "EC2",
+			Status: inst.State,
+			Region: inst.AvailabilityZone,
+			Tags:   inst.Tags,
+			Metadata: map[string]string{
+				"type": inst.InstanceType,
+			},
+		})
+		details[inst.ID] = ec2Detail(inst)
+	}
+	return items, details
+}
+
+func describeEC2(ctx context.Context, s *Service, id string) (models.DetailView, error) {
+	client, err := s.factory.EC2(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	start := time.Now()
+	inst, err := s.ec2Repo.GetInstance(ctx, client, id)
+	s.observe(ctx, "ec2", "DescribeInstances", start, err)
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	return ec2Detail(inst), nil
+}
+
+func ec2Detail(inst models.EC2Instance) models.DetailView {
+	return models.DetailView{
+		Overview: map[string]string{
+			"Instance ID": inst.ID,
+			"Name":        inst.Name,
+			"Type":        inst.InstanceType,
+			"State":       inst.State,
+			"Private IP":  inst.PrivateIP,
+			"Public IP":   inst.PublicIP,
+			"VPC":         inst.VpcID,
+			"Subnet":      inst.SubnetID,
+			"IAM Role":    inst.IAMRole,
+			"AMI":         inst.ImageID,
+			"Key Pair":    inst.KeyName,
+			"Launched":    inst.LaunchTime,
+		},
+		Relations: map[string][]string{
+			"Security Groups": inst.SecurityGroups,
+			"EBS Volumes":     volumeIDs(inst.Volumes),
+		},
+		Tags: inst.Tags,
+	}
+}
+
+func volumeIDs(vols []models.EBSVolume) []string {
+	result := make([]string, 0, len(vols))
+	for _, vol := range vols {
+		if vol.ID == "" {
+			continue
+		}
+		// 清單查詢沒有容量資訊，Describe 後才會補上
+		if vol.SizeGiB > 0 {
+			result = append(result, fmt.Sprintf("%s %s %d GiB %s (%s)", vol.ID, vol.DeviceName, vol.SizeGiB, vol.VolumeType, vol.State))
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s (%s)", vol.ID, vol.State))
+	}
+	return result
+}
+
+func executeEC2Action(ctx context.Context, s *Service, id string, action Action, onWait func(string)) (ActionResult, error) {
+	client, err := s.factory.EC2(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return ActionResult{}, err
+	}
+	ec2Ops := ops.NewEC2Ops(client)
+
+	var (
+		target    ec2types.InstanceStateName
+		operation string
+		run       func(context.Context) error
+	)
+	switch action {
+	case ActionStart:
+		target, operation = ec2types.InstanceStateNameRunning, "StartInstances"
+		run = func(ctx context.Context) error { return ec2Ops.StartInstance(ctx, id, false) }
+	case ActionStop:
+		target, operation = ec2types.InstanceStateNameStopped, "StopInstances"
+		run = func(ctx context.Context) error { return ec2Ops.StopInstance(ctx, id, false) }
+	case ActionReboot:
+		// 重新開機期間實例維持 running，沒有可等待的狀態變化
+		operation = "RebootInstances"
+		run = func(ctx context.Context) error { return ec2Ops.RebootInstance(ctx, id, false) }
+	default:
+		return ActionResult{}, fmt.Errorf("action %s not supported for ec2", action)
+	}
+
+	if err := s.runOp(ctx, "ec2", operation, run); err != nil {
+		return ActionResult{}, err
+	}
+	if target == "" {
+		return ActionResult{Action: action, ResourceID: id, MessageKey: "action.reboot_requested"}, nil
+	}
+
+	if onWait != nil {
+		onWait(string(target))
+	}
+	start := time.Now()
+	err = ec2Ops.WaitForState(ctx, id, target, actionWaitTimeout)
+	s.observe(ctx, "ec2", "WaitForState", start, err)
+	if err != nil {
+		return ActionResult{}, err
+	}
+	return ActionResult{Action: action, ResourceID: id, TargetState: string(target)}, nil
+}
@@ -0,0 +1,226 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/logs"
+	"github.com/vincent119/awsGUITools/internal/aws/metrics"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/ops"
+)
+
+// KindLambda 表示 Lambda 函式。
+const KindLambda Kind = "lambda"
+
+func init() {
+	Register(Definition{
+		Kind:   KindLambda,
+		Label:  "resource.lambda",
+		Hotkey: '4',
+		Columns: []models.Column{
+			nameColumn,
+			column("column.runtime", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.memory", "memory"),
+		},
+		List:     listLambda,
+		Describe: describeLambda,
+		Metrics:  metrics.LambdaQueries,
+		LogGroup: logs.LambdaLogGroup,
+		Actions:  []Action{ActionInvoke},
+		Execute:  executeLambdaAction,
+		Keys: map[rune]Command{
+			'd': CommandCodeDownload,
+			'v': CommandPackageBrowser,
+			'I': CommandInvoke,
+			'C': CommandConcurrency,
+			'E': CommandConfigEdit,
+		},
+	})
+}
+
+func listLambda(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	fns, err := s.lambdaRepo.ListFunctions(ctx, client, nil)
+	s.observe(ctx, "lambda", "ListFunctions", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, details := buildLambdaList(fns)
+	return items, details, nil
+}
+
+func buildLambdaList(fns []models.LambdaFunction) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(fns))
+	details := make(map[string]models.DetailView, len(fns))
+
+	for _, fn := range fns {
+		items = append(items, models.ListItem{
+			ID:     fn.ARN,
+			Name:   fn.Name,
+			Type:   "Lambda",
+			Status: fn.Runtime,
+			Tags:   fn.Tags,
+			Metadata: map[string]string{
+				"memory": fmt.Sprintf("%d MB", fn.MemoryMB),
+			},
+		})
+		details[fn.ARN] = lambdaDetail(fn)
+	}
+	return items, details
+}
+
+func describeLambda(ctx context.Context, s *Service, id string) (models.DetailView, error) {
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	start := time.Now()
+	fn, err := s.lambdaRepo.GetFunction(ctx, client, id)
+	s.observe(ctx, "lambda", "GetFunction", start, err)
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	return lambdaDetail(fn), nil
+}
+
+func lambdaDetail(fn models.LambdaFunction) models.DetailView {
+	overview := map[string]string{
+		"Function":     fn.Name,
+		"Runtime":      fn.Runtime,
+		"Handler":      fn.Handler,
+		"Description":  fn.Description,
+		"Package":      fn.PackageType,
+		"Architecture": strings.Join(fn.Architectures, ", "),
+		"Memory":       fmt.Sprintf("%d MB", fn.MemoryMB),
+		"Timeout":      fmt.Sprintf("%d s", fn.TimeoutSec),
+		"Role":         fn.Role,
+		"LastChange":   fn.LastModified,
+		"State":        fn.State,
+	}
+	if fn.LastUpdateStatus != "" {
+		overview["Last Update"] = strings.TrimSpace(fn.LastUpdateStatus + " " + fn.LastUpdateReason)
+	}
+	if fn.CodeSizeBytes > 0 {
+		overview["Code Size"] = formatSize(fn.CodeSizeBytes)
+	}
+	if fn.ReservedConcurrency != nil {
+		overview["Reserved Concurrency"] = fmt.Sprintf("%d", *fn.ReservedConcurrency)
+	}
+	layers := make([]string, len(fn.Layers))
+	for i, l := range fn.Layers {
+		layers[i] = layerSummary(l)
+	}
+	triggers := make([]string, len(fn.Triggers))
+	for i, t := range fn.Triggers {
+		triggers[i] = TriggerSummary(t)
+	}
+	return models.DetailView{
+		Overview: overview,
+		Relations: map[string][]string{
+			"Environment": flattenEnv(maskEnv(fn.EnvVars)),
+			"Layers":      layers,
+			"Triggers":    triggers,
+			"Errors":      lookupErrors(fn.Errors),
+		},
+		Tags: fn.Tags,
+	}
+}
+
+// layerSummary 說明 layer 的名稱、版本與大小，例如 "shared-deps v3 (12.4 MB)"。
+func layerSummary(l models.LambdaLayer) string {
+	label := l.Name
+	if l.Version != "" {
+		label += " v" + l.Version
+	}
+	if l.CodeSizeBytes > 0 {
+		label += " (" + formatSize(l.CodeSizeBytes) + ")"
+	}
+	return label
+}
+
+// FunctionTarget 為呼叫、下載程式碼等操作的函式對象：Function 為未限定的函式 ARN，Qualifier 為版本或 alias。
+type FunctionTarget struct {
+	Function  string
+	Name      string
+	Qualifier string
+}
+
+// ARN 回傳 API 使用的函式識別（有 Qualifier 時為限定 ARN）。
+func (t FunctionTarget) ARN() string {
+	if t.Qualifier == "" {
+		return t.Function
+	}
+	return t.Function + ":" + t.Qualifier
+}
+
+// Label 回傳顯示用的名稱，例如 "orders" 或 "orders:live"。
+func (t FunctionTarget) Label() string {
+	if t.Qualifier == "" {
+		return t.Name
+	}
+	return t.Name + ":" + t.Qualifier
+}
+
+// FunctionTargetFor 依目前清單與選取項目決定操作對象：函式清單為選取的函式，
+// 版本清單為選取的版本或 alias（$LATEST 為未限定的函式），觸發來源清單為目前函式。
+func (s *Service) FunctionTargetFor(kind Kind, item models.ListItem) (FunctionTarget, bool) {
+	switch kind {
+	case KindLambda:
+		return FunctionTarget{Function: item.ID, Name: item.Name}, true
+	case KindLambdaVersions:
+		target := FunctionTarget{Function: s.currentFunction, Name: s.currentFunctionName}
+		if item.ID != LatestVersion {
+			target.Qualifier = item.ID
+		}
+		return target, s.currentFunction != ""
+	case KindLambdaTriggers:
+		return FunctionTarget{Function: s.currentFunction, Name: s.currentFunctionName}, s.currentFunction != ""
+	}
+	return FunctionTarget{}, false
+}
+
+func executeLambdaAction(ctx context.Context, s *Service, id string, action Action, _ func(string)) (ActionResult, error) {
+	if action != ActionInvoke {
+		return ActionResult{}, fmt.Errorf("action %s not supported for lambda", action)
+	}
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return ActionResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result, err := ops.NewLambdaOps(client).TestInvoke(ctx, id, nil)
+	s.observe(ctx, "lambda", "Invoke", start, err)
+	if err != nil {
+		return ActionResult{}, err
+	}
+	return ActionResult{Action: action, ResourceID: id, Message: formatInvokeResult(result)}, nil
+}
+
+func formatInvokeResult(result *ops.InvokeResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Status: %d\n", result.StatusCode)
+	if result.ExecutedVersion != "" {
+		fmt.Fprintf(&b, "Version: %s\n", result.ExecutedVersion)
+	}
+	if result.FunctionError != "" {
+		fmt.Fprintf(&b, "Function Error: %s\n", result.FunctionError)
+	}
+	if result.Payload != "" {
+		fmt.Fprintf(&b, "Payload: %s\n", result.Payload)
+	}
+	if result.Log != "" {
+		fmt.Fprintf(&b, "Log:\n%s", result.Log)
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,161 @@
+package resource
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// PackageFile 為部署套件中的一個檔案。
+type PackageFile struct {
+	Name           string
+	Size           int64
+	CompressedSize int64
+	Modified       time.Time
+}
+
+// LambdaPackage 為下載到暫存目錄的 zip 部署套件；瀏覽結束後以 Close 刪除暫存檔。
+type LambdaPackage struct {
+	Name  string // 例如 orders.zip 或 orders-live.zip
+	Size  int64  // zip 檔大小
+	Files []PackageFile
+
+	dir         string
+	reader      *zip.ReadCloser
+	entries     map[string]*zip.File
+	previewSize int64
+}
+
+// TotalSize 回傳解壓縮後的總大小。
+func (p *LambdaPackage) TotalSize() int64 {
+	var total int64
+	for _, f := range p.Files {
+		total += f.Size
+	}
+	return total
+}
+
+// ReadFile 讀取套件中檔案的前段（最多預覽大小），truncated 表示檔案比讀取的內容長。
+func (p *LambdaPackage) ReadFile(name string) ([]byte, bool, error) {
+	entry, ok := p.entries[name]
+	if !ok {
+		return nil, false, fmt.Errorf("%s is not in %s", name, p.Name)
+	}
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, false, fmt.Errorf("open %s: %w", name, err)
+	}
+	defer rc.Close()
+	// 多讀一個位元組判斷是否截斷，不依賴 zip 標頭中的大小
+	data, err := io.ReadAll(io.LimitReader(rc, p.previewSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("read %s: %w", name, err)
+	}
+	if int64(len(data)) > p.previewSize {
+		return data[:p.previewSize], true, nil
+	}
+	return data, false, nil
+}
+
+// Close 關閉 zip 並刪除暫存目錄。
+func (p *LambdaPackage) Close() error {
+	err := p.reader.Close()
+	if rmErr := os.RemoveAll(p.dir); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// DownloadLambdaCode 將函式（或版本、alias）的 zip 部署套件下載到本機目錄 dir，
+// 檔名為 <函式>.zip 或 <函式>-<版本或 alias>.zip。傳輸不套用 API timeout，以 ctx 取消。
+func (s *Service) DownloadLambdaCode(ctx context.Context, target FunctionTarget, dir string, onProgress func(TransferProgress)) (TransferResult, error) {
+	dest := filepath.Join(dir, packageFileName(target))
+	code, err := s.downloadLambdaCode(ctx, target, dest, onProgress)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	return TransferResult{Files: 1, Bytes: code.CodeSizeBytes}, nil
+}
+
+// OpenLambdaPackage 將部署套件下載到暫存目錄並開啟 zip，列出檔案（依路徑排序，不含目錄項目）。
+func (s *Service) OpenLambdaPackage(ctx context.Context, target FunctionTarget, onProgress func(TransferProgress)) (*LambdaPackage, error) {
+	dir, err := os.MkdirTemp("", "aws-tui-lambda-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp directory: %w", err)
+	}
+	name := packageFileName(target)
+	code, err := s.downloadLambdaCode(ctx, target, filepath.Join(dir, name), onProgress)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	reader, err := zip.OpenReader(filepath.Join(dir, name))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+
+	pkg := &LambdaPackage{
+		Name:        name,
+		Size:        code.CodeSizeBytes,
+		dir:         dir,
+		reader:      reader,
+		entries:     make(map[string]*zip.File, len(reader.File)),
+		previewSize: s.previewSize,
+	}
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		pkg.entries[f.Name] = f
+		pkg.Files = append(pkg.Files, PackageFile{
+			Name:           f.Name,
+			Size:           int64(f.UncompressedSize64),
+			CompressedSize: int64(f.CompressedSize64),
+			Modified:       f.Modified,
+		})
+	}
+	slices.SortFunc(pkg.Files, func(a, b PackageFile) int { return strings.Compare(a.Name, b.Name) })
+	return pkg, nil
+}
+
+// downloadLambdaCode 取得 presigned URL（有效約 10 分鐘，每次重新取得）後下載到 dest。
+func (s *Service) downloadLambdaCode(ctx context.Context, target FunctionTarget, dest string, onProgress func(TransferProgress)) (models.LambdaCode, error) {
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.LambdaCode{}, err
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	start := time.Now()
+	code, err := s.lambdaRepo.GetCode(lookupCtx, client, target.ARN())
+	s.observe(ctx, "lambda", "GetFunction", start, err)
+	cancel()
+	if err != nil {
+		return models.LambdaCode{}, err
+	}
+
+	file := filepath.Base(dest)
+	tracker := newTransferTracker([]models.S3Object{{Key: file, Size: code.CodeSizeBytes}}, onProgress)
+	tracker.begin(file)
+	if err := s.lambdaRepo.DownloadCode(ctx, code, dest, tracker.add); err != nil {
+		return models.LambdaCode{}, err
+	}
+	tracker.finish()
+	return code, nil
+}
+
+// packageFileName 回傳部署套件的本機檔名；限定版本或 alias 時加上後綴（$LATEST 不會出現在檔名中）。
+func packageFileName(target FunctionTarget) string {
+	if target.Qualifier == "" {
+		return target.Name + ".zip"
+	}
+	return target.Name + "-" + target.Qualifier + ".zip"
+}
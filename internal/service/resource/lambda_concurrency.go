@@ -0,0 +1,296 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/metrics"
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// concurrencyWindow 為並行數面板查詢指標的時間範圍（每分鐘一點）。
+	concurrencyWindow = 3 * time.Hour
+	// atLimitRatio 為視為「達到上限」的並行數比例；每分鐘的最大值可能略低於實際尖峰。
+	atLimitRatio = 0.9
+)
+
+// concurrencyMetrics 為 lambdaQueries 中與並行數相關的序列。
+var concurrencyMetrics = []string{"concurrent", "throttles"}
+
+// ThrottleCause 為 throttle 最可能的原因。
+type ThrottleCause string
+
+const (
+	// ThrottleNone 表示期間內沒有 throttle。
+	ThrottleNone ThrottleCause = "none"
+	// ThrottleReserved 表示被 throttle 時並行數已達保留並行數。
+	ThrottleReserved ThrottleCause = "reserved"
+	// ThrottleAccount 表示未設定保留並行數，被 throttle 時已用盡帳號未保留的容量。
+	ThrottleAccount ThrottleCause = "account"
+	// ThrottleBurst 表示被 throttle 時並行數尚未達上限（擴展速率或突發流量）。
+	ThrottleBurst ThrottleCause = "burst"
+)
+
+// ConcurrencyStats 為並行數與 throttle 序列的對照結果。
+type ConcurrencyStats struct {
+	PeakConcurrent   float64
+	PeakAt           time.Time
+	Throttles        float64
+	ThrottledMinutes int
+	LastThrottle     time.Time
+	// AtLimitMinutes 為有 throttle 且並行數達上限 90% 以上的分鐘數
+	AtLimitMinutes int
+	Cause          ThrottleCause
+}
+
+// ConcurrencyPanel 為函式並行數面板的內容。
+type ConcurrencyPanel struct {
+	Function    string
+	Account     models.LambdaAccountConcurrency
+	Reserved    *int32 // nil 表示未設定保留並行數
+	Provisioned []models.LambdaProvisionedConcurrency
+	Concurrent  []metrics.Point
+	Throttles   []metrics.Point
+	Stats       ConcurrencyStats
+	Start, End  time.Time
+	// MetricsErr 為指標查詢失敗的原因；不影響面板的設定內容
+	MetricsErr error
+}
+
+// Limit 回傳函式可同時執行的上限：有保留並行數時為保留值，否則為帳號未保留的容量。
+func (p ConcurrencyPanel) Limit() int32 {
+	if p.Reserved != nil {
+		return *p.Reserved
+	}
+	return p.Account.Unreserved
+}
+
+// ProvisionedTotal 回傳 qualifier 以外其他 alias 與版本要求的 provisioned concurrency 總和；qualifier 為空字串時加總全部。
+func (p ConcurrencyPanel) ProvisionedTotal(except string) int32 {
+	var total int32
+	for _, c := range p.Provisioned {
+		if c.Qualifier != except {
+			total += c.Requested
+		}
+	}
+	return total
+}
+
+// ValidateReserved 檢查新的保留並行數：帳號必須保留至少 100 個未保留的並行數，
+// 且不能低於各 alias 與版本的 provisioned concurrency 總和。reserved 為 nil 表示移除。
+func (p ConcurrencyPanel) ValidateReserved(reserved *int32) error {
+	if reserved == nil {
+		return nil
+	}
+	var current int32
+	if p.Reserved != nil {
+		current = *p.Reserved
+	}
+	if limit := p.Account.Unreserved + current - repo.MinUnreservedConcurrency; *reserved > limit {
+		return fmt.Errorf("reserved concurrency %d exceeds %d: the account must keep %d concurrency unreserved", *reserved, max(limit, 0), repo.MinUnreservedConcurrency)
+	}
+	if provisioned := p.ProvisionedTotal(""); *reserved < provisioned {
+		return fmt.Errorf("reserved concurrency %d is below the %d provisioned for aliases and versions", *reserved, provisioned)
+	}
+	return nil
+}
+
+// ValidateProvisioned 檢查 alias 或版本的 provisioned concurrency：有保留並行數時所有 provisioned 總和不得超過保留值，
+// 否則不得讓帳號未保留的容量低於 100。count 為 0 表示移除。
+func (p ConcurrencyPanel) ValidateProvisioned(qualifier string, count int32) error {
+	if qualifier == "" || qualifier == LatestVersion {
+		return fmt.Errorf("provisioned concurrency needs an alias or a published version, not %s", LatestVersion)
+	}
+	if count == 0 {
+		return nil
+	}
+	others := p.ProvisionedTotal(qualifier)
+	if p.Reserved != nil {
+		if others+count > *p.Reserved {
+			return fmt.Errorf("provisioned concurrency %d plus %d on other aliases/versions exceeds the reserved concurrency %d", count, others, *p.Reserved)
+		}
+		return nil
+	}
+	current := p.ProvisionedTotal("") - others
+	if limit := p.Account.Unreserved + current - repo.MinUnreservedConcurrency; count > limit {
+		return fmt.Errorf("provisioned concurrency %d exceeds %d: the account must keep %d concurrency unreserved", count, max(limit, 0), repo.MinUnreservedConcurrency)
+	}
+	return nil
+}
+
+// ParseReservedConcurrency 解析保留並行數輸入；空白或 "none" 表示移除保留並行數（回傳 nil）。
+func ParseReservedConcurrency(text string) (*int32, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.EqualFold(text, "none") {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(text, 10, 32)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("reserved concurrency must be a number >= 0 or empty to remove it, got %q", text)
+	}
+	reserved := int32(n)
+	return &reserved, nil
+}
+
+// ParseProvisionedConcurrency 解析 "<alias 或版本> <數量>"，例如 "live 10"；數量 0 表示移除。
+func ParseProvisionedConcurrency(text string) (string, int32, error) {
+	fields := strings.Fields(text)
+	if len(fields) != 2 {
+		return "", 0, fmt.Errorf("use <alias or version> <count>, e.g. live 10")
+	}
+	n, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil || n < 0 {
+		return "", 0, fmt.Errorf("provisioned concurrency must be a number >= 0, got %q", fields[1])
+	}
+	return fields[0], int32(n), nil
+}
+
+// AnalyzeConcurrency 逐分鐘對照並行數與 throttle：統計尖峰、throttle 次數，
+// 以及 throttle 發生時並行數是否已接近 limit，推斷 throttle 的原因。
+func AnalyzeConcurrency(concurrent, throttles []metrics.Point, limit int32, reserved bool) ConcurrencyStats {
+	stats := ConcurrencyStats{Cause: ThrottleNone}
+	byMinute := make(map[time.Time]float64, len(concurrent))
+	for _, p := range concurrent {
+		byMinute[p.Timestamp.Truncate(time.Minute)] = p.Value
+		if p.Value > stats.PeakConcurrent {
+			stats.PeakConcurrent, stats.PeakAt = p.Value, p.Timestamp
+		}
+	}
+	for _, p := range throttles {
+		if p.Value <= 0 {
+			continue
+		}
+		stats.Throttles += p.Value
+		stats.ThrottledMinutes++
+		if p.Timestamp.After(stats.LastThrottle) {
+			stats.LastThrottle = p.Timestamp
+		}
+		if byMinute[p.Timestamp.Truncate(time.Minute)] >= atLimitRatio*float64(limit) {
+			stats.AtLimitMinutes++
+		}
+	}
+
+	switch {
+	case stats.ThrottledMinutes == 0:
+	case stats.AtLimitMinutes*2 >= stats.ThrottledMinutes && reserved:
+		stats.Cause = ThrottleReserved
+	case stats.AtLimitMinutes*2 >= stats.ThrottledMinutes:
+		stats.Cause = ThrottleAccount
+	default:
+		stats.Cause = ThrottleBurst
+	}
+	return stats
+}
+
+// LoadConcurrency 查詢帳號並行數上限、函式的保留與 provisioned concurrency，
+// 以及最近 3 小時的並行數與 throttle 指標（指標失敗時仍回傳設定，錯誤記錄在 MetricsErr）。
+func (s *Service) LoadConcurrency(ctx context.Context, target FunctionTarget) (ConcurrencyPanel, error) {
+	panel := ConcurrencyPanel{Function: target.Name}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return panel, err
+	}
+
+	start := time.Now()
+	panel.Account, err = s.lambdaRepo.GetAccountConcurrency(ctx, client)
+	s.observe(ctx, "lambda", "GetAccountSettings", start, err)
+	if err != nil {
+		return panel, err
+	}
+	start = time.Now()
+	panel.Reserved, err = s.lambdaRepo.GetReservedConcurrency(ctx, client, target.Function)
+	s.observe(ctx, "lambda", "GetFunctionConcurrency", start, err)
+	if err != nil {
+		return panel, err
+	}
+	start = time.Now()
+	panel.Provisioned, err = s.lambdaRepo.ListProvisionedConcurrency(ctx, client, target.Function)
+	s.observe(ctx, "lambda", "ListProvisionedConcurrencyConfigs", start, err)
+	if err != nil {
+		return panel, err
+	}
+
+	panel.End = time.Now()
+	panel.Start = panel.End.Add(-concurrencyWindow)
+	series, err := s.concurrencyMetrics(ctx, target.Name, panel.Start, panel.End)
+	if err != nil {
+		panel.MetricsErr = err
+		return panel, nil
+	}
+	panel.Concurrent = series["concurrent"].Points
+	panel.Throttles = series["throttles"].Points
+	panel.Stats = AnalyzeConcurrency(panel.Concurrent, panel.Throttles, panel.Limit(), panel.Reserved != nil)
+	return panel, nil
+}
+
+// concurrencyMetrics 以 metrics.LambdaQueries 中的 concurrent 與 throttles 查詢函式（以名稱為 dimension）的指標。
+func (s *Service) concurrencyMetrics(ctx context.Context, name string, from, to time.Time) (map[string]metrics.Series, error) {
+	queries := slices.DeleteFunc(metrics.LambdaQueries(name), func(q metrics.Query) bool {
+		return !slices.Contains(concurrencyMetrics, q.ID)
+	})
+	client, err := s.factory.CloudWatch(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, fmt.Errorf("create cloudwatch client: %w", err)
+	}
+	start := time.Now()
+	series, err := metrics.NewFetcher(client).Fetch(ctx, metrics.Options{
+		StartTime: from,
+		EndTime:   to,
+		Period:    60,
+		Queries:   queries,
+	})
+	s.observe(ctx, "cloudwatch", "GetMetricData", start, err)
+	if err != nil {
+		return nil, fmt.Errorf("fetch metrics: %w", err)
+	}
+	return series, nil
+}
+
+// ApplyReservedConcurrency 設定或移除（reserved 為 nil）函式的保留並行數。
+func (s *Service) ApplyReservedConcurrency(ctx context.Context, target FunctionTarget, reserved *int32) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return err
+	}
+	op := "PutFunctionConcurrency"
+	if reserved == nil {
+		op = "DeleteFunctionConcurrency"
+	}
+	start := time.Now()
+	err = s.lambdaRepo.SetReservedConcurrency(ctx, client, target.Function, reserved)
+	s.observe(ctx, "lambda", op, start, err)
+	if err != nil {
+		return err
+	}
+	s.forgetDetail(KindLambda, target.Function)
+	return nil
+}
+
+// ApplyProvisionedConcurrency 設定或移除（count 為 0）alias 或版本的 provisioned concurrency；
+// 設定後 Lambda 於背景配置，狀態由 IN_PROGRESS 變為 READY。
+func (s *Service) ApplyProvisionedConcurrency(ctx context.Context, target FunctionTarget, qualifier string, count int32) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return err
+	}
+	op := "PutProvisionedConcurrencyConfig"
+	if count == 0 {
+		op = "DeleteProvisionedConcurrencyConfig"
+	}
+	start := time.Now()
+	err = s.lambdaRepo.SetProvisionedConcurrency(ctx, client, target.Function, qualifier, count)
+	s.observe(ctx, "lambda", op, start, err)
+	return err
+}
@@ -0,0 +1,220 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// lambdaUpdatePollInterval 為等待設定更新完成時查詢 LastUpdateStatus 的間隔。
+	lambdaUpdatePollInterval = 2 * time.Second
+	// lambdaUpdateTimeout 為等待設定更新完成的上限。
+	lambdaUpdateTimeout = 5 * time.Minute
+	// MaskedEnvValue 為機密環境變數在詳情、編輯器與差異中顯示的值。
+	MaskedEnvValue = "********"
+)
+
+// lambdaConfigHint 附加在編輯器內容後，說明遮蔽值的用法。
+const lambdaConfigHint = `# Values of secret-looking variables are shown as ` + MaskedEnvValue + `;
+# keep them to leave the value unchanged, or type a new value to replace it.
+`
+
+// LambdaConfigEdit 為開啟編輯器時載入的函式設定。
+type LambdaConfigEdit struct {
+	Function   string // ARN
+	Name       string
+	RevisionID string
+	Original   models.LambdaConfig // 實際值（未遮蔽）
+	Text       string              // 編輯器內容（機密值已遮蔽）
+}
+
+// LambdaConfigChange 為已驗證、尚未套用的設定變更。
+type LambdaConfigChange struct {
+	Function   string
+	Name       string
+	RevisionID string
+	// Update 只包含有變更的欄位：MemoryMB、TimeoutSec 為 0 與 Environment 為 nil 表示不變更
+	Update models.LambdaConfig
+	Diff   []diff.Line // 機密值已遮蔽
+}
+
+// Changed 回傳是否有需要套用的變更。
+func (c LambdaConfigChange) Changed() bool {
+	return c.Update.MemoryMB != 0 || c.Update.TimeoutSec != 0 || c.Update.Environment != nil
+}
+
+// LoadLambdaConfig 以 GetFunction 取得函式目前的記憶體、逾時與環境變數（不查詢觸發來源），轉為機密值已遮蔽的 YAML。
+func (s *Service) LoadLambdaConfig(ctx context.Context, function string) (LambdaConfigEdit, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return LambdaConfigEdit{}, err
+	}
+	start := time.Now()
+	fn, err := s.lambdaRepo.GetFunctionConfig(ctx, client, function)
+	s.observe(ctx, "lambda", "GetFunction", start, err)
+	if err != nil {
+		return LambdaConfigEdit{}, err
+	}
+	if fn.LastUpdateStatus == "InProgress" {
+		return LambdaConfigEdit{}, fmt.Errorf("%s has an update in progress; try again when it completes", fn.Name)
+	}
+
+	edit := LambdaConfigEdit{
+		Function:   function,
+		Name:       fn.Name,
+		RevisionID: fn.RevisionID,
+		Original:   models.LambdaConfig{MemoryMB: fn.MemoryMB, TimeoutSec: fn.TimeoutSec, Environment: fn.EnvVars},
+	}
+	text, err := lambdaConfigText(edit.Original, nil)
+	if err != nil {
+		return LambdaConfigEdit{}, err
+	}
+	edit.Text = text + lambdaConfigHint
+	return edit, nil
+}
+
+// PlanLambdaConfig 解析並驗證編輯後的 YAML（不允許未知欄位），保留維持遮蔽值的機密變數，
+// 回傳只含變更欄位的更新與遮蔽機密值後的差異。
+func PlanLambdaConfig(edit LambdaConfigEdit, text string) (LambdaConfigChange, error) {
+	change := LambdaConfigChange{Function: edit.Function, Name: edit.Name, RevisionID: edit.RevisionID}
+
+	var edited models.LambdaConfig
+	if err := decodeYAMLDocument(text, &edited); err != nil {
+		return change, err
+	}
+	for key, value := range edited.Environment {
+		if value != MaskedEnvValue {
+			continue
+		}
+		original, ok := edit.Original.Environment[key]
+		if !ok {
+			return change, fmt.Errorf("environment variable %s: replace %s with a value", key, MaskedEnvValue)
+		}
+		edited.Environment[key] = original
+	}
+	if err := repo.ValidateLambdaConfig(edited); err != nil {
+		return change, err
+	}
+
+	if edited.MemoryMB != edit.Original.MemoryMB {
+		change.Update.MemoryMB = edited.MemoryMB
+	}
+	if edited.TimeoutSec != edit.Original.TimeoutSec {
+		change.Update.TimeoutSec = edited.TimeoutSec
+	}
+	if !maps.Equal(edited.Environment, edit.Original.Environment) {
+		change.Update.Environment = edited.Environment
+		if change.Update.Environment == nil {
+			// 空的 map 才會清除全部環境變數
+			change.Update.Environment = map[string]string{}
+		}
+	}
+
+	before, err := lambdaConfigText(edit.Original, nil)
+	if err != nil {
+		return change, err
+	}
+	after, err := lambdaConfigText(edited, edit.Original.Environment)
+	if err != nil {
+		return change, err
+	}
+	change.Diff = diff.Text(before, after)
+	return change, nil
+}
+
+// ApplyLambdaConfig 以 UpdateFunctionConfiguration 套用變更；函式設定在開啟編輯器後已被修改時拒絕（RevisionId 不符）。
+func (s *Service) ApplyLambdaConfig(ctx context.Context, change LambdaConfigChange) error {
+	if !change.Changed() {
+		return fmt.Errorf("no changes to apply")
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = s.lambdaRepo.UpdateConfiguration(ctx, client, change.Function, change.RevisionID, change.Update)
+	s.observe(ctx, "lambda", "UpdateFunctionConfiguration", start, err)
+	if err != nil {
+		return err
+	}
+	s.forgetDetail(KindLambda, change.Function)
+	return nil
+}
+
+// WaitLambdaUpdate 輪詢 LastUpdateStatus 直到 Successful（最多 lambdaUpdateTimeout），onStatus 回報每次查詢結果。
+func (s *Service) WaitLambdaUpdate(ctx context.Context, function string, onStatus func(status string)) error {
+	ctx, cancel := context.WithTimeout(ctx, lambdaUpdateTimeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = s.lambdaRepo.WaitForUpdate(ctx, client, function, lambdaUpdatePollInterval, onStatus)
+	s.observe(ctx, "lambda", "GetFunctionConfiguration", start, err)
+	if err == nil {
+		s.forgetDetail(KindLambda, function)
+	}
+	return err
+}
+
+// lambdaConfigText 將設定轉為 YAML，機密變數的值以 MaskedEnvValue 顯示；
+// previous 不為 nil 時，值與 previous 不同的機密變數標示為已變更（只用於差異）。
+func lambdaConfigText(cfg models.LambdaConfig, previous map[string]string) (string, error) {
+	doc := cfg
+	if cfg.Environment == nil {
+		return marshalYAMLDocument(doc)
+	}
+	doc.Environment = make(map[string]string, len(cfg.Environment))
+	for key, value := range cfg.Environment {
+		masked := value
+		if SecretEnvKey(key) {
+			masked = MaskedEnvValue
+			if old, ok := previous[key]; ok && old != value {
+				masked += " (changed)"
+			}
+		}
+		doc.Environment[key] = masked
+	}
+	return marshalYAMLDocument(doc)
+}
+
+// maskEnv 回傳機密變數的值已遮蔽的副本。
+func maskEnv(env map[string]string) map[string]string {
+	if env == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(env))
+	for key, value := range env {
+		if SecretEnvKey(key) {
+			value = MaskedEnvValue
+		}
+		masked[key] = value
+	}
+	return masked
+}
+
+// SecretEnvKey 判斷環境變數名稱是否像是機密（密碼、token、金鑰等），其值在畫面上一律遮蔽。
+func SecretEnvKey(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, word := range []string{"SECRET", "PASSWORD", "PASSWD", "TOKEN", "CREDENTIAL", "PRIVATE", "APIKEY", "DSN"} {
+		if strings.Contains(upper, word) {
+			return true
+		}
+	}
+	// API_KEY、ACCESS_KEY、DB_PASS 等以這些字結尾的名稱；KEY_PREFIX 之類不算
+	parts := strings.FieldsFunc(upper, func(r rune) bool { return r == '_' || r == '-' || r == '.' })
+	return len(parts) > 0 && slices.Contains([]string{"KEY", "PWD", "PASS", "AUTH"}, parts[len(parts)-1])
+}
@@ -0,0 +1,64 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/ops"
+)
+
+// ParseInvokePayload 驗證工作台輸入的 JSON 並壓縮；空白輸入回傳 nil（不送 payload）。
+// 語法錯誤會標示行號與欄位。
+func ParseInvokePayload(text string) ([]byte, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(text)); err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			line, col := lineColumn(text, syntax.Offset)
+			return nil, fmt.Errorf("invalid JSON at line %d, column %d: %w", line, col, err)
+		}
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// FormatInvokePayload 將回應 payload 縮排顯示；不是 JSON 時原樣回傳。
+func FormatInvokePayload(payload string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(payload), "", "  "); err != nil {
+		return payload
+	}
+	return buf.String()
+}
+
+// InvokeLambda 以 RequestResponse 呼叫函式並取回 tail log。呼叫可能執行到函式逾時（最長 15 分鐘），
+// 因此不套用一般查詢的 timeout，由呼叫端透過 ctx 取消。
+func (s *Service) InvokeLambda(ctx context.Context, target FunctionTarget, payload []byte) (*ops.InvokeResult, error) {
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	result, err := ops.NewLambdaOps(client).TestInvoke(ctx, target.ARN(), payload)
+	s.observe(ctx, "lambda", "Invoke", start, err)
+	return result, err
+}
+
+// lineColumn 將位元組位移轉為 1 起算的行號與欄位。
+func lineColumn(text string, offset int64) (int, int) {
+	if offset > int64(len(text)) {
+		offset = int64(len(text))
+	}
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	return line, int(offset) - strings.LastIndex(before, "\n") - 1
+}
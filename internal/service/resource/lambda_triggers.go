@@ -0,0 +1,225 @@
+package resource
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// KindLambdaTriggers 表示目前函式的觸發來源：event source mappings 與 resource policy 允許的服務。
+const KindLambdaTriggers Kind = "lambda-triggers"
+
+const (
+	// ActionEnableMapping 啟用 event source mapping（Lambda 恢復輪詢來源）。
+	ActionEnableMapping Action = "enable-mapping"
+	// ActionDisableMapping 停用 event source mapping（Lambda 停止輪詢，訊息留在來源）。
+	ActionDisableMapping Action = "disable-mapping"
+)
+
+// mappingPollInterval 為等待 mapping 狀態轉換時的查詢間隔。
+const mappingPollInterval = 3 * time.Second
+
+func init() {
+	Register(Definition{
+		Kind:  KindLambdaTriggers,
+		Label: "resource.lambda_triggers",
+		Columns: []models.Column{
+			nameColumn,
+			typeColumn,
+			column("column.state", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.source", "source"),
+		},
+		List:       listLambdaTriggers,
+		SearchText: func(item models.ListItem) string { return item.Name + item.Type + item.Metadata["source"] },
+		Scope:      func(s *Service) string { return s.currentFunction },
+		Actions:    []Action{ActionEnableMapping, ActionDisableMapping},
+		Precheck:   precheckMappingAction,
+		Execute:    executeMappingAction,
+		Keys: map[rune]Command{
+			'I': CommandInvoke,
+			'C': CommandConcurrency,
+		},
+	})
+}
+
+func listLambdaTriggers(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	if s.currentFunction == "" {
+		return nil, nil, fmt.Errorf("no function selected")
+	}
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	mappings, err := s.lambdaRepo.ListEventSourceMappings(ctx, client, s.currentFunction)
+	s.observe(ctx, "lambda", "ListEventSourceMappings", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	start = time.Now()
+	permissions, err := s.lambdaRepo.PolicyTriggers(ctx, client, s.currentFunction)
+	s.observe(ctx, "lambda", "GetPolicy", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, details := buildLambdaTriggerList(append(mappings, permissions...))
+	return items, details, nil
+}
+
+func buildLambdaTriggerList(triggers []models.LambdaTrigger) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(triggers))
+	details := make(map[string]models.DetailView, len(triggers))
+	for _, t := range triggers {
+		kind := "Permission"
+		if t.Mapping {
+			kind = "Mapping"
+		}
+		items = append(items, models.ListItem{
+			ID:       t.ID,
+			Name:     cmp.Or(t.Service, "Other") + " " + shortSource(t.Source),
+			Type:     kind,
+			Status:   t.State,
+			Metadata: map[string]string{"source": t.Source},
+		})
+
+		overview := map[string]string{
+			"ID":      t.ID,
+			"Service": t.Service,
+			"Type":    kind,
+			"Source":  t.Source,
+		}
+		if t.Mapping {
+			overview["State"] = t.State
+			overview["Last Result"] = t.LastResult
+			if t.BatchSize > 0 {
+				overview["Batch Size"] = fmt.Sprintf("%d", t.BatchSize)
+			}
+		} else {
+			overview["Source Account"] = t.SourceAccount
+		}
+		details[t.ID] = models.DetailView{
+			Overview:  overview,
+			Relations: map[string][]string{"Actions": t.Actions},
+		}
+	}
+	return items, details
+}
+
+// TriggerSummary 回傳函式詳情 Triggers 中顯示的單行說明，例如 "SQS arn:aws:sqs:...:orders (Enabled)"。
+func TriggerSummary(t models.LambdaTrigger) string {
+	summary := strings.TrimSpace(cmp.Or(t.Service, "Other") + " " + t.Source)
+	if t.State != "" {
+		summary += " (" + t.State + ")"
+	}
+	return summary
+}
+
+// shortSource 回傳來源 ARN 的資源名稱部分（例如 queue 名稱或 rule/name）；非 ARN 原樣回傳。
+func shortSource(source string) string {
+	if !strings.HasPrefix(source, "arn:") {
+		return source
+	}
+	parts := strings.SplitN(source, ":", 6)
+	if len(parts) < 6 {
+		return source
+	}
+	// DynamoDB/Kinesis stream ARN 以 table/<name>/stream/<time> 表示，只保留 table 或 stream 名稱
+	resource := parts[5]
+	if table, _, ok := strings.Cut(resource, "/stream/"); ok {
+		resource = table
+	}
+	return resource
+}
+
+// findMapping 重新列出目前函式的 event source mappings 並找出指定的 mapping，確保操作依據的是最新狀態；
+// 不是 mapping（resource policy 陳述）時 ok 為 false。
+func (s *Service) findMapping(ctx context.Context, uuid string) (models.LambdaTrigger, bool, error) {
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.LambdaTrigger{}, false, err
+	}
+	start := time.Now()
+	mappings, err := s.lambdaRepo.ListEventSourceMappings(ctx, client, s.currentFunction)
+	s.observe(ctx, "lambda", "ListEventSourceMappings", start, err)
+	if err != nil {
+		return models.LambdaTrigger{}, false, err
+	}
+	for _, m := range mappings {
+		if m.ID == uuid {
+			return m, true, nil
+		}
+	}
+	return models.LambdaTrigger{}, false, nil
+}
+
+// precheckMappingAction 只允許切換 event source mapping，並於停用時提示來源的訊息會累積。
+func precheckMappingAction(ctx context.Context, s *Service, id string, action Action) (bool, Notice, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	mapping, ok, err := s.findMapping(ctx, id)
+	if err != nil {
+		return false, Notice{}, err
+	}
+	if !ok {
+		return false, Notice{Key: "lambda.mapping_only"}, nil
+	}
+	switch action {
+	case ActionEnableMapping:
+		if mapping.State != "Disabled" {
+			return false, Notice{Key: "lambda.mapping_state", Args: []any{mapping.State}}, nil
+		}
+		return true, Notice{Key: "lambda.mapping_enable_warning", Args: []any{mapping.Source, s.currentFunctionName}}, nil
+	case ActionDisableMapping:
+		if mapping.State != "Enabled" {
+			return false, Notice{Key: "lambda.mapping_state", Args: []any{mapping.State}}, nil
+		}
+		return true, Notice{Key: "lambda.mapping_disable_warning", Args: []any{mapping.Source}}, nil
+	}
+	return false, Notice{}, fmt.Errorf("action %s not supported for lambda triggers", action)
+}
+
+// executeMappingAction 啟用或停用 mapping，並等待狀態由 Enabling/Disabling 轉為 Enabled/Disabled。
+func executeMappingAction(ctx context.Context, s *Service, id string, action Action, onWait func(string)) (ActionResult, error) {
+	var enabled bool
+	switch action {
+	case ActionEnableMapping:
+		enabled = true
+	case ActionDisableMapping:
+	default:
+		return ActionResult{}, fmt.Errorf("action %s not supported for lambda triggers", action)
+	}
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return ActionResult{}, err
+	}
+	err = s.runOp(ctx, "lambda", "UpdateEventSourceMapping", func(ctx context.Context) error {
+		_, err := s.lambdaRepo.SetEventSourceMappingEnabled(ctx, client, id, enabled)
+		return err
+	})
+	if err != nil {
+		return ActionResult{}, err
+	}
+	s.forgetDetail(KindLambda, s.currentFunction)
+
+	target := "Disabled"
+	if enabled {
+		target = "Enabled"
+	}
+	if onWait != nil {
+		onWait(target)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, actionWaitTimeout)
+	defer cancel()
+	start := time.Now()
+	err = s.lambdaRepo.WaitForMappingState(waitCtx, client, id, target, mappingPollInterval)
+	s.observe(ctx, "lambda", "GetEventSourceMapping", start, err)
+	if err != nil {
+		return ActionResult{}, err
+	}
+	return ActionResult{Action: action, ResourceID: id, TargetState: target, Refresh: true}, nil
+}
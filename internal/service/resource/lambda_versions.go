@@ -0,0 +1,300 @@
+package resource
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// KindLambdaVersions 表示目前函式的 aliases 與已發佈版本（含 $LATEST）。
+const KindLambdaVersions Kind = "lambda-versions"
+
+// LatestVersion 為未發佈、可編輯的函式版本。
+const LatestVersion = "$LATEST"
+
+func init() {
+	Register(Definition{
+		Kind:  KindLambdaVersions,
+		Label: "resource.lambda_versions",
+		Columns: []models.Column{
+			nameColumn,
+			typeColumn,
+			column("column.status", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.routing", "routing"),
+			metadataColumn("column.description", "description"),
+		},
+		List:     listLambdaVersions,
+		Describe: describeLambdaVersion,
+		Scope:    func(s *Service) string { return s.currentFunction },
+		Keys: map[rune]Command{
+			'd': CommandCodeDownload,
+			'v': CommandPackageBrowser,
+			'I': CommandInvoke,
+			'C': CommandConcurrency,
+			'E': CommandAliasRouting,
+		},
+	})
+}
+
+func listLambdaVersions(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	if s.currentFunction == "" {
+		return nil, nil, fmt.Errorf("no function selected")
+	}
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	versions, err := s.lambdaRepo.ListVersions(ctx, client, s.currentFunction)
+	s.observe(ctx, "lambda", "ListVersionsByFunction", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	start = time.Now()
+	aliases, err := s.lambdaRepo.ListAliases(ctx, client, s.currentFunction)
+	s.observe(ctx, "lambda", "ListAliases", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, details := buildLambdaVersionList(versions, aliases)
+	return items, details, nil
+}
+
+// buildLambdaVersionList 先列 aliases，再由新到舊列出版本（$LATEST 在最前），版本標示指向它的 aliases 與流量比例。
+func buildLambdaVersionList(versions []models.LambdaVersion, aliases []models.LambdaAlias) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(versions)+len(aliases))
+	details := make(map[string]models.DetailView, len(versions)+len(aliases))
+
+	servedBy := make(map[string][]string)
+	for _, a := range aliases {
+		routing := AliasRouting(a)
+		items = append(items, models.ListItem{
+			ID:     a.Name,
+			Name:   a.Name,
+			Type:   "Alias",
+			Status: "→ " + a.Version,
+			Metadata: map[string]string{
+				"routing":     routing,
+				"description": a.Description,
+			},
+		})
+		details[a.Name] = models.DetailView{
+			Overview: map[string]string{
+				"Alias":       a.Name,
+				"ARN":         a.ARN,
+				"Version":     a.Version,
+				"Routing":     routing,
+				"Description": a.Description,
+			},
+		}
+		primary := fmt.Sprintf("%s %s", a.Name, formatWeight(1-a.AdditionalWeight))
+		servedBy[a.Version] = append(servedBy[a.Version], primary)
+		if a.AdditionalVersion != "" {
+			servedBy[a.AdditionalVersion] = append(servedBy[a.AdditionalVersion], fmt.Sprintf("%s %s", a.Name, formatWeight(a.AdditionalWeight)))
+		}
+	}
+
+	sorted := slices.Clone(versions)
+	slices.SortStableFunc(sorted, func(a, b models.LambdaVersion) int {
+		return cmp.Compare(versionOrder(b.Version), versionOrder(a.Version))
+	})
+	for _, v := range sorted {
+		items = append(items, models.ListItem{
+			ID:     v.Version,
+			Name:   v.Version,
+			Type:   "Version",
+			Status: v.State,
+			Metadata: map[string]string{
+				"routing":     strings.Join(servedBy[v.Version], ", "),
+				"description": v.Description,
+			},
+		})
+		details[v.Version] = models.DetailView{
+			Overview: map[string]string{
+				"Version":       v.Version,
+				"Description":   v.Description,
+				"Runtime":       v.Runtime,
+				"State":         v.State,
+				"Code SHA256":   v.CodeSHA256,
+				"Code Size":     formatSize(v.CodeSizeBytes),
+				"Last Modified": v.LastModified,
+			},
+			Relations: map[string][]string{"Aliases": servedBy[v.Version]},
+		}
+	}
+	return items, details
+}
+
+// describeLambdaVersion 以版本或 alias 限定的 ARN 查詢函式設定（$LATEST 為未限定的函式），alias 另加上 routing。
+func describeLambdaVersion(ctx context.Context, s *Service, id string) (models.DetailView, error) {
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	qualified := s.currentFunction
+	if id != LatestVersion {
+		qualified += ":" + id
+	}
+	start := time.Now()
+	fn, err := s.lambdaRepo.GetFunction(ctx, client, qualified)
+	s.observe(ctx, "lambda", "GetFunction", start, err)
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	detail := lambdaDetail(fn)
+	detail.Overview["Version"] = fn.Version
+	if fn.Version != id {
+		start = time.Now()
+		alias, err := s.lambdaRepo.GetAlias(ctx, client, s.currentFunction, id)
+		s.observe(ctx, "lambda", "GetAlias", start, err)
+		if err != nil {
+			return models.DetailView{}, err
+		}
+		detail.Overview["Alias"] = alias.Name
+		detail.Overview["Routing"] = AliasRouting(alias)
+	}
+	return detail, nil
+}
+
+// AliasRouting 說明 alias 的流量分配，例如 "4 (100%)" 或 "4 (90%) + 5 (10%)"。
+func AliasRouting(a models.LambdaAlias) string {
+	if a.AdditionalVersion == "" {
+		return a.Version + " (100%)"
+	}
+	return fmt.Sprintf("%s (%s) + %s (%s)", a.Version, formatWeight(1-a.AdditionalWeight), a.AdditionalVersion, formatWeight(a.AdditionalWeight))
+}
+
+// ParseAliasRouting 解析 alias 路由輸入："<version>" 將全部流量移到該版本，
+// "<version> <version>=<percent>" 將指定百分比（可含 %）分給第二個版本。
+func ParseAliasRouting(name, text string) (models.LambdaAlias, error) {
+	alias := models.LambdaAlias{Name: name}
+	fields := strings.Fields(text)
+	switch len(fields) {
+	case 1:
+		alias.Version = fields[0]
+	case 2:
+		additional, percent, ok := strings.Cut(fields[1], "=")
+		if !ok {
+			return alias, fmt.Errorf("use <version> <version>=<percent>, e.g. 4 5=10")
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSuffix(percent, "%"), 64)
+		if err != nil {
+			return alias, fmt.Errorf("invalid percent %q", percent)
+		}
+		alias.Version, alias.AdditionalVersion, alias.AdditionalWeight = fields[0], additional, weight/100
+	default:
+		return alias, fmt.Errorf("use <version> or <version> <version>=<percent>, e.g. 4 5=10")
+	}
+	return alias, repo.ValidateAliasRouting(alias)
+}
+
+// AliasChange 為已驗證、尚未套用的 alias 路由變更；Before 含載入時的 RevisionId。
+type AliasChange struct {
+	Function string
+	Before   models.LambdaAlias
+	After    models.LambdaAlias
+}
+
+// PlanAliasRouting 解析路由輸入，重新查詢 alias 與版本清單，確認目標版本存在且與目前設定不同。
+func (s *Service) PlanAliasRouting(ctx context.Context, name, text string) (AliasChange, error) {
+	change := AliasChange{Function: s.currentFunctionName}
+	after, err := ParseAliasRouting(name, text)
+	if err != nil {
+		return change, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return change, err
+	}
+
+	start := time.Now()
+	before, err := s.lambdaRepo.GetAlias(ctx, client, s.currentFunction, name)
+	s.observe(ctx, "lambda", "GetAlias", start, err)
+	if err != nil {
+		return change, err
+	}
+	start = time.Now()
+	versions, err := s.lambdaRepo.ListVersions(ctx, client, s.currentFunction)
+	s.observe(ctx, "lambda", "ListVersionsByFunction", start, err)
+	if err != nil {
+		return change, err
+	}
+	for _, v := range []string{after.Version, after.AdditionalVersion} {
+		if v != "" && !slices.ContainsFunc(versions, func(existing models.LambdaVersion) bool { return existing.Version == v }) {
+			return change, fmt.Errorf("version %s of %s does not exist", v, s.currentFunctionName)
+		}
+	}
+	if AliasRouting(before) == AliasRouting(after) {
+		return change, fmt.Errorf("alias %s already routes %s", name, AliasRouting(before))
+	}
+	after.Description = before.Description
+	change.Before, change.After = before, after
+	return change, nil
+}
+
+// ApplyAliasChange 以 UpdateAlias 套用路由；alias 在檢視後已被修改時拒絕（RevisionId 不符）。
+func (s *Service) ApplyAliasChange(ctx context.Context, change AliasChange) (models.LambdaAlias, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.LambdaAlias{}, err
+	}
+	start := time.Now()
+	alias, err := s.lambdaRepo.UpdateAlias(ctx, client, s.currentFunction, change.After, change.Before.RevisionID)
+	s.observe(ctx, "lambda", "UpdateAlias", start, err)
+	if err != nil {
+		return models.LambdaAlias{}, err
+	}
+	s.forgetDetail(KindLambdaVersions, change.After.Name)
+	return alias, nil
+}
+
+// PublishLambdaVersion 將目前函式的 $LATEST 發佈為新版本。$LATEST 與最新的已發佈版本相同時，
+// Lambda 不會建立新版本而回傳既有版本，此時 created 為 false。
+func (s *Service) PublishLambdaVersion(ctx context.Context, description string) (models.LambdaVersion, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Lambda(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.LambdaVersion{}, false, err
+	}
+	start := time.Now()
+	versions, err := s.lambdaRepo.ListVersions(ctx, client, s.currentFunction)
+	s.observe(ctx, "lambda", "ListVersionsByFunction", start, err)
+	if err != nil {
+		return models.LambdaVersion{}, false, err
+	}
+	start = time.Now()
+	version, err := s.lambdaRepo.PublishVersion(ctx, client, s.currentFunction, strings.TrimSpace(description), "")
+	s.observe(ctx, "lambda", "PublishVersion", start, err)
+	if err != nil {
+		return models.LambdaVersion{}, false, err
+	}
+	created := !slices.ContainsFunc(versions, func(v models.LambdaVersion) bool { return v.Version == version.Version })
+	return version, created, nil
+}
+
+// versionOrder 回傳排序用的版本號；$LATEST 排在所有已發佈版本之前。
+func versionOrder(version string) int {
+	if version == LatestVersion {
+		return math.MaxInt
+	}
+	n, _ := strconv.Atoi(version)
+	return n
+}
+
+// formatWeight 將 0-1 的比例轉為百分比字串（最多兩位小數）。
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(math.Round(weight*10000)/100, 'f', -1, 64) + "%"
+}
@@ -0,0 +1,171 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/logs"
+	"github.com/vincent119/awsGUITools/internal/aws/metrics"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/ops"
+)
+
+// KindRDS 表示 RDS 資料庫執行個體。
+const KindRDS Kind = "rds"
+
+func init() {
+	Register(Definition{
+		Kind:   KindRDS,
+		Label:  "resource.rds",
+		Hotkey: '2',
+		Columns: []models.Column{
+			nameColumn,
+			column("column.engine", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.endpoint", "endpoint"),
+		},
+		List:       listRDS,
+		SearchText: func(item models.ListItem) string { return item.Name + item.Status },
+		Describe:   describeRDS,
+		Metrics:    metrics.RDSQueries,
+		LogGroup:   logs.RDSLogGroup,
+		Actions:    []Action{ActionStart, ActionStop, ActionReboot},
+		Precheck:   precheckRDSAction,
+		Execute:    executeRDSAction,
+	})
+}
+
+func listRDS(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	client, err := s.factory.RDS(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	dbs, err := s.rdsRepo.ListInstances(ctx, client, nil)
+	s.observe(ctx, "rds", "DescribeDBInstances", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, details := buildRDSList(dbs)
+	return items, details, nil
+}
+
+func buildRDSList(instances []models.RDSInstance) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(instances))
+	details := make(map[string]models.DetailView, len(instances))
+
+	for _, inst := range instances {
+		items = append(items, models.ListItem{
+			ID:     inst.ID,
+			Name:   inst.ID,
+			Type:   "RDS",
+			Status: inst.Engine,
+			Tags:   inst.Tags,
+			Metadata: map[string]string{
+				"endpoint": inst.Endpoint,
+			},
+		})
+		details[inst.ID] = rdsDetail(inst)
+	}
+	return items, details
+}
+
+func describeRDS(ctx context.Context, s *Service, id string) (models.DetailView, error) {
+	client, err := s.factory.RDS(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	start := time.Now()
+	inst, err := s.rdsRepo.GetInstance(ctx, client, id)
+	s.observe(ctx, "rds", "DescribeDBInstances", start, err)
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	return rdsDetail(inst), nil
+}
+
+func rdsDetail(inst models.RDSInstance) models.DetailView {
+	storage := ""
+	if inst.StorageGiB > 0 {
+		storage = fmt.Sprintf("%d GiB %s", inst.StorageGiB, inst.StorageType)
+	}
+	return models.DetailView{
+		Overview: map[string]string{
+			"DB Instance": inst.ID,
+			"Status":      inst.Status,
+			"Class":       inst.InstanceClass,
+			"Engine":      inst.Engine,
+			"Version":     inst.EngineVersion,
+			"Storage":     storage,
+			"Multi-AZ":    fmt.Sprintf("%t", inst.MultiAZ),
+			"Endpoint":    inst.Endpoint,
+			"SubnetGroup": inst.SubnetGroup,
+		},
+		Relations: map[string][]string{
+			"Parameter Groups": inst.ParameterGroup,
+			"Security Groups":  inst.SecurityGroups,
+		},
+		Tags: inst.Tags,
+	}
+}
+
+// precheckRDSAction 停止前檢查 Read Replica、Aurora 成員與 Multi-AZ 限制。
+func precheckRDSAction(ctx context.Context, s *Service, id string, action Action) (bool, Notice, error) {
+	if action != ActionStop {
+		return true, Notice{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	client, err := s.factory.RDS(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return false, Notice{}, err
+	}
+	start := time.Now()
+	allowed, warning, err := ops.NewRDSOps(client).CanStop(ctx, id)
+	s.observe(ctx, "rds", "DescribeDBInstances", start, err)
+	return allowed, Notice{Text: warning}, err
+}
+
+func executeRDSAction(ctx context.Context, s *Service, id string, action Action, onWait func(string)) (ActionResult, error) {
+	client, err := s.factory.RDS(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return ActionResult{}, err
+	}
+	rdsOps := ops.NewRDSOps(client)
+
+	var (
+		target    string
+		operation string
+		run       func(context.Context) error
+	)
+	switch action {
+	case ActionStart:
+		target, operation = ops.StatusAvailable, "StartDBInstance"
+		run = func(ctx context.Context) error { return rdsOps.StartDBInstance(ctx, id) }
+	case ActionStop:
+		target, operation = ops.StatusStopped, "StopDBInstance"
+		run = func(ctx context.Context) error { return rdsOps.StopDBInstance(ctx, id) }
+	case ActionReboot:
+		target, operation = ops.StatusAvailable, "RebootDBInstance"
+		run = func(ctx context.Context) error { return rdsOps.RebootDBInstance(ctx, id, false) }
+	default:
+		return ActionResult{}, fmt.Errorf("action %s not supported for rds", action)
+	}
+
+	if err := s.runOp(ctx, "rds", operation, run); err != nil {
+		return ActionResult{}, err
+	}
+
+	if onWait != nil {
+		onWait(target)
+	}
+	start := time.Now()
+	err = rdsOps.WaitForStatus(ctx, id, target, actionWaitTimeout)
+	s.observe(ctx, "rds", "WaitForStatus", start, err)
+	if err != nil {
+		return ActionResult{}, err
+	}
+	return ActionResult{Action: action, ResourceID: id, TargetState: target}, nil
+}
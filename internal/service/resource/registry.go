@@ -0,0 +1,209 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/vincent119/awsGUITools/internal/aws/metrics"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// Kind 表示資源類型。
+type Kind string
+
+// Lister 查詢資源並轉為清單列與詳情；回傳完整清單，搜尋條件由 Service 套用於快取。
+type Lister func(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error)
+
+// PageFunc 以 token 取得一頁清單（空字串為第一頁）。
+type PageFunc func(ctx context.Context, s *Service, token string) (ListPage, error)
+
+// ListPage 為分頁查詢的一頁結果；Next 為空字串表示已是最後一頁。
+type ListPage struct {
+	Items   []models.ListItem
+	Details map[string]models.DetailView
+	Next    string
+}
+
+// EnrichFunc 於背景補齊清單列，完成一筆即以 update 回報更新後的清單列與詳情。
+type EnrichFunc func(ctx context.Context, s *Service, items []models.ListItem, update func(item models.ListItem, detail models.DetailView))
+
+// DescribeFunc 以單一資源查詢取得完整詳情（通常比清單查詢提供更多欄位）。
+type DescribeFunc func(ctx context.Context, s *Service, id string) (models.DetailView, error)
+
+// PrecheckFunc 在確認前檢查操作是否允許，回傳是否允許與需顯示的警告。
+type PrecheckFunc func(ctx context.Context, s *Service, id string, action Action) (bool, Notice, error)
+
+// ExecuteFunc 執行操作；開始等待目標狀態時呼叫 onWait（可為 nil）。
+type ExecuteFunc func(ctx context.Context, s *Service, id string, action Action, onWait func(target string)) (ActionResult, error)
+
+// Command 表示資源類型專屬按鍵觸發的 UI 指令，由 UI 對應到實際的處理函式。
+type Command string
+
+// 資源類型專屬的 UI 指令；同一按鍵在不同資源類型可對應不同指令。
+const (
+	CommandDownload        Command = "download"
+	CommandPreview         Command = "preview"
+	CommandDelete          Command = "delete"
+	CommandUsageExport     Command = "usage-export"
+	CommandCodeDownload    Command = "code-download"
+	CommandPackageBrowser  Command = "package-browser"
+	CommandInvoke          Command = "invoke"
+	CommandConcurrency     Command = "concurrency"
+	CommandConfigEdit      Command = "config-edit"
+	CommandAliasRouting    Command = "alias-routing"
+	CommandRecordEdit      Command = "record-edit"
+	CommandRecordDelete    Command = "record-delete"
+	CommandZoneExport      Command = "zone-export"
+	CommandVPCDisassociate Command = "vpc-disassociate"
+)
+
+// Definition 描述單一資源類型的清單、詳情、監控與操作行為。
+// 新增 AWS 服務時只需建立一個 Definition 並註冊，不需修改 Service 或 UI。
+type Definition struct {
+	Kind    Kind
+	Label   string          // 顯示名稱的 i18n key
+	Hotkey  rune            // 主畫面切換鍵；0 表示只能透過導覽進入（例如 S3 物件）
+	Columns []models.Column // 清單欄位；nil 使用清單預設欄位
+	List    Lister
+	Page    PageFunc // 設定時改以分頁查詢清單（例如 S3 物件），List 可為 nil
+
+	// SearchText 回傳搜尋比對用的文字；nil 時比對 Name。
+	SearchText func(item models.ListItem) string
+	// Enrich 在清單存入快取後於背景補齊欄位，每完成一筆呼叫 update（呼叫不可重疊）；
+	// nil 表示清單查詢已包含完整資訊。
+	Enrich EnrichFunc
+	// Scope 回傳目前瀏覽範圍（例如 bucket/prefix），與 profile/region 一起作為快取鍵；nil 表示不分範圍。
+	Scope func(s *Service) string
+
+	// 以下欄位皆為選用，nil 表示該資源類型不支援。
+	Describe DescribeFunc // nil 時 Detail 以清單快取為準
+	Metrics  func(id string) []metrics.Query
+	Region   func(s *Service, id string) string // 資源實際所在 region（例如 S3 bucket）；空字串表示目前 region
+	LogGroup func(id string) string
+	Actions  []Action
+	Precheck PrecheckFunc
+	Execute  ExecuteFunc
+	Keys     map[rune]Command // 此資源類型專屬的按鍵；UI 依指令執行對應功能
+}
+
+// Registry 保存資源類型定義，並保留註冊順序。
+type Registry struct {
+	mu    sync.RWMutex
+	defs  map[Kind]Definition
+	order []Kind
+}
+
+// NewRegistry 建立空的 Registry。
+func NewRegistry() *Registry {
+	return &Registry{defs: make(map[Kind]Definition)}
+}
+
+// Register 註冊資源類型；Kind 為空、List 與 Page 皆未設定、Kind 或 Hotkey 重複時回傳錯誤。
+func (r *Registry) Register(def Definition) error {
+	if def.Kind == "" {
+		return errors.New("resource kind is empty")
+	}
+	if def.List == nil && def.Page == nil {
+		return fmt.Errorf("resource kind %s has no lister", def.Kind)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.defs[def.Kind]; exists {
+		return fmt.Errorf("resource kind %s already registered", def.Kind)
+	}
+	if def.Hotkey != 0 {
+		for _, other := range r.defs {
+			if other.Hotkey == def.Hotkey {
+				return fmt.Errorf("hotkey %q already used by %s", def.Hotkey, other.Kind)
+			}
+		}
+	}
+	r.defs[def.Kind] = def
+	r.order = append(r.order, def.Kind)
+	return nil
+}
+
+// Lookup 取得指定資源類型的定義。
+func (r *Registry) Lookup(kind Kind) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	def, ok := r.defs[kind]
+	return def, ok
+}
+
+// ByHotkey 依切換鍵取得資源類型定義。
+func (r *Registry) ByHotkey(key rune) (Definition, bool) {
+	if key == 0 {
+		return Definition{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, kind := range r.order {
+		if def := r.defs[kind]; def.Hotkey == key {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Command 取得指定資源類型中按鍵對應的指令。
+func (r *Registry) Command(kind Kind, key rune) (Command, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cmd, ok := r.defs[kind].Keys[key]
+	return cmd, ok
+}
+
+// Definitions 依註冊順序回傳所有定義。
+func (r *Registry) Definitions() []Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]Definition, 0, len(r.order))
+	for _, kind := range r.order {
+		result = append(result, r.defs[kind])
+	}
+	return result
+}
+
+var defaultRegistry = NewRegistry()
+
+// Register 將定義註冊到內建 Registry，供各資源類型於 init 中呼叫；失敗時 panic。
+func Register(def Definition) {
+	if err := defaultRegistry.Register(def); err != nil {
+		panic(err)
+	}
+}
+
+// DefaultRegistry 回傳包含所有內建資源類型的 Registry。
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// column 建立清單欄位。
+func column(title string, value func(models.ListItem) string) models.Column {
+	return models.Column{Title: title, Value: value}
+}
+
+// metadataColumn 建立讀取 ListItem.Metadata 指定鍵值的清單欄位。
+func metadataColumn(title, key string) models.Column {
+	return column(title, func(item models.ListItem) string {
+		return item.Metadata[key]
+	})
+}
+
+// searchText 依定義取得清單列的搜尋比對文字。
+func (d Definition) searchText(item models.ListItem) string {
+	if d.SearchText == nil {
+		return item.Name
+	}
+	return d.SearchText(item)
+}
+
+var (
+	nameColumn   = column("column.name", func(item models.ListItem) string { return item.Name })
+	typeColumn   = column("column.type", func(item models.ListItem) string { return item.Type })
+	regionColumn = column("column.region", func(item models.ListItem) string { return item.Region })
+)
@@ -0,0 +1,233 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// KindRoute53 表示 Route53 Hosted Zone 清單。
+	KindRoute53 Kind = "route53"
+	// KindRoute53Records 表示單一 Hosted Zone 內的 DNS records。
+	KindRoute53Records Kind = "route53-records"
+)
+
+func init() {
+	Register(Definition{
+		Kind:   KindRoute53,
+		Label:  "resource.route53",
+		Hotkey: '5',
+		Columns: []models.Column{
+			nameColumn,
+			column("column.zone_type", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.records", "records"),
+		},
+		List:       listRoute53Zones,
+		Describe:   describeRoute53Zone,
+		SearchText: func(item models.ListItem) string { return item.Name + item.ID },
+	})
+	Register(Definition{
+		Kind:  KindRoute53Records,
+		Label: "resource.route53_records",
+		Columns: []models.Column{
+			nameColumn,
+			typeColumn,
+			column("column.value", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.ttl", "ttl"),
+			metadataColumn("column.routing", "routing"),
+			metadataColumn("column.pending", "pending"),
+		},
+		List:       listRoute53Records,
+		SearchText: func(item models.ListItem) string { return item.Name + item.Type + item.Metadata["routing"] },
+		Scope:      func(s *Service) string { return s.currentZoneID },
+		Keys: map[rune]Command{
+			'e': CommandZoneExport,
+			'E': CommandRecordEdit,
+			'D': CommandRecordDelete,
+		},
+	})
+}
+
+func listRoute53Zones(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	zones, err := s.route53Repo.ListHostedZones(ctx, client)
+	s.observe(ctx, "route53", "ListHostedZones", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, details := buildRoute53ZoneList(zones)
+	return items, details, nil
+}
+
+// describeRoute53Zone 以 GetHostedZone 取得 zone 詳情，private zone 會列出關聯的 VPC。
+func describeRoute53Zone(ctx context.Context, s *Service, id string) (models.DetailView, error) {
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	start := time.Now()
+	zone, err := s.route53Repo.GetHostedZone(ctx, client, id)
+	s.observe(ctx, "route53", "GetHostedZone", start, err)
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	return route53ZoneDetail(zone), nil
+}
+
+func listRoute53Records(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	if s.currentZoneID == "" {
+		return nil, nil, fmt.Errorf("no hosted zone selected")
+	}
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	records, err := s.route53Repo.ListRecords(ctx, client, s.currentZoneID)
+	s.observe(ctx, "route53", "ListResourceRecordSets", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	edits := s.rememberRecords(s.currentZoneID, records)
+	items, details := buildRoute53RecordList(records, s.currentZoneName, edits)
+	return items, details, nil
+}
+
+func buildRoute53ZoneList(zones []models.Route53HostedZone) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(zones))
+	details := make(map[string]models.DetailView, len(zones))
+
+	for _, zone := range zones {
+		items = append(items, models.ListItem{
+			ID:     zone.ID,
+			Name:   zone.Name,
+			Type:   "Route53",
+			Status: zoneType(zone),
+			Metadata: map[string]string{
+				"records": fmt.Sprintf("%d", zone.RecordCount),
+			},
+		})
+		details[zone.ID] = route53ZoneDetail(zone)
+	}
+	return items, details
+}
+
+func route53ZoneDetail(zone models.Route53HostedZone) models.DetailView {
+	detail := models.DetailView{
+		Overview: map[string]string{
+			"Zone ID":      zone.ID,
+			"Name":         zone.Name,
+			"Type":         zoneType(zone),
+			"Record Count": fmt.Sprintf("%d", zone.RecordCount),
+			"Comment":      zone.Comment,
+		},
+	}
+	if len(zone.VPCs) > 0 {
+		vpcs := make([]string, len(zone.VPCs))
+		for i, vpc := range zone.VPCs {
+			vpcs[i] = vpc.ID + " (" + vpc.Region + ")"
+		}
+		detail.Relations = map[string][]string{"VPCs": vpcs}
+	}
+	return detail
+}
+
+func zoneType(zone models.Route53HostedZone) string {
+	if zone.IsPrivate {
+		return "Private"
+	}
+	return "Public"
+}
+
+// buildRoute53RecordList 產生 record 清單；edits 為暫存的變更，已套用於清單並以 pending 欄標示，
+// 暫存新增的 record 附加在最後。
+func buildRoute53RecordList(records []models.Route53Record, zoneName string, edits map[string]*models.Route53Record) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(records)+len(edits))
+	details := make(map[string]models.DetailView, len(records)+len(edits))
+
+	add := func(record models.Route53Record, pending string) {
+		id := recordID(record)
+		value := ""
+		if record.AliasTarget != "" {
+			value = "ALIAS → " + record.AliasTarget
+		} else if len(record.Values) > 0 {
+			value = record.Values[0]
+			if len(record.Values) > 1 {
+				value += fmt.Sprintf(" (+%d)", len(record.Values)-1)
+			}
+		}
+		items = append(items, models.ListItem{
+			ID:     id,
+			Name:   record.Name,
+			Type:   record.Type,
+			Status: value,
+			Metadata: map[string]string{
+				"ttl":     fmt.Sprintf("%d", record.TTL),
+				"routing": recordRouting(record),
+				"pending": pending,
+			},
+		})
+		details[id] = models.DetailView{
+			Overview: map[string]string{
+				"Name": record.Name,
+				"Type": record.Type,
+				"TTL":  fmt.Sprintf("%d", record.TTL),
+				"Zone": zoneName,
+			},
+			Relations: map[string][]string{
+				"Values": record.Values,
+			},
+		}
+		if record.AliasTarget != "" {
+			details[id].Overview["Alias"] = record.AliasTarget
+			details[id].Overview["Alias Zone"] = record.AliasZoneID
+			details[id].Overview["Evaluate Health"] = fmt.Sprintf("%t", record.EvaluateTargetHealth)
+		}
+		if record.SetIdentifier != "" {
+			details[id].Overview["Set Identifier"] = record.SetIdentifier
+		}
+		if policy := routingPolicy(record); policy != "" {
+			details[id].Overview["Routing"] = policy
+		}
+		if record.HealthCheckID != "" {
+			details[id].Overview["Health Check"] = record.HealthCheckID
+		}
+		if pending != "" {
+			details[id].Overview["Pending"] = pending
+		}
+	}
+
+	seen := make(map[string]bool, len(records))
+	for _, record := range records {
+		id := recordID(record)
+		seen[id] = true
+		edit, staged := edits[id]
+		switch {
+		case !staged:
+			add(record, "")
+		case edit == nil:
+			add(record, "- delete")
+		default:
+			add(*edit, "~ update")
+		}
+	}
+	created := make([]string, 0, len(edits))
+	for id, edit := range edits {
+		if edit != nil && !seen[id] {
+			created = append(created, id)
+		}
+	}
+	sort.Strings(created)
+	for _, id := range created {
+		add(*edits[id], "+ create")
+	}
+	return items, details
+}
@@ -0,0 +1,454 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// route53PollInterval 為等待變更 INSYNC 時查詢 GetChange 的間隔。
+	route53PollInterval = 5 * time.Second
+	// route53WaitTimeout 為等待變更 INSYNC 的時間上限。
+	route53WaitTimeout = 10 * time.Minute
+	// route53ChangeComment 為送出的變更批次註記。
+	route53ChangeComment = "aws-tui"
+)
+
+// RecordChangePlan 為送出前的變更批次與逐行差異。
+type RecordChangePlan struct {
+	ZoneID   string
+	ZoneName string
+	Source   string // 匯入的 zone file 路徑；暫存的變更為空
+	Changes  []models.Route53Change
+	Diff     []diff.Line
+}
+
+// recordID 回傳 record 在清單中的 ID；使用路由政策的 record 以 SetIdentifier 區分同名同類型的 record set。
+func recordID(record models.Route53Record) string {
+	id := record.Name + "_" + record.Type
+	if record.SetIdentifier != "" {
+		id += "_" + record.SetIdentifier
+	}
+	return id
+}
+
+// qualifyRecordName 將相對名稱補上 zone 名稱並轉為 Route53 回傳的形式（小寫、結尾加點、開頭的 * 轉為 \052）。
+func qualifyRecordName(name, zoneName string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	zone := strings.ToLower(strings.TrimSuffix(zoneName, "."))
+	switch {
+	case name == "" || name == "@":
+		name = zone
+	case strings.HasSuffix(name, "."):
+		name = strings.TrimSuffix(name, ".")
+	case zone != "" && name != zone && !strings.HasSuffix(name, "."+zone):
+		name += "." + zone
+	}
+	if strings.HasPrefix(name, "*") {
+		name = `\052` + name[1:]
+	}
+	return name + "."
+}
+
+// RecordTypes 回傳可選的 record 類型，常用類型排在前面。
+func RecordTypes() []string {
+	common := []string{"A", "AAAA", "CNAME", "TXT", "MX"}
+	out := slices.Clone(common)
+	for _, t := range repo.RecordTypes() {
+		if !slices.Contains(common, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// EditableRecord 回傳 record 目前的內容（含暫存的修改），供編輯表單使用。
+func (s *Service) EditableRecord(id string) (models.Route53Record, bool) {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	if edit, ok := s.recordEdits[s.currentZoneID][id]; ok {
+		if edit == nil {
+			return models.Route53Record{}, false
+		}
+		return *edit, true
+	}
+	return s.liveRecordLocked(id)
+}
+
+// StageRecord 暫存新增（originalID 為空）或修改的 record，送出前不會呼叫 AWS。
+// 修改名稱或類型時，原 record 暫存為刪除。
+func (s *Service) StageRecord(originalID string, record models.Route53Record) error {
+	if s.currentZoneID == "" {
+		return fmt.Errorf("no hosted zone selected")
+	}
+	record.Name = qualifyRecordName(record.Name, s.currentZoneName)
+	record.Type = strings.ToUpper(strings.TrimSpace(record.Type))
+	if record.AliasTarget != "" {
+		record.TTL, record.Values = 0, nil
+	}
+	if err := repo.ValidateRecord(record); err != nil {
+		return err
+	}
+
+	s.changesMu.Lock()
+	edits := s.zoneEditsLocked()
+	id := recordID(record)
+	live, isLive := s.liveRecordLocked(id)
+	exists := isLive
+	if edit, staged := edits[id]; staged {
+		exists = edit != nil
+	}
+	if id != originalID && exists {
+		s.changesMu.Unlock()
+		return fmt.Errorf("record %s %s already exists; edit it instead", record.Name, record.Type)
+	}
+
+	if originalID != "" && originalID != id {
+		if _, ok := s.liveRecordLocked(originalID); ok {
+			edits[originalID] = nil
+		} else {
+			delete(edits, originalID)
+		}
+	}
+	if isLive && recordsEqual(live, record) {
+		delete(edits, id)
+	} else {
+		edits[id] = &record
+	}
+	s.changesMu.Unlock()
+
+	s.rebuildRecordList()
+	return nil
+}
+
+// ToggleRecordDelete 暫存刪除 record；已暫存變更的 record 依序改為刪除、取消暫存。
+// 回傳操作後該 record 是否仍有暫存的變更。
+func (s *Service) ToggleRecordDelete(id string) (bool, error) {
+	s.changesMu.Lock()
+	edits := s.zoneEditsLocked()
+	_, live := s.liveRecordLocked(id)
+	edit, staged := edits[id]
+	var pending bool
+	switch {
+	case staged && edit != nil && live:
+		edits[id], pending = nil, true
+	case staged:
+		delete(edits, id)
+	case live:
+		edits[id], pending = nil, true
+	default:
+		s.changesMu.Unlock()
+		return false, fmt.Errorf("record %s not found", id)
+	}
+	s.changesMu.Unlock()
+
+	s.rebuildRecordList()
+	return pending, nil
+}
+
+// StagedRecordCount 回傳目前 zone 暫存的 record 數。
+func (s *Service) StagedRecordCount() int {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	return len(s.recordEdits[s.currentZoneID])
+}
+
+// DiscardRecordChanges 捨棄目前 zone 所有暫存的變更。
+func (s *Service) DiscardRecordChanges() {
+	s.changesMu.Lock()
+	delete(s.recordEdits, s.currentZoneID)
+	s.changesMu.Unlock()
+	s.rebuildRecordList()
+}
+
+// PlanRecordChanges 重新列出目前 zone 的 records，依暫存的目標狀態產生變更批次與差異。
+func (s *Service) PlanRecordChanges(ctx context.Context) (RecordChangePlan, error) {
+	plan := RecordChangePlan{ZoneID: s.currentZoneID, ZoneName: s.currentZoneName}
+	if plan.ZoneID == "" {
+		return plan, fmt.Errorf("no hosted zone selected")
+	}
+	s.changesMu.Lock()
+	edits := maps.Clone(s.recordEdits[plan.ZoneID])
+	s.changesMu.Unlock()
+	if len(edits) == 0 {
+		return plan, fmt.Errorf("no staged record changes")
+	}
+
+	live, err := s.fetchZoneRecords(ctx, plan.ZoneID)
+	if err != nil {
+		return plan, err
+	}
+	plan.Changes, plan.Diff = BuildRecordChanges(live, edits)
+	return plan, nil
+}
+
+// fetchZoneRecords 直接向 AWS 列出 zone 的所有 records（不使用快取）。
+func (s *Service) fetchZoneRecords(ctx context.Context, zoneID string) ([]models.Route53Record, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	records, err := s.route53Repo.ListRecords(ctx, client, zoneID)
+	s.observe(ctx, "route53", "ListResourceRecordSets", start, err)
+	return records, err
+}
+
+// SubmitRecordChanges 以單一批次送出變更；成功後清除該 zone 的暫存變更。
+func (s *Service) SubmitRecordChanges(ctx context.Context, plan RecordChangePlan) (models.Route53ChangeInfo, error) {
+	if len(plan.Changes) == 0 {
+		return models.Route53ChangeInfo{}, fmt.Errorf("no changes to submit")
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	start := time.Now()
+	info, err := s.route53Repo.ChangeRecords(ctx, client, plan.ZoneID, route53ChangeComment, plan.Changes)
+	s.observe(ctx, "route53", "ChangeResourceRecordSets", start, err)
+	if err != nil {
+		return info, err
+	}
+
+	s.changesMu.Lock()
+	delete(s.recordEdits, plan.ZoneID)
+	s.changesMu.Unlock()
+	return info, nil
+}
+
+// WaitRecordChange 輪詢 GetChange 直到變更 INSYNC（最多 route53WaitTimeout），onStatus 回報每次查詢結果；
+// record 批次與 VPC 關聯變更都使用 change ID 追蹤。
+func (s *Service) WaitRecordChange(ctx context.Context, changeID string, onStatus func(info models.Route53ChangeInfo)) (models.Route53ChangeInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, route53WaitTimeout)
+	defer cancel()
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	start := time.Now()
+	info, err := s.route53Repo.WaitForChange(ctx, client, changeID, route53PollInterval, onStatus)
+	s.observe(ctx, "route53", "GetChange", start, err)
+	return info, err
+}
+
+// BuildRecordChanges 比較暫存的目標狀態（nil 表示刪除）與目前的 records，產生最少的變更：
+// 已不存在的刪除會略過，不存在的 record 以 CREATE 新增，內容相同的修改會略過。
+// DELETE 使用目前的內容（Route53 要求完全相符）並排在最前面，讓同名的 record 可在同一批次中替換。
+func BuildRecordChanges(live []models.Route53Record, edits map[string]*models.Route53Record) ([]models.Route53Change, []diff.Line) {
+	current := make(map[string]models.Route53Record, len(live))
+	for _, record := range live {
+		current[recordID(record)] = record
+	}
+	ids := make([]string, 0, len(edits))
+	for id := range edits {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var (
+		changes       []models.Route53Change
+		before, after []string
+	)
+	for _, id := range ids {
+		edit := edits[id]
+		old, exists := current[id]
+		switch {
+		case edit == nil && exists:
+			changes = append(changes, models.Route53Change{Action: string(types.ChangeActionDelete), Record: old})
+		case edit == nil:
+			continue
+		case !exists:
+			changes = append(changes, models.Route53Change{Action: string(types.ChangeActionCreate), Record: *edit})
+		case !recordsEqual(old, *edit):
+			changes = append(changes, models.Route53Change{Action: string(types.ChangeActionUpsert), Record: *edit})
+		default:
+			continue
+		}
+		if exists {
+			before = append(before, RecordLines(old)...)
+		}
+		if edit != nil {
+			after = append(after, RecordLines(*edit)...)
+		}
+	}
+	order := map[string]int{string(types.ChangeActionDelete): 0, string(types.ChangeActionCreate): 1, string(types.ChangeActionUpsert): 2}
+	sort.SliceStable(changes, func(i, j int) bool { return order[changes[i].Action] < order[changes[j].Action] })
+	return changes, diff.Lines(before, after)
+}
+
+// RecordLines 以類似 zone file 的格式列出 record，每個值一行，例如 "www.example.com. 300 A 192.0.2.1"；
+// 使用路由政策或 health check 時在行尾附上說明，例如 "(set blue; weight 10)"。
+func RecordLines(record models.Route53Record) []string {
+	suffix := ""
+	if routing := recordRouting(record); routing != "" {
+		suffix = " (" + routing + ")"
+	}
+	if record.AliasTarget != "" {
+		line := fmt.Sprintf("%s ALIAS %s %s (zone %s", record.Name, record.Type, record.AliasTarget, record.AliasZoneID)
+		if record.EvaluateTargetHealth {
+			line += ", evaluate health"
+		}
+		return []string{line + ")" + suffix}
+	}
+	lines := make([]string, len(record.Values))
+	for i, value := range record.Values {
+		lines[i] = fmt.Sprintf("%s %d %s %s%s", record.Name, record.TTL, record.Type, value, suffix)
+	}
+	return lines
+}
+
+// recordRouting 描述 record 的 set identifier、路由政策與 health check，例如 "set blue; weight 10; health check hc-1"。
+func recordRouting(record models.Route53Record) string {
+	var parts []string
+	if record.SetIdentifier != "" {
+		parts = append(parts, "set "+record.SetIdentifier)
+	}
+	if policy := routingPolicy(record); policy != "" {
+		parts = append(parts, policy)
+	}
+	if record.HealthCheckID != "" {
+		parts = append(parts, "health check "+record.HealthCheckID)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// routingPolicy 描述 record 的路由政策，例如 "weight 10"、"latency us-east-1"、"geo JP"；簡單路由回傳空字串。
+func routingPolicy(record models.Route53Record) string {
+	switch {
+	case record.Weight != nil:
+		return fmt.Sprintf("weight %d", *record.Weight)
+	case record.Region != "":
+		return "latency " + record.Region
+	case record.GeoLocation != nil:
+		return "geo " + geoLocationLabel(*record.GeoLocation)
+	case record.Failover != "":
+		return "failover " + record.Failover
+	case record.MultiValueAnswer:
+		return "multivalue"
+	case record.CidrRouting != nil:
+		return "ip " + record.CidrRouting.LocationName
+	case record.GeoProximity != nil:
+		return "geoproximity " + geoProximityLabel(*record.GeoProximity)
+	}
+	return ""
+}
+
+// geoProximityLabel 例如 "us-east-1"、"45.5,-122.6" 加上非零的 bias（"us-east-1 bias 10"）。
+func geoProximityLabel(geo models.Route53GeoProximity) string {
+	label := geo.AWSRegion
+	switch {
+	case geo.LocalZoneGroup != "":
+		label = geo.LocalZoneGroup
+	case label == "":
+		label = geo.Latitude + "," + geo.Longitude
+	}
+	if geo.Bias != nil && *geo.Bias != 0 {
+		label += fmt.Sprintf(" bias %d", *geo.Bias)
+	}
+	return label
+}
+
+// geoLocationLabel 例如 "JP"、"US-CA"、"continent EU" 或 "default"。
+func geoLocationLabel(geo models.Route53GeoLocation) string {
+	switch {
+	case geo.CountryCode == "*":
+		return "default"
+	case geo.SubdivisionCode != "":
+		return geo.CountryCode + "-" + geo.SubdivisionCode
+	case geo.CountryCode != "":
+		return geo.CountryCode
+	}
+	return "continent " + geo.ContinentCode
+}
+
+func recordsEqual(a, b models.Route53Record) bool {
+	return a.Name == b.Name && a.Type == b.Type && a.TTL == b.TTL &&
+		slices.Equal(a.Values, b.Values) && a.AliasTarget == b.AliasTarget &&
+		a.AliasZoneID == b.AliasZoneID && a.EvaluateTargetHealth == b.EvaluateTargetHealth &&
+		a.SetIdentifier == b.SetIdentifier && pointerEqual(a.Weight, b.Weight) && a.Region == b.Region &&
+		pointerEqual(a.GeoLocation, b.GeoLocation) && a.Failover == b.Failover &&
+		a.MultiValueAnswer == b.MultiValueAnswer && pointerEqual(a.CidrRouting, b.CidrRouting) &&
+		geoProximityEqual(a.GeoProximity, b.GeoProximity) && a.HealthCheckID == b.HealthCheckID
+}
+
+func geoProximityEqual(a, b *models.Route53GeoProximity) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.AWSRegion == b.AWSRegion && a.LocalZoneGroup == b.LocalZoneGroup &&
+		a.Latitude == b.Latitude && a.Longitude == b.Longitude && pointerEqual(a.Bias, b.Bias)
+}
+
+func pointerEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// zoneEditsLocked 回傳目前 zone 的暫存變更（必要時建立），呼叫前需持有 s.changesMu。
+func (s *Service) zoneEditsLocked() map[string]*models.Route53Record {
+	if s.recordEdits == nil {
+		s.recordEdits = make(map[string]map[string]*models.Route53Record)
+	}
+	edits, ok := s.recordEdits[s.currentZoneID]
+	if !ok {
+		edits = make(map[string]*models.Route53Record)
+		s.recordEdits[s.currentZoneID] = edits
+	}
+	return edits
+}
+
+// liveRecordLocked 從最近一次列出的 records 找出 id，呼叫前需持有 s.changesMu。
+func (s *Service) liveRecordLocked(id string) (models.Route53Record, bool) {
+	if s.zoneRecordsID != s.currentZoneID {
+		return models.Route53Record{}, false
+	}
+	for _, record := range s.zoneRecords {
+		if recordID(record) == id {
+			return record, true
+		}
+	}
+	return models.Route53Record{}, false
+}
+
+// rememberRecords 保存列出的 records 並回傳該 zone 的暫存變更副本，供產生清單使用。
+func (s *Service) rememberRecords(zoneID string, records []models.Route53Record) map[string]*models.Route53Record {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	s.zoneRecordsID, s.zoneRecords = zoneID, records
+	return maps.Clone(s.recordEdits[zoneID])
+}
+
+// rebuildRecordList 以最近一次列出的 records 與暫存變更重新產生快取中的清單（不呼叫 AWS）。
+func (s *Service) rebuildRecordList() {
+	def, ok := s.registry.Lookup(KindRoute53Records)
+	if !ok {
+		return
+	}
+	s.changesMu.Lock()
+	if s.zoneRecordsID != s.currentZoneID {
+		s.changesMu.Unlock()
+		return
+	}
+	records, edits := s.zoneRecords, maps.Clone(s.recordEdits[s.currentZoneID])
+	s.changesMu.Unlock()
+
+	items, details := buildRoute53RecordList(records, s.currentZoneName, edits)
+	s.replaceList(s.cacheKey(def), items, details)
+}
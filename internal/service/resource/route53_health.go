@@ -0,0 +1,137 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// KindRoute53HealthChecks 表示 Route53 health checks 與使用它們的 records。
+const KindRoute53HealthChecks Kind = "route53-health-checks"
+
+func init() {
+	Register(Definition{
+		Kind:  KindRoute53HealthChecks,
+		Label: "resource.route53_health",
+		Columns: []models.Column{
+			column("column.target", func(item models.ListItem) string { return item.Name }),
+			typeColumn,
+			column("column.status", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.checkers", "checkers"),
+			metadataColumn("column.used_by", "records"),
+		},
+		List:       listRoute53HealthChecks,
+		SearchText: func(item models.ListItem) string { return item.ID + item.Name + item.Metadata["records"] },
+		Scope:      func(s *Service) string { return s.currentZoneID },
+	})
+}
+
+// listRoute53HealthChecks 列出所有 health checks；瀏覽 hosted zone 時一併列出 zone 中使用各 health check 的 records。
+func listRoute53HealthChecks(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	checks, err := s.route53Repo.ListHealthChecks(ctx, client)
+	s.observe(ctx, "route53", "ListHealthChecks", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []models.Route53Record
+	if s.currentZoneID != "" {
+		s.changesMu.Lock()
+		if s.zoneRecordsID == s.currentZoneID {
+			records = s.zoneRecords
+		}
+		s.changesMu.Unlock()
+		if records == nil {
+			if records, err = s.fetchZoneRecords(ctx, s.currentZoneID); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	items, details := buildRoute53HealthCheckList(checks, records)
+	return items, details, nil
+}
+
+func buildRoute53HealthCheckList(checks []models.Route53HealthCheck, records []models.Route53Record) ([]models.ListItem, map[string]models.DetailView) {
+	usedBy := make(map[string][]string)
+	for _, record := range records {
+		if record.HealthCheckID == "" {
+			continue
+		}
+		entry := record.Name + " " + record.Type
+		if record.SetIdentifier != "" {
+			entry += " (set " + record.SetIdentifier + ")"
+		}
+		usedBy[record.HealthCheckID] = append(usedBy[record.HealthCheckID], entry)
+	}
+
+	items := make([]models.ListItem, 0, len(checks))
+	details := make(map[string]models.DetailView, len(checks))
+	for _, check := range checks {
+		status := healthCheckStatus(check)
+		used := usedBy[check.ID]
+		sort.Strings(used)
+		names := make([]string, len(used))
+		for i, entry := range used {
+			names[i], _, _ = strings.Cut(entry, " ")
+		}
+
+		target := check.Target
+		if target == "" {
+			target = check.ID
+		}
+		items = append(items, models.ListItem{
+			ID:     check.ID,
+			Name:   target,
+			Type:   check.Type,
+			Status: status,
+			Metadata: map[string]string{
+				"checkers": fmt.Sprintf("%d/%d", check.Healthy, check.Checkers),
+				"records":  strings.Join(names, ", "),
+			},
+		})
+		details[check.ID] = models.DetailView{
+			Overview: map[string]string{
+				"Health Check ID": check.ID,
+				"Type":            check.Type,
+				"Target":          check.Target,
+				"Status":          status,
+				"Healthy":         fmt.Sprintf("%d of %d checkers", check.Healthy, check.Checkers),
+				"Inverted":        fmt.Sprintf("%t", check.Inverted),
+				"Disabled":        fmt.Sprintf("%t", check.Disabled),
+				"Last Failure":    check.Report,
+			},
+			Relations: map[string][]string{
+				"Records": used,
+			},
+		}
+	}
+	return items, details
+}
+
+// healthCheckStatus 依 Route53 的規則判斷狀態：超過 18% 的 checker 回報成功即為 Healthy（inverted 時相反），
+// 沒有觀測結果為 Unknown。
+func healthCheckStatus(check models.Route53HealthCheck) string {
+	switch {
+	case check.Disabled:
+		return "Disabled"
+	case check.Checkers == 0:
+		return "Unknown"
+	}
+	healthy := check.Healthy*100 > check.Checkers*18
+	if check.Inverted {
+		healthy = !healthy
+	}
+	if healthy {
+		return "Healthy"
+	}
+	return "Unhealthy"
+}
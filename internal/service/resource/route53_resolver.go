@@ -0,0 +1,231 @@
+package resource
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// KindRoute53Resolver 表示目前 region 的 Resolver endpoints、轉送目前 zone 網域的 rules 與 query logging 設定。
+const KindRoute53Resolver Kind = "route53-resolver"
+
+func init() {
+	Register(Definition{
+		Kind:  KindRoute53Resolver,
+		Label: "resource.route53_resolver",
+		Columns: []models.Column{
+			nameColumn,
+			typeColumn,
+			column("column.status", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.target", "target"),
+			metadataColumn("column.effect", "effect"),
+		},
+		List:       listRoute53Resolver,
+		SearchText: func(item models.ListItem) string { return item.ID + item.Name + item.Metadata["target"] },
+		Scope:      func(s *Service) string { return s.currentZoneID },
+	})
+}
+
+func listRoute53Resolver(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	zone, err := s.currentHostedZone(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := s.factory.Route53Resolver(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start := time.Now()
+	endpoints, err := s.resolverRepo.ListEndpoints(ctx, client)
+	s.observe(ctx, "route53resolver", "ListResolverEndpoints", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	start = time.Now()
+	rules, err := s.resolverRepo.ListRules(ctx, client)
+	s.observe(ctx, "route53resolver", "ListResolverRules", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	start = time.Now()
+	configs, err := s.resolverRepo.ListQueryLogConfigs(ctx, client)
+	s.observe(ctx, "route53resolver", "ListResolverQueryLogConfigs", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, details := buildResolverList(zone, endpoints, ResolverRulesForZone(zone.Name, rules), configs)
+	return items, details, nil
+}
+
+// ResolverRulesForZone 回傳網域與 zone 相同、為其上層或其子網域的 rules（不含根網域 "." 的系統 rule），
+// 依網域由具體到一般排序，與 Resolver 選擇 rule 的順序一致。
+func ResolverRulesForZone(zoneName string, rules []models.ResolverRule) []models.ResolverRule {
+	zone := normalizeDomain(zoneName)
+	var matched []models.ResolverRule
+	for _, rule := range rules {
+		domain := normalizeDomain(rule.DomainName)
+		if domain == "" {
+			continue
+		}
+		if domain == zone || strings.HasSuffix(zone, "."+domain) || strings.HasSuffix(domain, "."+zone) {
+			matched = append(matched, rule)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return strings.Count(normalizeDomain(matched[i].DomainName), ".") > strings.Count(normalizeDomain(matched[j].DomainName), ".")
+	})
+	return matched
+}
+
+// resolverRuleEffect 說明 rule 對 zone 的影響：Resolver 以最具體的網域決定，網域相同時 forward rule 優先於 private zone。
+// private zone 只影響同時關聯 zone 與 rule 的 VPC；public zone 則影響所有關聯 rule 的 VPC。
+func resolverRuleEffect(zone models.Route53HostedZone, rule models.ResolverRule) string {
+	vpcs := rule.VPCs
+	if zone.IsPrivate {
+		vpcs = nil
+		for _, vpc := range zone.VPCs {
+			if slices.Contains(rule.VPCs, vpc.ID) {
+				vpcs = append(vpcs, vpc.ID)
+			}
+		}
+	}
+	if rule.RuleType != "FORWARD" || len(vpcs) == 0 {
+		return ""
+	}
+	list := strings.Join(vpcs, ", ")
+	domain, name := normalizeDomain(rule.DomainName), normalizeDomain(zone.Name)
+	switch {
+	case domain == name:
+		return "forwards the whole zone in " + list
+	case strings.HasSuffix(domain, "."+name):
+		return "forwards " + domain + " in " + list
+	case zone.IsPrivate:
+		return "zone takes precedence in " + list
+	default:
+		return "forwards zone names in " + list
+	}
+}
+
+func buildResolverList(zone models.Route53HostedZone, endpoints []models.ResolverEndpoint, rules []models.ResolverRule, configs []models.ResolverQueryLogConfig) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(endpoints)+len(rules)+len(configs))
+	details := make(map[string]models.DetailView, len(endpoints)+len(rules)+len(configs))
+	zoneVPC := func(id string) bool {
+		return slices.ContainsFunc(zone.VPCs, func(vpc models.Route53VPC) bool { return vpc.ID == id })
+	}
+
+	usedBy := make(map[string][]string)
+	for _, rule := range rules {
+		effect := resolverRuleEffect(zone, rule)
+		items = append(items, models.ListItem{
+			ID:     rule.ID,
+			Name:   cmp.Or(rule.Name, rule.ID),
+			Type:   "Rule " + rule.RuleType,
+			Status: rule.Status,
+			Metadata: map[string]string{
+				"target": rule.DomainName + " → " + strings.Join(rule.TargetIPs, ", "),
+				"effect": effect,
+			},
+		})
+		details[rule.ID] = models.DetailView{
+			Overview: map[string]string{
+				"Rule ID":  rule.ID,
+				"Name":     rule.Name,
+				"Domain":   rule.DomainName,
+				"Type":     rule.RuleType,
+				"Status":   rule.Status,
+				"Endpoint": rule.EndpointID,
+				"Effect":   effect,
+			},
+			Relations: map[string][]string{
+				"Target IPs": rule.TargetIPs,
+				"VPCs":       rule.VPCs,
+			},
+		}
+		if rule.EndpointID != "" {
+			usedBy[rule.EndpointID] = append(usedBy[rule.EndpointID], cmp.Or(rule.Name, rule.ID))
+		}
+	}
+
+	for _, ep := range endpoints {
+		effect := ""
+		switch {
+		case len(usedBy[ep.ID]) > 0:
+			effect = "used by " + strings.Join(usedBy[ep.ID], ", ")
+		case ep.Direction == "INBOUND" && zoneVPC(ep.VPCID):
+			effect = "answers the zone for external resolvers"
+		}
+		items = append(items, models.ListItem{
+			ID:     ep.ID,
+			Name:   cmp.Or(ep.Name, ep.ID),
+			Type:   "Endpoint " + ep.Direction,
+			Status: ep.Status,
+			Metadata: map[string]string{
+				"target": fmt.Sprintf("%s (%d IPs)", ep.VPCID, len(ep.IPAddresses)),
+				"effect": effect,
+			},
+		})
+		details[ep.ID] = models.DetailView{
+			Overview: map[string]string{
+				"Endpoint ID": ep.ID,
+				"Name":        ep.Name,
+				"Direction":   ep.Direction,
+				"Status":      ep.Status,
+				"VPC":         ep.VPCID,
+				"Effect":      effect,
+			},
+			Relations: map[string][]string{
+				"IP Addresses": ep.IPAddresses,
+				"Rules":        usedBy[ep.ID],
+			},
+		}
+	}
+
+	for _, cfg := range configs {
+		var logged []string
+		for _, vpc := range cfg.VPCs {
+			if zoneVPC(vpc) {
+				logged = append(logged, vpc)
+			}
+		}
+		effect := ""
+		if len(logged) > 0 {
+			effect = "logs queries from " + strings.Join(logged, ", ")
+		}
+		items = append(items, models.ListItem{
+			ID:     cfg.ID,
+			Name:   cmp.Or(cfg.Name, cfg.ID),
+			Type:   "Query Log",
+			Status: cfg.Status,
+			Metadata: map[string]string{
+				"target": cfg.Destination,
+				"effect": effect,
+			},
+		})
+		details[cfg.ID] = models.DetailView{
+			Overview: map[string]string{
+				"Config ID":   cfg.ID,
+				"Name":        cfg.Name,
+				"Status":      cfg.Status,
+				"Destination": cfg.Destination,
+				"Effect":      effect,
+			},
+			Relations: map[string][]string{
+				"VPCs": cfg.VPCs,
+			},
+		}
+	}
+	return items, details
+}
+
+// normalizeDomain 轉為小寫並移除結尾的點；根網域 "." 回傳空字串。
+func normalizeDomain(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
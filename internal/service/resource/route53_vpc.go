@@ -0,0 +1,140 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// KindRoute53ZoneVPCs 表示目前 private hosted zone 關聯的 VPC。
+const KindRoute53ZoneVPCs Kind = "route53-zone-vpcs"
+
+func init() {
+	Register(Definition{
+		Kind:  KindRoute53ZoneVPCs,
+		Label: "resource.route53_vpcs",
+		Columns: []models.Column{
+			nameColumn,
+			metadataColumn("column.region", "region"),
+		},
+		List:  listRoute53ZoneVPCs,
+		Scope: func(s *Service) string { return s.currentZoneID },
+		Keys: map[rune]Command{
+			'D': CommandVPCDisassociate,
+		},
+	})
+}
+
+func listRoute53ZoneVPCs(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	zone, err := s.privateZone(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]models.ListItem, 0, len(zone.VPCs))
+	details := make(map[string]models.DetailView, len(zone.VPCs))
+	for _, vpc := range zone.VPCs {
+		items = append(items, models.ListItem{
+			ID:       vpc.ID,
+			Name:     vpc.ID,
+			Type:     "VPC",
+			Metadata: map[string]string{"region": vpc.Region},
+		})
+		details[vpc.ID] = models.DetailView{
+			Overview: map[string]string{
+				"VPC":    vpc.ID,
+				"Region": vpc.Region,
+				"Zone":   zone.Name,
+			},
+		}
+	}
+	return items, details, nil
+}
+
+// currentHostedZone 以 GetHostedZone 取得目前瀏覽的 hosted zone（含關聯的 VPC）。
+func (s *Service) currentHostedZone(ctx context.Context) (models.Route53HostedZone, error) {
+	if s.currentZoneID == "" {
+		return models.Route53HostedZone{}, fmt.Errorf("no hosted zone selected")
+	}
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.Route53HostedZone{}, err
+	}
+	start := time.Now()
+	zone, err := s.route53Repo.GetHostedZone(ctx, client, s.currentZoneID)
+	s.observe(ctx, "route53", "GetHostedZone", start, err)
+	return zone, err
+}
+
+// privateZone 取得目前瀏覽的 hosted zone，public zone 沒有 VPC 關聯時回傳錯誤。
+func (s *Service) privateZone(ctx context.Context) (models.Route53HostedZone, error) {
+	zone, err := s.currentHostedZone(ctx)
+	if err != nil {
+		return models.Route53HostedZone{}, err
+	}
+	if !zone.IsPrivate {
+		return models.Route53HostedZone{}, fmt.Errorf("%s is a public hosted zone and has no VPC associations", zone.Name)
+	}
+	return zone, nil
+}
+
+// AssociateZoneVPC 將 VPC 關聯到目前的 private hosted zone；region 為空時使用目前的 region。
+func (s *Service) AssociateZoneVPC(ctx context.Context, vpcID, region string) (models.Route53ChangeInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	zone, err := s.privateZone(ctx)
+	if err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	vpc := models.Route53VPC{ID: strings.TrimSpace(vpcID), Region: strings.TrimSpace(region)}
+	if vpc.Region == "" {
+		vpc.Region = s.state.Region()
+	}
+	for _, existing := range zone.VPCs {
+		if existing == vpc {
+			return models.Route53ChangeInfo{}, fmt.Errorf("%s is already associated with %s", vpc.ID, zone.Name)
+		}
+	}
+
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	start := time.Now()
+	info, err := s.route53Repo.AssociateVPC(ctx, client, zone.ID, vpc)
+	s.observe(ctx, "route53", "AssociateVPCWithHostedZone", start, err)
+	return info, err
+}
+
+// DisassociateZoneVPC 解除 VPC 與目前 private hosted zone 的關聯；不允許移除最後一個 VPC。
+func (s *Service) DisassociateZoneVPC(ctx context.Context, vpcID string) (models.Route53ChangeInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	zone, err := s.privateZone(ctx)
+	if err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	var vpc models.Route53VPC
+	for _, existing := range zone.VPCs {
+		if existing.ID == vpcID {
+			vpc = existing
+		}
+	}
+	switch {
+	case vpc.ID == "":
+		return models.Route53ChangeInfo{}, fmt.Errorf("%s is not associated with %s", vpcID, zone.Name)
+	case len(zone.VPCs) == 1:
+		return models.Route53ChangeInfo{}, fmt.Errorf("%s is the last VPC of %s; a private zone needs at least one VPC", vpcID, zone.Name)
+	}
+
+	client, err := s.factory.Route53(ctx, s.state.Profile(), s.state.Region())
+	if err != nil {
+		return models.Route53ChangeInfo{}, err
+	}
+	start := time.Now()
+	info, err := s.route53Repo.DisassociateVPC(ctx, client, zone.ID, vpc)
+	s.observe(ctx, "route53", "DisassociateVPCFromHostedZone", start, err)
+	return info, err
+}
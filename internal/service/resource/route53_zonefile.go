@@ -0,0 +1,108 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/zonefile"
+)
+
+// ExportZoneFile 將目前 zone 的所有 records 匯出為 BIND zone file，回傳 record 數。
+func (s *Service) ExportZoneFile(ctx context.Context, path string) (int, error) {
+	if s.currentZoneID == "" {
+		return 0, fmt.Errorf("no hosted zone selected")
+	}
+	records, err := s.fetchZoneRecords(ctx, s.currentZoneID)
+	if err != nil {
+		return 0, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("create %s: %w", path, err)
+	}
+	err = zonefile.Write(file, s.currentZoneName, records)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return len(records), err
+}
+
+// PlanZoneImport 解析 zone file 並與目前的 records 比較，產生讓 zone 與檔案一致的最少變更。
+// 有暫存的變更時拒絕匯入，避免送出後清除暫存內容。
+func (s *Service) PlanZoneImport(ctx context.Context, path string) (RecordChangePlan, error) {
+	plan := RecordChangePlan{ZoneID: s.currentZoneID, ZoneName: s.currentZoneName, Source: path}
+	if plan.ZoneID == "" {
+		return plan, fmt.Errorf("no hosted zone selected")
+	}
+	if s.StagedRecordCount() > 0 {
+		return plan, fmt.Errorf("submit or discard staged record changes before importing")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return plan, err
+	}
+	defer file.Close()
+	imported, err := zonefile.Parse(file, plan.ZoneName)
+	if err != nil {
+		return plan, fmt.Errorf("%s: %w", path, err)
+	}
+
+	live, err := s.fetchZoneRecords(ctx, plan.ZoneID)
+	if err != nil {
+		return plan, err
+	}
+	plan.Changes, plan.Diff, err = BuildImportChanges(plan.ZoneName, live, imported)
+	return plan, err
+}
+
+// BuildImportChanges 比較 zone file 與目前的 records：檔案中新增或不同的 record 以 UPSERT 寫入，
+// 檔案中沒有的 record 以 DELETE 刪除。SOA 與 apex NS 由 Route53 管理，兩邊都會略過；
+// zone file 無法表示路由政策與 health check，使用路由政策或關聯 health check 的 record 不會被修改或刪除。
+func BuildImportChanges(zoneName string, live, imported []models.Route53Record) ([]models.Route53Change, []diff.Line, error) {
+	zone := zonefile.Fqdn(zoneName)
+	managed := func(record models.Route53Record) bool {
+		return record.Type == string(types.RRTypeSoa) || record.Type == string(types.RRTypeNs) && record.Name == zone ||
+			record.SetIdentifier != "" || record.HealthCheckID != ""
+	}
+	// 檔案中同名同類型的 record 不含 health check，UPSERT 會移除關聯，因此以目前的 record 為準
+	kept := make(map[string]bool)
+	for _, record := range live {
+		if managed(record) {
+			kept[recordID(record)] = true
+		}
+	}
+
+	edits := make(map[string]*models.Route53Record, len(imported))
+	for _, record := range imported {
+		if managed(record) || kept[recordID(record)] {
+			continue
+		}
+		if record.Name != zone && !strings.HasSuffix(record.Name, "."+zone) {
+			return nil, nil, fmt.Errorf("record %s is outside zone %s", record.Name, zone)
+		}
+		if err := repo.ValidateRecord(record); err != nil {
+			return nil, nil, fmt.Errorf("%s %s: %w", record.Name, record.Type, err)
+		}
+		edits[recordID(record)] = &record
+	}
+	for _, record := range live {
+		if _, ok := edits[recordID(record)]; !ok && !managed(record) {
+			edits[recordID(record)] = nil
+		}
+	}
+
+	changes, lines := BuildRecordChanges(live, edits)
+	for i := range changes {
+		if changes[i].Action == string(types.ChangeActionCreate) {
+			changes[i].Action = string(types.ChangeActionUpsert)
+		}
+	}
+	return changes, lines, nil
+}
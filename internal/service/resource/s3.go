@@ -0,0 +1,348 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/aws/metrics"
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+const (
+	// KindS3 表示 S3 bucket 清單。
+	KindS3 Kind = "s3"
+	// KindS3Objects 表示單一 bucket 內的物件瀏覽。
+	KindS3Objects Kind = "s3-objects"
+)
+
+func init() {
+	Register(Definition{
+		Kind:   KindS3,
+		Label:  "resource.s3",
+		Hotkey: '3',
+		Columns: []models.Column{
+			nameColumn,
+			column("column.versioning", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.access", "access"),
+			regionColumn,
+		},
+		List:     listS3Buckets,
+		Enrich:   enrichS3Buckets,
+		Describe: describeS3Bucket,
+		Metrics:  metrics.S3Queries,
+		Region: func(s *Service, id string) string {
+			return s.knownBucketRegion(s.state.Profile(), id)
+		},
+	})
+	Register(Definition{
+		Kind:  KindS3Objects,
+		Label: "resource.s3_objects",
+		Columns: []models.Column{
+			nameColumn,
+			typeColumn,
+			column("column.size", func(item models.ListItem) string { return item.Status }),
+			column("column.last_modified", func(item models.ListItem) string { return item.Region }),
+			metadataColumn("column.storage_class", "storage"),
+		},
+		Page:  pageS3Objects,
+		Scope: func(s *Service) string { return s.currentBucket + "/" + s.currentPrefix },
+		Keys: map[rune]Command{
+			'd': CommandDownload,
+			'v': CommandPreview,
+			'D': CommandDelete,
+		},
+	})
+}
+
+func listS3Buckets(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	profile := s.state.Profile()
+	client, err := s.factory.S3(ctx, profile, s.state.Region())
+	if err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	var buckets []models.S3Bucket
+	if s.s3LazyEnrich {
+		// 先顯示名稱，設定由 enrichS3Buckets 於背景補齊
+		buckets, err = s.s3Repo.ListBucketNames(ctx, client)
+	} else {
+		buckets, err = s.s3Repo.ListBuckets(ctx, client, s.s3RegionalClients(profile))
+	}
+	s.observe(ctx, "s3", "ListBuckets", start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !s.s3LazyEnrich {
+		account, accountErr := s.accountPublicAccessBlock(ctx, profile)
+		for i := range buckets {
+			applyAccountPublicAccess(&buckets[i], account, accountErr)
+		}
+	}
+	for _, bucket := range buckets {
+		s.rememberBucketRegion(profile, bucket.Name, bucket.Region)
+	}
+	items, details := buildS3List(buckets)
+	return items, details, nil
+}
+
+// enrichS3Buckets 在 lazy 模式下平行補齊 bucket 設定，完成一個 bucket 就更新一列。
+func enrichS3Buckets(ctx context.Context, s *Service, items []models.ListItem, update func(models.ListItem, models.DetailView)) {
+	if !s.s3LazyEnrich || len(items) == 0 {
+		return
+	}
+	profile := s.state.Profile()
+	client, err := s.factory.S3(ctx, profile, s.state.Region())
+	if err != nil {
+		return
+	}
+
+	buckets := make([]models.S3Bucket, 0, len(items))
+	for _, item := range items {
+		buckets = append(buckets, models.S3Bucket{Name: item.ID})
+	}
+	account, accountErr := s.accountPublicAccessBlock(ctx, profile)
+	start := time.Now()
+	s.s3Repo.EnrichBuckets(ctx, client, s.s3RegionalClients(profile), buckets, func(_ int, bucket models.S3Bucket) {
+		applyAccountPublicAccess(&bucket, account, accountErr)
+		s.rememberBucketRegion(profile, bucket.Name, bucket.Region)
+		update(s3BucketItem(bucket), s3BucketDetail(bucket))
+	})
+	s.observe(ctx, "s3", "EnrichBuckets", start, ctx.Err())
+}
+
+// pageS3Objects 以 config.PageSize 分頁列出目前 bucket/prefix 下的物件。
+func pageS3Objects(ctx context.Context, s *Service, token string) (ListPage, error) {
+	if s.currentBucket == "" {
+		return ListPage{}, fmt.Errorf("no bucket selected")
+	}
+	var page models.S3ObjectPage
+	err := s.withBucketClient(ctx, s.currentBucket, func(client *s3.Client) error {
+		start := time.Now()
+		var err error
+		page, err = s.s3Repo.ListObjectsPage(ctx, client, s.currentBucket, s.currentPrefix, token, s.pageSize)
+		s.observe(ctx, "s3", "ListObjectsV2", start, err)
+		return err
+	})
+	if err != nil {
+		return ListPage{}, err
+	}
+	items, details := buildS3ObjectList(page.Objects, s.currentBucket, s.currentPrefix)
+	return ListPage{Items: items, Details: details, Next: page.NextToken}, nil
+}
+
+func buildS3List(buckets []models.S3Bucket) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(buckets))
+	details := make(map[string]models.DetailView, len(buckets))
+
+	for _, bucket := range buckets {
+		items = append(items, s3BucketItem(bucket))
+		details[bucket.Name] = s3BucketDetail(bucket)
+	}
+	return items, details
+}
+
+func s3BucketItem(bucket models.S3Bucket) models.ListItem {
+	status := bucket.Versioning
+	switch {
+	case !bucket.Enriched:
+		status = "…"
+	case len(bucket.Errors) > 0:
+		status += " ⚠"
+	}
+	return models.ListItem{
+		ID:     bucket.Name,
+		Name:   bucket.Name,
+		Type:   "S3",
+		Status: status,
+		Region: bucket.Region,
+		Tags:   bucket.Tags,
+		Metadata: map[string]string{
+			"access": s3AccessBadge(bucket),
+		},
+	}
+}
+
+func describeS3Bucket(ctx context.Context, s *Service, id string) (models.DetailView, error) {
+	var bucket models.S3Bucket
+	err := s.withBucketClient(ctx, id, func(client *s3.Client) error {
+		start := time.Now()
+		var err error
+		bucket, err = s.s3Repo.GetBucket(ctx, client, id)
+		s.observe(ctx, "s3", "HeadBucket", start, err)
+		return err
+	})
+	if err != nil {
+		return models.DetailView{}, err
+	}
+	account, accountErr := s.accountPublicAccessBlock(ctx, s.state.Profile())
+	applyAccountPublicAccess(&bucket, account, accountErr)
+	return s3BucketDetail(bucket), nil
+}
+
+func s3BucketDetail(bucket models.S3Bucket) models.DetailView {
+	detail := models.DetailView{
+		Overview: map[string]string{
+			"Bucket":     bucket.Name,
+			"Region":     bucket.Region,
+			"Versioning": bucket.Versioning,
+			"Encryption": bucket.Encryption,
+			"Lifecycle":  bucket.Lifecycle,
+		},
+		Relations: map[string][]string{
+			"Policies": filterEmpty(bucket.Policy),
+			"Errors":   lookupErrors(bucket.Errors),
+		},
+		Tags: bucket.Tags,
+	}
+	if bucket.Enriched {
+		s3SecurityOverview(detail.Overview, bucket)
+		s3SecurityRelations(detail.Relations, bucket.Security)
+		s3RuleRelations(detail.Relations, bucket)
+	}
+	return detail
+}
+
+// s3RegionalClients 回傳依 region 取得 S3 client 的函式（沿用指定 profile）。
+func (s *Service) s3RegionalClients(profile string) repo.S3ClientFunc {
+	return func(ctx context.Context, region string) (*s3.Client, error) {
+		return s.factory.S3(ctx, profile, region)
+	}
+}
+
+// withBucketClient 以 bucket 所在 region 的 client 執行 fn；
+// 遇到 301 / PermanentRedirect 時記錄正確 region 後重試一次。
+func (s *Service) withBucketClient(ctx context.Context, bucket string, fn func(*s3.Client) error) error {
+	profile := s.state.Profile()
+	client, err := s.s3BucketClient(ctx, profile, bucket)
+	if err != nil {
+		return err
+	}
+	err = fn(client)
+	region, ok := repo.RedirectRegion(err)
+	if !ok || region == client.Options().Region {
+		return err
+	}
+
+	s.rememberBucketRegion(profile, bucket, region)
+	client, clientErr := s.factory.S3(ctx, profile, region)
+	if clientErr != nil {
+		return err
+	}
+	return fn(client)
+}
+
+// s3BucketClient 回傳 bucket 所在 region 的 client；region 未知時先以 HeadBucket 查詢並記錄，
+// 查詢失敗則使用目前 region，由實際呼叫回報錯誤。
+func (s *Service) s3BucketClient(ctx context.Context, profile, bucket string) (*s3.Client, error) {
+	if region := s.knownBucketRegion(profile, bucket); region != "" {
+		return s.factory.S3(ctx, profile, region)
+	}
+
+	client, err := s.factory.S3(ctx, profile, s.state.Region())
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	region, err := s.s3Repo.BucketRegion(ctx, client, bucket)
+	s.observe(ctx, "s3", "HeadBucket", start, err)
+	if err != nil || region == client.Options().Region {
+		return client, nil
+	}
+	s.rememberBucketRegion(profile, bucket, region)
+	return s.factory.S3(ctx, profile, region)
+}
+
+func (s *Service) rememberBucketRegion(profile, bucket, region string) {
+	if region == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bucketRegions[profile+"|"+bucket] = region
+}
+
+func (s *Service) knownBucketRegion(profile, bucket string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bucketRegions[profile+"|"+bucket]
+}
+
+// lookupErrors 將查詢失敗依 API 名稱排序，格式為「operation: message」。
+func lookupErrors(errs map[string]string) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(errs))
+	for operation, message := range errs {
+		result = append(result, operation+": "+message)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func buildS3ObjectList(objects []models.S3Object, bucket, prefix string) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(objects))
+	details := make(map[string]models.DetailView, len(objects))
+
+	for _, obj := range objects {
+		// 取得顯示名稱（移除 prefix）
+		displayName := strings.TrimPrefix(obj.Key, prefix)
+		if obj.IsDirectory {
+			displayName = strings.TrimSuffix(displayName, "/") + "/"
+		}
+
+		objType := "File"
+		status := formatSize(obj.Size)
+		if obj.IsDirectory {
+			objType = "Dir"
+			status = ""
+		}
+
+		items = append(items, models.ListItem{
+			ID:     obj.Key,
+			Name:   displayName,
+			Type:   objType,
+			Status: status,
+			Region: obj.LastModified,
+			Metadata: map[string]string{
+				"storage": obj.StorageClass,
+				"bucket":  bucket,
+				"size":    strconv.FormatInt(obj.Size, 10),
+			},
+		})
+
+		details[obj.Key] = models.DetailView{
+			Overview: map[string]string{
+				"Key":           obj.Key,
+				"Bucket":        bucket,
+				"Size":          formatSize(obj.Size),
+				"Last Modified": obj.LastModified,
+				"Storage Class": obj.StorageClass,
+			},
+		}
+	}
+	return items, details
+}
+
+func formatSize(bytes int64) string {
+	if bytes == 0 {
+		return "0 B"
+	}
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// DeletePlan 為刪除前的 dry-run 結果：實際會刪除的 key 與總大小。
+type DeletePlan struct {
+	Bucket     string
+	Keys       []string
+	Bytes      int64
+	Prefixes   int    // 展開的 prefix 數
+	Versioning string // Enabled/Suspended 時刪除只會建立刪除標記
+}
+
+// DeleteResult 為刪除結果；Failed 為個別刪除失敗的 key。
+type DeleteResult struct {
+	Deleted int
+	Failed  []models.S3DeleteError
+}
+
+// PlanDelete 依選取的物件清單項目建立刪除計畫（dry-run，不會刪除任何物件）。
+// Dir 項目會遞迴展開為其下所有物件（含目錄標記），File 項目使用清單中的大小。
+func (s *Service) PlanDelete(ctx context.Context, items []models.ListItem) (DeletePlan, error) {
+	if s.currentBucket == "" {
+		return DeletePlan{}, fmt.Errorf("no bucket selected")
+	}
+	plan := DeletePlan{Bucket: s.currentBucket}
+	seen := make(map[string]bool)
+	add := func(key string, size int64) {
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		plan.Keys = append(plan.Keys, key)
+		plan.Bytes += size
+	}
+
+	err := s.withBucketClient(ctx, plan.Bucket, func(client *s3.Client) error {
+		start := time.Now()
+		status, err := s.s3Repo.GetBucketVersioning(ctx, client, plan.Bucket)
+		s.observe(ctx, "s3", "GetBucketVersioning", start, err)
+		if err != nil {
+			return err
+		}
+		plan.Versioning = status
+
+		for _, item := range items {
+			if !strings.HasSuffix(item.ID, "/") {
+				size, _ := strconv.ParseInt(item.Metadata["size"], 10, 64)
+				add(item.ID, size)
+				continue
+			}
+			start := time.Now()
+			objects, err := s.s3Repo.ListAllKeys(ctx, client, plan.Bucket, item.ID)
+			s.observe(ctx, "s3", "ListObjectsV2", start, err)
+			if err != nil {
+				return err
+			}
+			plan.Prefixes++
+			for _, obj := range objects {
+				add(obj.Key, obj.Size)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return DeletePlan{}, err
+	}
+	if len(plan.Keys) == 0 {
+		return DeletePlan{}, fmt.Errorf("nothing to delete")
+	}
+	return plan, nil
+}
+
+// DeleteObjects 刪除計畫中的 key；只刪除 dry-run 時列出的物件，之後新增到 prefix 的物件不受影響。
+// onProgress 於每批完成後回報已處理數量。刪除不套用 API timeout，以 ctx 取消；
+// 中途失敗或取消時 Deleted 仍包含先前批次已刪除的數量。
+func (s *Service) DeleteObjects(ctx context.Context, plan DeletePlan, onProgress func(done, total int)) (DeleteResult, error) {
+	var (
+		processed int
+		failed    []models.S3DeleteError
+	)
+	err := s.withBucketClient(ctx, plan.Bucket, func(client *s3.Client) error {
+		start := time.Now()
+		var err error
+		processed, failed, err = s.s3Repo.DeleteObjects(ctx, client, plan.Bucket, plan.Keys, func(done int) {
+			if onProgress != nil {
+				onProgress(done, len(plan.Keys))
+			}
+		})
+		s.observe(ctx, "s3", "DeleteObjects", start, err)
+		return err
+	})
+	return DeleteResult{Deleted: processed - len(failed), Failed: failed}, err
+}
@@ -0,0 +1,27 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// PresignObject 為目前 bucket 中的 key 產生 GET 或 PUT presigned URL。
+// 以 bucket 所在 region 的 client 簽署，避免跨 region 時 URL 因重新導向而失效。
+func (s *Service) PresignObject(ctx context.Context, method, key string, expires time.Duration) (models.S3PresignedURL, error) {
+	if s.currentBucket == "" {
+		return models.S3PresignedURL{}, fmt.Errorf("no bucket selected")
+	}
+	bucket := s.currentBucket
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	client, err := s.s3BucketClient(ctx, s.state.Profile(), bucket)
+	if err != nil {
+		return models.S3PresignedURL{}, err
+	}
+	return s.s3Repo.PresignObject(ctx, client, bucket, key, method, expires)
+}
@@ -0,0 +1,65 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// defaultPreviewSize 為未設定時預覽讀取的位元組數。
+const defaultPreviewSize int64 = 64 << 10
+
+// ObjectPreview 為物件預覽所需的 HeadObject 資訊與內容前段。
+type ObjectPreview struct {
+	Info      models.S3ObjectInfo
+	Data      []byte
+	Truncated bool // Data 只是物件的前段
+}
+
+// SetPreviewSize 設定預覽讀取的位元組數；小於 1 時忽略。
+func (s *Service) SetPreviewSize(size int64) {
+	if size > 0 {
+		s.previewSize = size
+	}
+}
+
+// PreviewObject 以 HeadObject 取得 metadata，再以 Range GET 讀取目前 bucket 中物件的前段。
+func (s *Service) PreviewObject(ctx context.Context, key string) (ObjectPreview, error) {
+	if s.currentBucket == "" {
+		return ObjectPreview{}, fmt.Errorf("no bucket selected")
+	}
+	bucket := s.currentBucket
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var preview ObjectPreview
+	err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+		start := time.Now()
+		info, err := s.s3Repo.HeadObject(ctx, client, bucket, key)
+		s.observe(ctx, "s3", "HeadObject", start, err)
+		if err != nil {
+			return err
+		}
+		preview = ObjectPreview{Info: info}
+		// 空物件的 Range GET 會回傳 InvalidRange
+		if info.Size == 0 {
+			return nil
+		}
+
+		start = time.Now()
+		data, err := s.s3Repo.GetObjectHead(ctx, client, bucket, key, s.previewSize)
+		s.observe(ctx, "s3", "GetObject", start, err)
+		if err != nil {
+			return err
+		}
+		preview.Data = data
+		preview.Truncated = info.Size > int64(len(data))
+		return nil
+	})
+	return preview, err
+}
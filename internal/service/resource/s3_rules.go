@@ -0,0 +1,336 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// BucketConfig 為可用 YAML 編輯的 bucket 設定種類。
+type BucketConfig string
+
+const (
+	// BucketConfigLifecycle 為 lifecycle 規則。
+	BucketConfigLifecycle BucketConfig = "lifecycle"
+	// BucketConfigCORS 為 CORS 規則。
+	BucketConfigCORS BucketConfig = "cors"
+)
+
+// BucketConfigs 為可編輯的設定，依選單順序排列。
+var BucketConfigs = []BucketConfig{BucketConfigLifecycle, BucketConfigCORS}
+
+// BucketConfigChange 為已驗證、尚未套用的設定變更。
+type BucketConfigChange struct {
+	Bucket   string
+	Config   BucketConfig
+	Original string // 開啟編輯器時的設定（正規化 YAML）
+	Edited   string // 編輯後的設定（正規化 YAML）
+	Rules    int
+	Diff     []diff.Line
+}
+
+// lifecycleDocument 與 corsDocument 為編輯器中的 YAML 文件格式。
+type lifecycleDocument struct {
+	Rules []models.S3LifecycleRule `yaml:"rules"`
+}
+
+type corsDocument struct {
+	Rules []models.S3CORSRule `yaml:"rules"`
+}
+
+// bucketConfigTemplates 為尚未設定時附加在編輯器內容後的註解範例。
+var bucketConfigTemplates = map[BucketConfig]string{
+	BucketConfigLifecycle: `# Example:
+# rules:
+#   - id: archive-logs
+#     status: Enabled
+#     prefix: logs/
+#     transitions:
+#       - days: 30
+#         storage_class: STANDARD_IA
+#       - days: 90
+#         storage_class: GLACIER
+#     expiration_days: 365
+#     noncurrent_expiration_days: 30
+#     abort_incomplete_upload_days: 7
+`,
+	BucketConfigCORS: `# Example:
+# rules:
+#   - id: web
+#     allowed_origins: ["https://example.com"]
+#     allowed_methods: [GET, HEAD]
+#     allowed_headers: ["*"]
+#     expose_headers: [ETag]
+#     max_age_seconds: 3000
+`,
+}
+
+// BucketConfigTemplate 回傳設定的註解範例。
+func BucketConfigTemplate(cfg BucketConfig) string {
+	return bucketConfigTemplates[cfg]
+}
+
+// BucketConfigYAML 取得 bucket 目前的設定並轉為 YAML；未設定時回傳 "rules: []"。
+func (s *Service) BucketConfigYAML(ctx context.Context, bucket string, cfg BucketConfig) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var text string
+	err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+		var err error
+		text, err = s.bucketConfigYAML(ctx, client, bucket, cfg)
+		return err
+	})
+	return text, err
+}
+
+func (s *Service) bucketConfigYAML(ctx context.Context, client *s3.Client, bucket string, cfg BucketConfig) (string, error) {
+	start := time.Now()
+	switch cfg {
+	case BucketConfigLifecycle:
+		rules, err := s.s3Repo.GetLifecycleRules(ctx, client, bucket)
+		s.observe(ctx, "s3", "GetBucketLifecycleConfiguration", start, err)
+		if err != nil {
+			return "", err
+		}
+		return marshalYAMLDocument(lifecycleDocument{Rules: rules})
+	case BucketConfigCORS:
+		rules, err := s.s3Repo.GetCORSRules(ctx, client, bucket)
+		s.observe(ctx, "s3", "GetBucketCors", start, err)
+		if err != nil {
+			return "", err
+		}
+		return marshalYAMLDocument(corsDocument{Rules: rules})
+	}
+	return "", fmt.Errorf("unsupported bucket config %q", cfg)
+}
+
+// PlanBucketConfig 解析並驗證編輯後的 YAML（不允許未知欄位），回傳與 original 的逐行差異。
+// 編輯內容只有註解或空白時視為刪除全部規則。
+func PlanBucketConfig(bucket string, cfg BucketConfig, original, edited string) (BucketConfigChange, error) {
+	change := BucketConfigChange{Bucket: bucket, Config: cfg, Original: original}
+
+	var (
+		doc   any
+		rules int
+		err   error
+	)
+	switch cfg {
+	case BucketConfigLifecycle:
+		var lifecycle lifecycleDocument
+		if err = decodeYAMLDocument(edited, &lifecycle); err == nil {
+			err = repo.ValidateLifecycleRules(lifecycle.Rules)
+		}
+		doc, rules = lifecycle, len(lifecycle.Rules)
+	case BucketConfigCORS:
+		var cors corsDocument
+		if err = decodeYAMLDocument(edited, &cors); err == nil {
+			err = repo.ValidateCORSRules(cors.Rules)
+		}
+		doc, rules = cors, len(cors.Rules)
+	default:
+		err = fmt.Errorf("unsupported bucket config %q", cfg)
+	}
+	if err != nil {
+		return change, err
+	}
+
+	change.Edited, err = marshalYAMLDocument(doc)
+	if err != nil {
+		return change, err
+	}
+	change.Rules = rules
+	change.Diff = diff.Text(original, change.Edited)
+	return change, nil
+}
+
+// ApplyBucketConfig 套用變更；若 bucket 的設定在開啟編輯器後已被修改則拒絕，避免覆蓋他人的變更。
+func (s *Service) ApplyBucketConfig(ctx context.Context, change BucketConfigChange) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err := s.withBucketClient(ctx, change.Bucket, func(client *s3.Client) error {
+		current, err := s.bucketConfigYAML(ctx, client, change.Bucket, change.Config)
+		if err != nil {
+			return err
+		}
+		if current != change.Original {
+			return fmt.Errorf("%s configuration of %s changed since it was loaded; reopen the editor", change.Config, change.Bucket)
+		}
+
+		start := time.Now()
+		switch change.Config {
+		case BucketConfigLifecycle:
+			var doc lifecycleDocument
+			if err := decodeYAMLDocument(change.Edited, &doc); err != nil {
+				return err
+			}
+			err = s.s3Repo.PutLifecycleRules(ctx, client, change.Bucket, doc.Rules)
+			s.observe(ctx, "s3", "PutBucketLifecycleConfiguration", start, err)
+		case BucketConfigCORS:
+			var doc corsDocument
+			if err := decodeYAMLDocument(change.Edited, &doc); err != nil {
+				return err
+			}
+			err = s.s3Repo.PutCORSRules(ctx, client, change.Bucket, doc.Rules)
+			s.observe(ctx, "s3", "PutBucketCors", start, err)
+		default:
+			err = fmt.Errorf("unsupported bucket config %q", change.Config)
+		}
+		return err
+	})
+	if err == nil {
+		s.forgetDetail(KindS3, change.Bucket)
+	}
+	return err
+}
+
+func decodeYAMLDocument(text string, doc any) error {
+	dec := yaml.NewDecoder(strings.NewReader(text))
+	dec.KnownFields(true)
+	if err := dec.Decode(doc); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	return nil
+}
+
+func marshalYAMLDocument(doc any) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// s3RuleRelations 將 lifecycle、CORS 與複寫規則逐條加入詳情 Relations。
+func s3RuleRelations(relations map[string][]string, bucket models.S3Bucket) {
+	lifecycle := make([]string, len(bucket.LifecycleRules))
+	for i, rule := range bucket.LifecycleRules {
+		lifecycle[i] = lifecycleRuleSummary(rule)
+	}
+	relations["Lifecycle Rules"] = lifecycle
+
+	cors := make([]string, len(bucket.CORSRules))
+	for i, rule := range bucket.CORSRules {
+		cors[i] = corsRuleSummary(rule)
+	}
+	relations["CORS Rules"] = cors
+
+	replication := make([]string, len(bucket.ReplicationRules))
+	for i, rule := range bucket.ReplicationRules {
+		replication[i] = replicationRuleSummary(rule)
+	}
+	relations["Replication Rules"] = replication
+}
+
+// lifecycleRuleSummary 例如 "archive (Enabled; prefix logs/): → GLACIER 30d; expire 365d"。
+func lifecycleRuleSummary(rule models.S3LifecycleRule) string {
+	scope := []string{rule.Status}
+	if rule.Prefix != "" {
+		scope = append(scope, "prefix "+rule.Prefix)
+	}
+	keys := make([]string, 0, len(rule.Tags))
+	for key := range rule.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		scope = append(scope, fmt.Sprintf("tag %s=%s", key, rule.Tags[key]))
+	}
+	if rule.ObjectSizeGreaterThan > 0 {
+		scope = append(scope, "> "+formatSize(rule.ObjectSizeGreaterThan))
+	}
+	if rule.ObjectSizeLessThan > 0 {
+		scope = append(scope, "< "+formatSize(rule.ObjectSizeLessThan))
+	}
+	if len(scope) == 1 {
+		scope = append(scope, "whole bucket")
+	}
+
+	var actions []string
+	for _, t := range rule.Transitions {
+		actions = append(actions, fmt.Sprintf("→ %s %s", t.StorageClass, ruleWhen(t.Days, t.Date)))
+	}
+	switch {
+	case rule.ExpirationDays != 0 || rule.ExpirationDate != "":
+		actions = append(actions, "expire "+ruleWhen(rule.ExpirationDays, rule.ExpirationDate))
+	case rule.ExpiredObjectDeleteMarker:
+		actions = append(actions, "remove expired delete markers")
+	}
+	for _, t := range rule.NoncurrentTransitions {
+		actions = append(actions, fmt.Sprintf("noncurrent → %s %dd", t.StorageClass, t.Days))
+	}
+	if rule.NoncurrentExpirationDays != 0 {
+		entry := fmt.Sprintf("noncurrent expire %dd", rule.NoncurrentExpirationDays)
+		if rule.NewerNoncurrentVersions != 0 {
+			entry += fmt.Sprintf(" (keep %d)", rule.NewerNoncurrentVersions)
+		}
+		actions = append(actions, entry)
+	}
+	if rule.AbortIncompleteUploadDays != 0 {
+		actions = append(actions, fmt.Sprintf("abort uploads %dd", rule.AbortIncompleteUploadDays))
+	}
+	return fmt.Sprintf("%s (%s): %s", rule.ID, strings.Join(scope, "; "), strings.Join(actions, "; "))
+}
+
+func ruleWhen(days int32, date string) string {
+	if date != "" {
+		return "on " + date
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
+// corsRuleSummary 例如 "web: GET, HEAD from https://example.com (max age 3000s)"。
+func corsRuleSummary(rule models.S3CORSRule) string {
+	entry := fmt.Sprintf("%s from %s", strings.Join(rule.AllowedMethods, ", "), strings.Join(rule.AllowedOrigins, ", "))
+	if rule.ID != "" {
+		entry = rule.ID + ": " + entry
+	}
+	var extra []string
+	if len(rule.AllowedHeaders) > 0 {
+		extra = append(extra, "headers "+strings.Join(rule.AllowedHeaders, ", "))
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		extra = append(extra, "expose "+strings.Join(rule.ExposeHeaders, ", "))
+	}
+	if rule.MaxAgeSeconds > 0 {
+		extra = append(extra, fmt.Sprintf("max age %ds", rule.MaxAgeSeconds))
+	}
+	if len(extra) > 0 {
+		entry += " (" + strings.Join(extra, "; ") + ")"
+	}
+	return entry
+}
+
+// replicationRuleSummary 例如 "replicate (Enabled; priority 1; prefix logs/) → backup-bucket STANDARD_IA"。
+func replicationRuleSummary(rule models.S3ReplicationRule) string {
+	scope := []string{rule.Status, fmt.Sprintf("priority %d", rule.Priority)}
+	if rule.Prefix != "" {
+		scope = append(scope, "prefix "+rule.Prefix)
+	}
+	if rule.DeleteMarkerReplication {
+		scope = append(scope, "delete markers")
+	}
+	entry := fmt.Sprintf("%s (%s) → %s", rule.ID, strings.Join(scope, "; "), rule.Destination)
+	if rule.StorageClass != "" {
+		entry += " " + rule.StorageClass
+	}
+	return entry
+}
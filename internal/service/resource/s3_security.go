@@ -0,0 +1,154 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// accountPublicAccessBlock 查詢帳號層級的 Block Public Access（每次列出 bucket 或查看詳情查詢一次）。
+func (s *Service) accountPublicAccessBlock(ctx context.Context, profile string) (models.S3PublicAccessBlock, error) {
+	stsClient, err := s.factory.STS(ctx, profile, s.state.Region())
+	if err != nil {
+		return models.S3PublicAccessBlock{}, err
+	}
+	start := time.Now()
+	account, err := repo.CallerAccount(ctx, stsClient)
+	s.observe(ctx, "sts", "GetCallerIdentity", start, err)
+	if err != nil {
+		return models.S3PublicAccessBlock{}, err
+	}
+	control, err := s.factory.S3Control(ctx, profile, s.state.Region())
+	if err != nil {
+		return models.S3PublicAccessBlock{}, err
+	}
+	start = time.Now()
+	block, err := s.s3Repo.GetAccountPublicAccessBlock(ctx, control, account)
+	s.observe(ctx, "s3control", "GetPublicAccessBlock", start, err)
+	return block, err
+}
+
+// applyAccountPublicAccess 將帳號層級設定併入 bucket 的公開判斷；查詢失敗時記錄於 Errors，只依 bucket 層級判斷。
+func applyAccountPublicAccess(bucket *models.S3Bucket, account models.S3PublicAccessBlock, err error) {
+	if !bucket.Enriched {
+		return
+	}
+	if err != nil {
+		if bucket.Errors == nil {
+			bucket.Errors = make(map[string]string)
+		}
+		bucket.Errors["GetAccountPublicAccessBlock"] = err.Error()
+	}
+	bucket.Security.AccountPublicAccessBlock = account
+	repo.EvaluatePublic(&bucket.Security)
+}
+
+// s3AccessBadge 回傳 bucket 清單「存取」欄的標示。
+func s3AccessBadge(bucket models.S3Bucket) string {
+	switch {
+	case !bucket.Enriched:
+		return "…"
+	case bucket.Security.Public:
+		return "⚠ PUBLIC"
+	}
+	return "Private"
+}
+
+// s3SecurityOverview 將安全設定加入詳情 Overview；未設定的項目明確標示，以便與查詢失敗區分。
+func s3SecurityOverview(overview map[string]string, bucket models.S3Bucket) {
+	sec := bucket.Security
+	public := "No"
+	if sec.Public {
+		public = "[red]Yes[-]"
+	}
+	overview["Public Access"] = public
+	overview["Block Public Access"] = publicAccessBlockSummary(sec.PublicAccessBlock)
+	overview["Account Block Public Access"] = publicAccessBlockSummary(sec.AccountPublicAccessBlock)
+	overview["Object Ownership"] = orNotConfigured(sec.ObjectOwnership)
+	overview["Owner"] = sec.Owner
+	if sec.Described {
+		overview["Logging"] = sec.Logging
+		overview["Replication"] = orNotConfigured(sec.Replication)
+		overview["Object Lock"] = orNotConfigured(sec.ObjectLock)
+	}
+	if sec.PolicyIsPublic != nil {
+		overview["Policy Status"] = map[bool]string{true: "Public", false: "Not public"}[*sec.PolicyIsPublic]
+	}
+}
+
+// s3SecurityRelations 將 ACL、公開的 policy 陳述與判定原因加入詳情 Relations。
+func s3SecurityRelations(relations map[string][]string, sec models.S3BucketSecurity) {
+	grants := make([]string, 0, len(sec.Grants))
+	for _, g := range sec.Grants {
+		entry := fmt.Sprintf("%s: %s", g.Grantee, g.Permission)
+		if g.Public {
+			entry = "⚠ " + entry
+		}
+		grants = append(grants, entry)
+	}
+	relations["ACL Grants"] = grants
+
+	findings := make([]string, 0, len(sec.PolicyFindings))
+	for _, f := range sec.PolicyFindings {
+		findings = append(findings, PolicyFindingSummary(f))
+	}
+	relations["Public Policy Statements"] = findings
+	relations["Public Because"] = sec.PublicReasons
+}
+
+func publicAccessBlockSummary(pab models.S3PublicAccessBlock) string {
+	if !pab.Configured {
+		return "Not configured"
+	}
+	var on, off []string
+	for _, setting := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"BlockPublicAcls", pab.BlockPublicAcls},
+		{"IgnorePublicAcls", pab.IgnorePublicAcls},
+		{"BlockPublicPolicy", pab.BlockPublicPolicy},
+		{"RestrictPublicBuckets", pab.RestrictPublicBuckets},
+	} {
+		if setting.enabled {
+			on = append(on, setting.name)
+		} else {
+			off = append(off, setting.name)
+		}
+	}
+	switch {
+	case len(off) == 0:
+		return "All blocked"
+	case len(on) == 0:
+		return "All off"
+	}
+	return "Off: " + strings.Join(off, ", ")
+}
+
+func orNotConfigured(value string) string {
+	if value == "" {
+		return "Not configured"
+	}
+	return value
+}
+
+// PolicyFindingSummary 回傳公開 policy 陳述的顯示文字：以 NotPrincipal 允許的陳述列出被排除的主體，而非 Principal *。
+func PolicyFindingSummary(f models.S3PolicyFinding) string {
+	sid := f.Sid
+	if sid == "" {
+		sid = "(no Sid)"
+	}
+	principal := "Principal *"
+	if f.NotPrincipal {
+		principal = fmt.Sprintf("NotPrincipal [%s]", strings.Join(f.Excluded, ", "))
+	}
+	entry := fmt.Sprintf("%s: %s %s", sid, principal, strings.Join(f.Actions, ", "))
+	if f.Conditional {
+		entry += " (conditional)"
+	}
+	return entry
+}
@@ -0,0 +1,240 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// transferNotifyInterval 為傳輸期間回報進度的最短間隔。
+const transferNotifyInterval = 200 * time.Millisecond
+
+// TransferProgress 描述一次下載/上傳的整體進度。
+type TransferProgress struct {
+	File      string // 目前傳輸中的檔案（S3 key 或本機路徑）
+	Files     int
+	FilesDone int
+	Bytes     int64
+	BytesDone int64
+}
+
+// TransferResult 為完成的傳輸摘要。
+type TransferResult struct {
+	Files int
+	Bytes int64
+}
+
+// DownloadObjects 將目前 bucket 中的物件下載到本機目錄 dir。
+// key 以 / 結尾時視為目錄，遞迴下載其下所有物件並保留相對於目前 prefix 的路徑。
+// 傳輸不套用 API timeout，以 ctx 取消。
+func (s *Service) DownloadObjects(ctx context.Context, key, dir string, onProgress func(TransferProgress)) (TransferResult, error) {
+	if s.currentBucket == "" {
+		return TransferResult{}, fmt.Errorf("no bucket selected")
+	}
+	bucket, prefix := s.currentBucket, s.currentPrefix
+
+	var objects []models.S3Object
+	err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+		start := time.Now()
+		var err error
+		if strings.HasSuffix(key, "/") {
+			objects, err = s.s3Repo.ListKeys(ctx, client, bucket, key)
+			s.observe(ctx, "s3", "ListObjectsV2", start, err)
+			return err
+		}
+		var info models.S3ObjectInfo
+		info, err = s.s3Repo.HeadObject(ctx, client, bucket, key)
+		s.observe(ctx, "s3", "HeadObject", start, err)
+		objects = []models.S3Object{info.S3Object}
+		return err
+	})
+	if err != nil {
+		return TransferResult{}, err
+	}
+
+	if len(objects) == 0 {
+		return TransferResult{}, fmt.Errorf("no objects under %s", key)
+	}
+
+	// key 可能含有 ../ 或絕對路徑，只接受落在 dir 之內的目的地
+	dests := make([]string, len(objects))
+	for i, obj := range objects {
+		rel := strings.TrimPrefix(obj.Key, prefix)
+		if !strings.HasSuffix(key, "/") {
+			rel = path.Base(obj.Key)
+		}
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return TransferResult{}, fmt.Errorf("refusing to download %s outside %s", obj.Key, dir)
+		}
+		dests[i] = filepath.Join(dir, filepath.FromSlash(rel))
+	}
+
+	tracker := newTransferTracker(objects, onProgress)
+	for i, obj := range objects {
+		tracker.begin(obj.Key)
+		err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+			start := time.Now()
+			err := s.s3Repo.DownloadObject(ctx, client, bucket, obj.Key, "", obj.Size, dests[i], tracker.add)
+			s.observe(ctx, "s3", "GetObject", start, err)
+			return err
+		})
+		if err != nil {
+			return tracker.result(), err
+		}
+		tracker.finish()
+	}
+	return tracker.result(), nil
+}
+
+// UploadPath 將本機檔案或目錄上傳到目前 bucket/prefix。
+// 目錄會連同目錄名稱遞迴上傳，例如上傳 ./site 會建立 <prefix>site/...。
+func (s *Service) UploadPath(ctx context.Context, local string, onProgress func(TransferProgress)) (TransferResult, error) {
+	if s.currentBucket == "" {
+		return TransferResult{}, fmt.Errorf("no bucket selected")
+	}
+	bucket, prefix := s.currentBucket, s.currentPrefix
+
+	files, err := collectUploadFiles(local, prefix)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	if len(files) == 0 {
+		return TransferResult{}, fmt.Errorf("no files to upload in %s", local)
+	}
+
+	objects := make([]models.S3Object, len(files))
+	for i, f := range files {
+		objects[i] = models.S3Object{Key: f.path, Size: f.size}
+	}
+	tracker := newTransferTracker(objects, onProgress)
+	for _, f := range files {
+		tracker.begin(f.path)
+		err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+			start := time.Now()
+			err := s.s3Repo.UploadFile(ctx, client, bucket, f.key, f.path, tracker.add)
+			s.observe(ctx, "s3", "PutObject", start, err)
+			return err
+		})
+		if err != nil {
+			return tracker.result(), err
+		}
+		tracker.finish()
+	}
+	return tracker.result(), nil
+}
+
+// uploadFile 為待上傳的本機檔案與目標 key。
+type uploadFile struct {
+	path string
+	key  string
+	size int64
+}
+
+func collectUploadFiles(local, prefix string) ([]uploadFile, error) {
+	info, err := os.Stat(local)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", local, err)
+	}
+	if !info.IsDir() {
+		return []uploadFile{{path: local, key: prefix + filepath.Base(local), size: info.Size()}}, nil
+	}
+
+	root := filepath.Clean(local)
+	base := filepath.Base(root)
+	var files []uploadFile
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, uploadFile{
+			path: p,
+			key:  prefix + base + "/" + filepath.ToSlash(rel),
+			size: info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s: %w", local, err)
+	}
+	return files, nil
+}
+
+// transferTracker 彙整多個檔案的傳輸進度，並節流回報。
+type transferTracker struct {
+	mu         sync.Mutex
+	progress   TransferProgress
+	lastNotify time.Time
+	onProgress func(TransferProgress)
+}
+
+func newTransferTracker(objects []models.S3Object, onProgress func(TransferProgress)) *transferTracker {
+	t := &transferTracker{onProgress: onProgress}
+	t.progress.Files = len(objects)
+	for _, obj := range objects {
+		t.progress.Bytes += obj.Size
+	}
+	return t
+}
+
+func (t *transferTracker) begin(file string) {
+	t.mu.Lock()
+	t.progress.File = file
+	t.mu.Unlock()
+	t.notify(true)
+}
+
+func (t *transferTracker) add(n int64) {
+	t.mu.Lock()
+	t.progress.BytesDone += n
+	t.mu.Unlock()
+	t.notify(false)
+}
+
+func (t *transferTracker) finish() {
+	t.mu.Lock()
+	t.progress.FilesDone++
+	t.mu.Unlock()
+	t.notify(true)
+}
+
+func (t *transferTracker) notify(force bool) {
+	if t.onProgress == nil {
+		return
+	}
+	t.mu.Lock()
+	if !force && time.Since(t.lastNotify) < transferNotifyInterval {
+		t.mu.Unlock()
+		return
+	}
+	t.lastNotify = time.Now()
+	progress := t.progress
+	t.mu.Unlock()
+	t.onProgress(progress)
+}
+
+func (t *transferTracker) result() TransferResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return TransferResult{Files: t.progress.FilesDone, Bytes: t.progress.BytesDone}
+}
@@ -0,0 +1,274 @@
+package resource
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// KindS3Usage 表示 S3 用量分析結果（依 prefix 的大小與 storage class 分佈）。
+const KindS3Usage Kind = "s3-usage"
+
+// usageFilesID 為「直接位於目前 prefix 的物件」列的 ID。
+const usageFilesID = "\x00files"
+
+func init() {
+	Register(Definition{
+		Kind:  KindS3Usage,
+		Label: "resource.s3_usage",
+		Columns: []models.Column{
+			nameColumn,
+			column("column.size", func(item models.ListItem) string { return item.Status }),
+			metadataColumn("column.objects", "objects"),
+			metadataColumn("column.share", "share"),
+			metadataColumn("column.storage_classes", "classes"),
+		},
+		List: listS3Usage,
+		Scope: func(s *Service) string {
+			node := s.UsageNode()
+			if node == nil {
+				return ""
+			}
+			return fmt.Sprintf("%s/%s#%d", s.usageBucket, node.Prefix, s.usageGen)
+		},
+		Keys: map[rune]Command{
+			'e': CommandUsageExport,
+		},
+	})
+}
+
+// AnalyzeUsage 遞迴分析 bucket 中 prefix 下的用量，完成後可用 KindS3Usage 瀏覽。
+// onProgress 回報累計掃描的物件數；分析不套用 API timeout，以 ctx 取消。
+func (s *Service) AnalyzeUsage(ctx context.Context, bucket, prefix string, onProgress func(objects int64)) error {
+	if bucket == "" {
+		return fmt.Errorf("no bucket selected")
+	}
+	var root *models.S3PrefixUsage
+	err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+		start := time.Now()
+		var err error
+		root, err = s.s3Repo.AnalyzePrefix(ctx, client, bucket, prefix, onProgress)
+		s.observe(ctx, "s3", "ListObjectsV2", start, err)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.usageBucket = bucket
+	s.usagePath = []*models.S3PrefixUsage{root}
+	s.usageGen++
+	return nil
+}
+
+// UsageBucket 回傳用量分析的 bucket。
+func (s *Service) UsageBucket() string {
+	return s.usageBucket
+}
+
+// UsageNode 回傳目前瀏覽的用量節點；尚未分析時回傳 nil。
+func (s *Service) UsageNode() *models.S3PrefixUsage {
+	if len(s.usagePath) == 0 {
+		return nil
+	}
+	return s.usagePath[len(s.usagePath)-1]
+}
+
+// UsageEnter 進入目前節點下的子 prefix，回傳是否找到。
+func (s *Service) UsageEnter(prefix string) bool {
+	node := s.UsageNode()
+	if node == nil {
+		return false
+	}
+	for _, child := range node.Children {
+		if child.Prefix == prefix {
+			s.usagePath = append(s.usagePath, child)
+			return true
+		}
+	}
+	return false
+}
+
+// UsageUp 回到上一層 prefix；已在分析的根 prefix 時回傳 false。
+func (s *Service) UsageUp() bool {
+	if len(s.usagePath) <= 1 {
+		return false
+	}
+	s.usagePath = s.usagePath[:len(s.usagePath)-1]
+	return true
+}
+
+// ClearUsage 捨棄用量分析結果。
+func (s *Service) ClearUsage() {
+	s.usageBucket = ""
+	s.usagePath = nil
+}
+
+// ExportUsage 將完整的分析結果寫入 path；副檔名為 .json 時輸出巢狀 JSON，其餘輸出每個 prefix 一列的 CSV。
+func (s *Service) ExportUsage(path string) error {
+	if len(s.usagePath) == 0 {
+		return fmt.Errorf("no usage analysis")
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = WriteUsageJSON(file, s.usageBucket, s.usagePath[0])
+	} else {
+		err = WriteUsageCSV(file, s.usageBucket, s.usagePath[0])
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// WriteUsageJSON 以縮排 JSON 輸出分析結果。
+func WriteUsageJSON(w io.Writer, bucket string, root *models.S3PrefixUsage) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Bucket string `json:"bucket"`
+		*models.S3PrefixUsage
+	}{bucket, root})
+}
+
+// WriteUsageCSV 以 CSV 輸出每個 prefix 的用量（深度優先），每個 storage class 各兩欄（bytes、objects）。
+func WriteUsageCSV(w io.Writer, bucket string, root *models.S3PrefixUsage) error {
+	classes := make([]string, 0, len(root.ByClass))
+	for class := range root.ByClass {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	cw := csv.NewWriter(w)
+	header := []string{"bucket", "prefix", "depth", "bytes", "objects", "direct_bytes", "direct_objects"}
+	for _, class := range classes {
+		header = append(header, class+"_bytes", class+"_objects")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	var walk func(node *models.S3PrefixUsage, depth int) error
+	walk = func(node *models.S3PrefixUsage, depth int) error {
+		row := []string{
+			bucket,
+			node.Prefix,
+			strconv.Itoa(depth),
+			strconv.FormatInt(node.Size, 10),
+			strconv.FormatInt(node.Objects, 10),
+			strconv.FormatInt(node.Direct.Size, 10),
+			strconv.FormatInt(node.Direct.Objects, 10),
+		}
+		for _, class := range classes {
+			usage := node.ByClass[class]
+			row = append(row, strconv.FormatInt(usage.Size, 10), strconv.FormatInt(usage.Objects, 10))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+		for _, child := range node.Children {
+			if err := walk(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root, 0); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func listS3Usage(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	node := s.UsageNode()
+	if node == nil {
+		return nil, nil, fmt.Errorf("no usage analysis")
+	}
+	items, details := buildS3UsageList(node, s.usageBucket)
+	return items, details, nil
+}
+
+func buildS3UsageList(node *models.S3PrefixUsage, bucket string) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(node.Children)+1)
+	details := make(map[string]models.DetailView, len(node.Children)+1)
+
+	for _, child := range node.Children {
+		items = append(items, usageItem(child.Prefix, strings.TrimPrefix(child.Prefix, node.Prefix), "Dir", child.S3StorageUsage, node.Size, child.ByClass))
+		details[child.Prefix] = usageDetail(bucket, child.Prefix, child.S3StorageUsage, node.Size, child.ByClass)
+	}
+	if node.Direct.Objects > 0 {
+		items = append(items, usageItem(usageFilesID, "(files)", "Files", node.Direct, node.Size, node.DirectByClass))
+		details[usageFilesID] = usageDetail(bucket, node.Prefix, node.Direct, node.Size, node.DirectByClass)
+	}
+	return items, details
+}
+
+func usageItem(id, name, kind string, usage models.S3StorageUsage, parent int64, byClass map[string]models.S3StorageUsage) models.ListItem {
+	return models.ListItem{
+		ID:     id,
+		Name:   name,
+		Type:   kind,
+		Status: formatSize(usage.Size),
+		Metadata: map[string]string{
+			"objects": strconv.FormatInt(usage.Objects, 10),
+			"share":   formatShare(usage.Size, parent),
+			"classes": classSummary(byClass, usage.Size),
+		},
+	}
+}
+
+func usageDetail(bucket, prefix string, usage models.S3StorageUsage, parent int64, byClass map[string]models.S3StorageUsage) models.DetailView {
+	overview := map[string]string{
+		"Bucket":  bucket,
+		"Prefix":  prefix,
+		"Size":    formatSize(usage.Size),
+		"Bytes":   strconv.FormatInt(usage.Size, 10),
+		"Objects": strconv.FormatInt(usage.Objects, 10),
+		"Share":   formatShare(usage.Size, parent),
+	}
+	for class, u := range byClass {
+		overview["Class "+class] = fmt.Sprintf("%s (%d objects, %s)", formatSize(u.Size), u.Objects, formatShare(u.Size, usage.Size))
+	}
+	return models.DetailView{Overview: overview}
+}
+
+// classSummary 依大小列出 storage class 占比，例如 "STANDARD 80% · GLACIER 20%"。
+func classSummary(byClass map[string]models.S3StorageUsage, total int64) string {
+	classes := make([]string, 0, len(byClass))
+	for class := range byClass {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		if byClass[classes[i]].Size != byClass[classes[j]].Size {
+			return byClass[classes[i]].Size > byClass[classes[j]].Size
+		}
+		return classes[i] < classes[j]
+	})
+	parts := make([]string, len(classes))
+	for i, class := range classes {
+		parts[i] = class + " " + formatShare(byClass[class].Size, total)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func formatShare(size, total int64) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(size)*100/float64(total))
+}
@@ -0,0 +1,262 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// KindS3Versions 表示單一物件 key 的版本清單。
+const KindS3Versions Kind = "s3-versions"
+
+const (
+	// ActionRestoreVersion 將選取版本設為最新版本（刪除標記則移除該標記）。
+	ActionRestoreVersion Action = "restore-version"
+	// ActionDeleteVersion 永久刪除選取的版本或刪除標記。
+	ActionDeleteVersion Action = "delete-version"
+)
+
+func init() {
+	Register(Definition{
+		Kind:  KindS3Versions,
+		Label: "resource.s3_versions",
+		Columns: []models.Column{
+			column("column.version_id", func(item models.ListItem) string { return item.Name }),
+			typeColumn,
+			column("column.size", func(item models.ListItem) string { return item.Status }),
+			column("column.last_modified", func(item models.ListItem) string { return item.Region }),
+			metadataColumn("column.latest", "latest"),
+		},
+		List:     listS3Versions,
+		Scope:    func(s *Service) string { return s.currentBucket + "/" + s.currentVersionKey },
+		Actions:  []Action{ActionRestoreVersion, ActionDeleteVersion},
+		Precheck: precheckS3VersionAction,
+		Execute:  executeS3VersionAction,
+		Keys: map[rune]Command{
+			'd': CommandDownload,
+		},
+	})
+}
+
+// SetCurrentVersionKey 設定版本清單要查看的物件 key（位於目前 bucket）。
+func (s *Service) SetCurrentVersionKey(key string) {
+	s.currentVersionKey = key
+}
+
+// CurrentVersionKey 回傳目前查看版本的物件 key。
+func (s *Service) CurrentVersionKey() string {
+	return s.currentVersionKey
+}
+
+func listS3Versions(ctx context.Context, s *Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	if s.currentBucket == "" || s.currentVersionKey == "" {
+		return nil, nil, fmt.Errorf("no object selected")
+	}
+	bucket, key := s.currentBucket, s.currentVersionKey
+
+	var versions []models.S3ObjectVersion
+	err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+		start := time.Now()
+		status, err := s.s3Repo.GetBucketVersioning(ctx, client, bucket)
+		s.observe(ctx, "s3", "GetBucketVersioning", start, err)
+		if err != nil {
+			return err
+		}
+		if status == "Disabled" {
+			return fmt.Errorf("versioning is not enabled for bucket %s", bucket)
+		}
+
+		start = time.Now()
+		versions, err = s.s3Repo.ListObjectVersions(ctx, client, bucket, key)
+		s.observe(ctx, "s3", "ListObjectVersions", start, err)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	items, details := buildS3VersionList(versions, bucket)
+	return items, details, nil
+}
+
+func buildS3VersionList(versions []models.S3ObjectVersion, bucket string) ([]models.ListItem, map[string]models.DetailView) {
+	items := make([]models.ListItem, 0, len(versions))
+	details := make(map[string]models.DetailView, len(versions))
+
+	for _, v := range versions {
+		versionType, size := "Version", formatSize(v.Size)
+		if v.IsDeleteMarker {
+			versionType, size = "DeleteMarker", ""
+		}
+		latest := ""
+		if v.IsLatest {
+			latest = "✓"
+		}
+		items = append(items, models.ListItem{
+			ID:     v.VersionID,
+			Name:   v.VersionID,
+			Type:   versionType,
+			Status: size,
+			Region: v.LastModified,
+			Metadata: map[string]string{
+				"latest": latest,
+				"key":    v.Key,
+			},
+		})
+		details[v.VersionID] = models.DetailView{
+			Overview: map[string]string{
+				"Key":           v.Key,
+				"Bucket":        bucket,
+				"Version ID":    v.VersionID,
+				"Type":          versionType,
+				"Size":          size,
+				"Last Modified": v.LastModified,
+				"Storage Class": v.StorageClass,
+				"ETag":          v.ETag,
+				"Latest":        fmt.Sprintf("%t", v.IsLatest),
+			},
+		}
+	}
+	return items, details
+}
+
+// findVersion 重新查詢版本清單並找出指定版本，確保操作依據的是最新狀態。
+func (s *Service) findVersion(ctx context.Context, versionID string) (models.S3ObjectVersion, error) {
+	bucket, key := s.currentBucket, s.currentVersionKey
+	var versions []models.S3ObjectVersion
+	err := s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+		start := time.Now()
+		var err error
+		versions, err = s.s3Repo.ListObjectVersions(ctx, client, bucket, key)
+		s.observe(ctx, "s3", "ListObjectVersions", start, err)
+		return err
+	})
+	if err != nil {
+		return models.S3ObjectVersion{}, err
+	}
+	for _, v := range versions {
+		if v.VersionID == versionID {
+			return v, nil
+		}
+	}
+	return models.S3ObjectVersion{}, fmt.Errorf("version %s of %s not found", versionID, key)
+}
+
+// precheckS3VersionAction 檢查版本是否可還原，永久刪除時提示無法復原。
+func precheckS3VersionAction(ctx context.Context, s *Service, id string, action Action) (bool, Notice, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	version, err := s.findVersion(ctx, id)
+	if err != nil {
+		return false, Notice{}, err
+	}
+	switch action {
+	case ActionRestoreVersion:
+		switch {
+		case version.IsDeleteMarker && version.IsLatest:
+			return true, Notice{Key: "versions.restore_marker"}, nil
+		case version.IsDeleteMarker:
+			return false, Notice{Key: "versions.marker_not_latest"}, nil
+		case version.IsLatest:
+			return false, Notice{Key: "versions.already_latest"}, nil
+		}
+		return true, Notice{Key: "versions.restore_copy", Args: []any{id, formatSize(version.Size)}}, nil
+	case ActionDeleteVersion:
+		if version.IsLatest && !version.IsDeleteMarker {
+			return true, Notice{Key: "versions.delete_current", Args: []any{id}}, nil
+		}
+		return true, Notice{Key: "versions.delete_permanent", Args: []any{id}}, nil
+	}
+	return false, Notice{}, fmt.Errorf("action %s not supported for s3 versions", action)
+}
+
+func executeS3VersionAction(ctx context.Context, s *Service, id string, action Action, _ func(string)) (ActionResult, error) {
+	bucket, key := s.currentBucket, s.currentVersionKey
+	version, err := s.findVersion(ctx, id)
+	if err != nil {
+		return ActionResult{}, err
+	}
+
+	var message Notice
+	switch action {
+	case ActionRestoreVersion:
+		if version.IsDeleteMarker {
+			if !version.IsLatest {
+				return ActionResult{}, fmt.Errorf("only the latest delete marker can be restored")
+			}
+			err = s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+				return s.runOp(ctx, "s3", "DeleteObject", func(ctx context.Context) error {
+					return s.s3Repo.DeleteVersion(ctx, client, bucket, key, id)
+				})
+			})
+			message = Notice{Key: "versions.marker_removed", Args: []any{id}}
+			break
+		}
+		if version.IsLatest {
+			return ActionResult{}, fmt.Errorf("version %s is already the latest", id)
+		}
+		var newVersion string
+		err = s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+			return s.runOp(ctx, "s3", "CopyObject", func(ctx context.Context) error {
+				var err error
+				newVersion, err = s.s3Repo.RestoreVersion(ctx, client, bucket, key, id)
+				return err
+			})
+		})
+		message = Notice{Key: "versions.restored", Args: []any{id, newVersion}}
+	case ActionDeleteVersion:
+		err = s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+			return s.runOp(ctx, "s3", "DeleteObject", func(ctx context.Context) error {
+				return s.s3Repo.DeleteVersion(ctx, client, bucket, key, id)
+			})
+		})
+		message = Notice{Key: "versions.deleted", Args: []any{id}}
+	default:
+		return ActionResult{}, fmt.Errorf("action %s not supported for s3 versions", action)
+	}
+	if err != nil {
+		return ActionResult{}, err
+	}
+	return ActionResult{Action: action, ResourceID: id, MessageKey: message.Key, MessageArgs: message.Args, Refresh: true}, nil
+}
+
+// DownloadVersion 下載目前物件的指定版本到本機目錄 dir，檔名為 <name>.<versionID>。
+func (s *Service) DownloadVersion(ctx context.Context, versionID, dir string, onProgress func(TransferProgress)) (TransferResult, error) {
+	if s.currentBucket == "" || s.currentVersionKey == "" {
+		return TransferResult{}, fmt.Errorf("no object selected")
+	}
+	bucket, key := s.currentBucket, s.currentVersionKey
+
+	version, err := s.findVersion(ctx, versionID)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	if version.IsDeleteMarker {
+		return TransferResult{}, fmt.Errorf("version %s is a delete marker and has no content", versionID)
+	}
+	name := path.Base(key) + "." + versionID
+	if !filepath.IsLocal(name) {
+		return TransferResult{}, fmt.Errorf("refusing to download %s outside %s", key, dir)
+	}
+	dest := filepath.Join(dir, name)
+
+	tracker := newTransferTracker([]models.S3Object{{Key: key, Size: version.Size}}, onProgress)
+	tracker.begin(key)
+	err = s.withBucketClient(ctx, bucket, func(client *s3.Client) error {
+		start := time.Now()
+		err := s.s3Repo.DownloadObject(ctx, client, bucket, key, versionID, version.Size, dest, tracker.add)
+		s.observe(ctx, "s3", "GetObject", start, err)
+		return err
+	})
+	if err != nil {
+		return tracker.result(), err
+	}
+	tracker.finish()
+	return tracker.result(), nil
+}
@@ -20,19 +20,6 @@ import (
 	"github.com/vincent119/awsGUITools/internal/search"
 )
 
-// Kind 表示資源類型。
-type Kind string
-
-const (
-	KindEC2            Kind = "ec2"
-	KindRDS            Kind = "rds"
-	KindS3             Kind = "s3"
-	KindLambda         Kind = "lambda"
-	KindRoute53        Kind = "route53"
-	KindRoute53Records Kind = "route53-records"
-	KindS3Objects      Kind = "s3-objects"
-)
-
 // Service 封裝資源查詢與轉換邏輯，供 UI 直接使用。
 type Service struct {
 	factory *clients.Factory
@@ -40,24 +27,53 @@ type Service struct {
 	timeout time.Duration
 	state   *state.Store
 
-	ec2Repo     *repo.EC2Repository
-	rdsRepo     *repo.RDSRepository
-	s3Repo      *repo.S3Repository
-	lambdaRepo  *repo.LambdaRepository
-	route53Repo *repo.Route53Repository
-	metricFetch metrics.MetricAPI
-	logFetch    *logs.Fetcher
-
-	mu    sync.RWMutex
-	cache map[Kind]map[string]models.DetailView
+	registry     *Registry
+	ec2Repo      *repo.EC2Repository
+	rdsRepo      *repo.RDSRepository
+	s3Repo       *repo.S3Repository
+	lambdaRepo   *repo.LambdaRepository
+	route53Repo  *repo.Route53Repository
+	resolverRepo *repo.ResolverRepository
+	metricFetch  metrics.MetricAPI
+	logFetch     *logs.Fetcher
+
+	mu         sync.RWMutex
+	cache      map[cacheKey]*cacheEntry
+	defaultTTL time.Duration
+	ttls       map[Kind]time.Duration
+	onUpdate   func(kind Kind)
+
+	// S3 bucket 清單是否先顯示名稱，再於背景補齊設定
+	s3LazyEnrich bool
+	// 分頁清單每頁筆數
+	pageSize int32
+	// S3 物件預覽讀取的位元組數
+	previewSize int64
 
 	// S3 瀏覽狀態
 	currentBucket string
 	currentPrefix string
+	// currentVersionKey 為版本清單查看的物件 key
+	currentVersionKey string
+	bucketRegions     map[string]string // profile|bucket → region
+	// S3 用量分析結果；usagePath 為目前瀏覽的節點路徑（第一個為分析的根 prefix）
+	usageBucket string
+	usagePath   []*models.S3PrefixUsage
+	usageGen    int
 
 	// Route53 瀏覽狀態
 	currentZoneID   string
 	currentZoneName string
+	// Route53 暫存的 record 變更：zone ID → record ID → 套用後的 record（nil 表示刪除）；
+	// zoneRecords 為 zoneRecordsID 最近一次列出的 records，供暫存後重新產生清單
+	changesMu     sync.Mutex
+	recordEdits   map[string]map[string]*models.Route53Record
+	zoneRecordsID string
+	zoneRecords   []models.Route53Record
+
+	// Lambda 瀏覽狀態：子清單（觸發來源等）所屬的函式
+	currentFunction     string // ARN
+	currentFunctionName string
 }
 
 // NewService 建立資源服務。
@@ -66,20 +82,31 @@ func NewService(factory *clients.Factory, metrics *observability.AWSCallMetrics,
 		timeout = 15 * time.Second
 	}
 	return &Service{
-		factory:     factory,
-		metrics:     metrics,
-		timeout:     timeout,
-		state:       st,
-		ec2Repo:     repo.NewEC2Repository(),
-		rdsRepo:     repo.NewRDSRepository(),
-		s3Repo:      repo.NewS3Repository(),
-		lambdaRepo:  repo.NewLambdaRepository(),
-		route53Repo: repo.NewRoute53Repository(),
-		cache:       make(map[Kind]map[string]models.DetailView),
+		factory:       factory,
+		metrics:       metrics,
+		timeout:       timeout,
+		state:         st,
+		registry:      DefaultRegistry(),
+		ec2Repo:       repo.NewEC2Repository(),
+		rdsRepo:       repo.NewRDSRepository(),
+		s3Repo:        repo.NewS3Repository(),
+		lambdaRepo:    repo.NewLambdaRepository(),
+		route53Repo:   repo.NewRoute53Repository(),
+		resolverRepo:  repo.NewResolverRepository(),
+		cache:         make(map[cacheKey]*cacheEntry),
+		defaultTTL:    DefaultCacheTTL,
+		bucketRegions: make(map[string]string),
+		pageSize:      defaultPageSize,
+		previewSize:   defaultPreviewSize,
 	}
 }
 
-// ListItems 依資源類型列出清單（會套用搜尋條件並更新 Detail 快取）。
+// Registry 回傳資源類型 Registry，供 UI 取得欄位、切換鍵與可用操作。
+func (s *Service) Registry() *Registry {
+	return s.registry
+}
+
+// ListItems 依資源類型重新查詢清單，更新目前 profile/region 的快取後套用搜尋條件。
 func (s *Service) ListItems(ctx context.Context, kind Kind, matcher search.Matcher) ([]models.ListItem, error) {
 	if s.factory == nil {
 		return nil, errors.New("aws client factory is nil")
@@ -88,131 +115,136 @@ func (s *Service) ListItems(ctx context.Context, kind Kind, matcher search.Match
 		return nil, errors.New("state store is nil")
 	}
 
+	def, ok := s.registry.Lookup(kind)
+	if !ok {
+		return nil, fmt.Errorf("unknown resource kind: %s", kind)
+	}
+	// 查詢前先決定快取鍵，查詢期間切換 profile/region 也不會存錯位置
+	key := s.cacheKey(def)
+
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	profile := s.state.Profile()
-	region := s.state.Region()
-
 	var (
 		items   []models.ListItem
 		details map[string]models.DetailView
+		next    string
 		err     error
 	)
+	if def.Page != nil {
+		var page ListPage
+		page, err = def.Page(ctx, s, "")
+		items, details, next = page.Items, page.Details, page.Next
+	} else {
+		items, details, err = def.List(ctx, s)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	switch kind {
-	case KindEC2:
-		client, errClient := s.factory.EC2(ctx, profile, region)
-		if errClient != nil {
-			return nil, errClient
-		}
-		var instances []models.EC2Instance
-		start := time.Now()
-		instances, err = s.ec2Repo.ListInstances(ctx, client, nil)
-		s.observe(ctx, "ec2", "DescribeInstances", start, err)
-		if err == nil {
-			items, details = buildEC2List(instances, matcher)
-		}
-	case KindRDS:
-		client, errClient := s.factory.RDS(ctx, profile, region)
-		if errClient != nil {
-			return nil, errClient
-		}
-		var dbs []models.RDSInstance
-		start := time.Now()
-		dbs, err = s.rdsRepo.ListInstances(ctx, client, nil)
-		s.observe(ctx, "rds", "DescribeDBInstances", start, err)
-		if err == nil {
-			items, details = buildRDSList(dbs, matcher)
-		}
-	case KindS3:
-		client, errClient := s.factory.S3(ctx, profile, region)
-		if errClient != nil {
-			return nil, errClient
-		}
-		var buckets []models.S3Bucket
-		start := time.Now()
-		buckets, err = s.s3Repo.ListBuckets(ctx, client)
-		s.observe(ctx, "s3", "ListBuckets", start, err)
-		if err == nil {
-			items, details = buildS3List(buckets, matcher)
-		}
-	case KindLambda:
-		client, errClient := s.factory.Lambda(ctx, profile, region)
-		if errClient != nil {
-			return nil, errClient
-		}
-		var fns []models.LambdaFunction
-		start := time.Now()
-		fns, err = s.lambdaRepo.ListFunctions(ctx, client, nil)
-		s.observe(ctx, "lambda", "ListFunctions", start, err)
-		if err == nil {
-			items, details = buildLambdaList(fns, matcher)
-		}
-	case KindRoute53:
-		client, errClient := s.factory.Route53(ctx, profile, region)
-		if errClient != nil {
-			return nil, errClient
-		}
-		var zones []models.Route53HostedZone
-		start := time.Now()
-		zones, err = s.route53Repo.ListHostedZones(ctx, client)
-		s.observe(ctx, "route53", "ListHostedZones", start, err)
-		if err == nil {
-			items, details = buildRoute53ZoneList(zones, matcher)
-		}
-	case KindRoute53Records:
-		if s.currentZoneID == "" {
-			return nil, fmt.Errorf("no hosted zone selected")
-		}
-		client, errClient := s.factory.Route53(ctx, profile, region)
-		if errClient != nil {
-			return nil, errClient
-		}
-		var records []models.Route53Record
-		start := time.Now()
-		records, err = s.route53Repo.ListRecords(ctx, client, s.currentZoneID)
-		s.observe(ctx, "route53", "ListResourceRecordSets", start, err)
-		if err == nil {
-			items, details = buildRoute53RecordList(records, s.currentZoneName, matcher)
+	s.storeList(key, items, details, next)
+	if def.Enrich != nil {
+		go s.enrich(ctx, def, key, items)
+	}
+	return filterItems(def, items, matcher), nil
+}
+
+// LoadMore 取得分頁清單的下一頁並附加到快取，回傳是否還有下一頁。
+// 資源類型不分頁或已取完時直接回傳 false。
+func (s *Service) LoadMore(ctx context.Context, kind Kind) (bool, error) {
+	if s.factory == nil {
+		return false, errors.New("aws client factory is nil")
+	}
+	if s.state == nil {
+		return false, errors.New("state store is nil")
+	}
+
+	def, ok := s.registry.Lookup(kind)
+	if !ok {
+		return false, fmt.Errorf("unknown resource kind: %s", kind)
+	}
+	if def.Page == nil {
+		return false, nil
+	}
+	key := s.cacheKey(def)
+	token := s.nextToken(key)
+	if token == "" {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	page, err := def.Page(ctx, s, token)
+	if err != nil {
+		return false, err
+	}
+	return s.appendPage(key, token, page), nil
+}
+
+// LoadAll 持續取得下一頁直到取完或 ctx 取消，每取得一頁呼叫 onPage（可為 nil）。
+func (s *Service) LoadAll(ctx context.Context, kind Kind, onPage func()) error {
+	for {
+		more, err := s.LoadMore(ctx, kind)
+		if err != nil {
+			return err
 		}
-	case KindS3Objects:
-		if s.currentBucket == "" {
-			return nil, fmt.Errorf("no bucket selected")
+		if onPage != nil {
+			onPage()
 		}
-		client, errClient := s.factory.S3(ctx, profile, region)
-		if errClient != nil {
-			return nil, errClient
+		if !more {
+			return nil
 		}
-		var objects []models.S3Object
-		start := time.Now()
-		objects, err = s.s3Repo.ListObjects(ctx, client, s.currentBucket, s.currentPrefix)
-		s.observe(ctx, "s3", "ListObjectsV2", start, err)
-		if err == nil {
-			items, details = buildS3ObjectList(objects, s.currentBucket, s.currentPrefix, matcher)
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-	default:
-		return nil, fmt.Errorf("unknown resource kind: %s", kind)
 	}
+}
 
-	if err != nil {
-		return nil, err
+// Detail 取得指定資源的詳細資訊。
+// 資源類型支援 Describe 時，會以單一資源查詢取得完整詳情並只更新該 ID 的快取；
+// 否則使用清單快取，快取不存在時才重新列出該類型。
+func (s *Service) Detail(ctx context.Context, kind Kind, id string) (models.DetailView, error) {
+	if s.factory == nil {
+		return models.DetailView{}, errors.New("aws client factory is nil")
+	}
+	if s.state == nil {
+		return models.DetailView{}, errors.New("state store is nil")
 	}
 
-	s.storeDetails(kind, details)
-	return items, nil
-}
+	def, ok := s.registry.Lookup(kind)
+	if !ok {
+		return models.DetailView{}, fmt.Errorf("unknown resource kind: %s", kind)
+	}
+	key := s.cacheKey(def)
 
-// Detail 取得指定資源的詳細資訊；若快取不存在會重新查詢。
-func (s *Service) Detail(ctx context.Context, kind Kind, id string) (models.DetailView, error) {
-	if detail, ok := s.getDetail(kind, id); ok {
-		return detail, nil
+	cached, full, found := s.getDetail(key, id)
+	if found && full {
+		return cached, nil
 	}
-	_, err := s.ListItems(ctx, kind, search.NewMatcher(""))
-	if err != nil {
+
+	if def.Describe != nil {
+		describeCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		detail, err := def.Describe(describeCtx, s, id)
+		cancel()
+		if err == nil {
+			s.storeDetail(key, id, detail)
+			return detail, nil
+		}
+		if !found {
+			return models.DetailView{}, err
+		}
+		// 單筆查詢失敗時退回清單層級的詳情，並標示錯誤
+		return withDetailError(cached, err), nil
+	}
+
+	if found {
+		return cached, nil
+	}
+	if _, err := s.ListItems(ctx, kind, search.NewMatcher("")); err != nil {
 		return models.DetailView{}, err
 	}
-	if detail, ok := s.getDetail(kind, id); ok {
+	if detail, _, ok := s.getDetail(key, id); ok {
 		return detail, nil
 	}
 	return models.DetailView{}, fmt.Errorf("resource %s not found", id)
@@ -231,22 +263,31 @@ func (s *Service) GetMetrics(ctx context.Context, kind Kind, resourceID string,
 		return nil, errors.New("aws client factory is nil")
 	}
 
+	def, ok := s.registry.Lookup(kind)
+	if !ok || def.Metrics == nil {
+		return nil, nil
+	}
+	queries := def.Metrics(resourceID)
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
 	profile := s.state.Profile()
 	region := s.state.Region()
+	if def.Region != nil {
+		if resourceRegion := def.Region(s, resourceID); resourceRegion != "" {
+			region = resourceRegion
+		}
+	}
 
 	client, err := s.factory.CloudWatch(ctx, profile, region)
 	if err != nil {
 		return nil, fmt.Errorf("create cloudwatch client: %w", err)
 	}
 
-	queries := metrics.DefaultQueries(metrics.ResourceKind(kind), resourceID)
-	if len(queries) == 0 {
-		return nil, nil
-	}
-
 	fetcher := metrics.NewFetcher(client)
 	startT := time.Now()
 	result, err := fetcher.Fetch(ctx, metrics.Options{
@@ -268,7 +309,11 @@ func (s *Service) GetLogs(ctx context.Context, kind Kind, resourceID string, sta
 		return logs.Page{}, errors.New("aws client factory is nil")
 	}
 
-	logGroup := logs.DeriveLogGroup(logs.ResourceKind(kind), resourceID)
+	def, ok := s.registry.Lookup(kind)
+	if !ok || def.LogGroup == nil {
+		return logs.Page{}, nil
+	}
+	logGroup := def.LogGroup(resourceID)
 	if logGroup == "" {
 		return logs.Page{}, nil
 	}
@@ -299,178 +344,15 @@ func (s *Service) GetLogs(ctx context.Context, kind Kind, resourceID string, sta
 	return result, nil
 }
 
-func (s *Service) storeDetails(kind Kind, details map[string]models.DetailView) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.cache[kind] = details
-}
-
-func (s *Service) getDetail(kind Kind, id string) (models.DetailView, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	if detailMap, ok := s.cache[kind]; ok {
-		detail, exists := detailMap[id]
-		return detail, exists
-	}
-	return models.DetailView{}, false
-}
-
-func buildEC2List(instances []models.EC2Instance, matcher search.Matcher) ([]models.ListItem, map[string]models.DetailView) {
-	items := make([]models.ListItem, 0, len(instances))
-	details := make(map[string]models.DetailView, len(instances))
-
-	for _, inst := range instances {
-		if !matcher.Match(inst.Name + inst.ID) {
-			continue
-		}
-		items = append(items, models.ListItem{
-			ID:     inst.ID,
-			Name:   fallback(inst.Name, inst.ID),
-			Type:   "EC2",
-			Status: inst.State,
-			Region: inst.AvailabilityZone,
-			Tags:   inst.Tags,
-			Metadata: map[string]string{
-				"type": inst.InstanceType,
-			},
-		})
-		details[inst.ID] = models.DetailView{
-			Overview: map[string]string{
-				"Instance ID": inst.ID,
-				"Name":        inst.Name,
-				"Type":        inst.InstanceType,
-				"State":       inst.State,
-				"Private IP":  inst.PrivateIP,
-				"Public IP":   inst.PublicIP,
-				"VPC":         inst.VpcID,
-				"Subnet":      inst.SubnetID,
-				"IAM Role":    inst.IAMRole,
-			},
-			Relations: map[string][]string{
-				"Security Groups": inst.SecurityGroups,
-				"EBS Volumes":     volumeIDs(inst.Volumes),
-			},
-			Tags: inst.Tags,
-		}
-	}
-	return items, details
-}
-
-func buildRDSList(instances []models.RDSInstance, matcher search.Matcher) ([]models.ListItem, map[string]models.DetailView) {
-	items := make([]models.ListItem, 0, len(instances))
-	details := make(map[string]models.DetailView, len(instances))
-
-	for _, inst := range instances {
-		if !matcher.Match(inst.ID + inst.Engine) {
-			continue
-		}
-		items = append(items, models.ListItem{
-			ID:     inst.ID,
-			Name:   inst.ID,
-			Type:   "RDS",
-			Status: inst.Engine,
-			Tags:   inst.Tags,
-			Metadata: map[string]string{
-				"endpoint": inst.Endpoint,
-			},
-		})
-		details[inst.ID] = models.DetailView{
-			Overview: map[string]string{
-				"DB Instance": inst.ID,
-				"Engine":      inst.Engine,
-				"Version":     inst.EngineVersion,
-				"Multi-AZ":    fmt.Sprintf("%t", inst.MultiAZ),
-				"Endpoint":    inst.Endpoint,
-				"SubnetGroup": inst.SubnetGroup,
-			},
-			Relations: map[string][]string{
-				"Parameter Groups": inst.ParameterGroup,
-				"Security Groups":  inst.SecurityGroups,
-			},
-			Tags: inst.Tags,
-		}
-	}
-	return items, details
-}
-
-func buildS3List(buckets []models.S3Bucket, matcher search.Matcher) ([]models.ListItem, map[string]models.DetailView) {
-	items := make([]models.ListItem, 0, len(buckets))
-	details := make(map[string]models.DetailView, len(buckets))
-
-	for _, bucket := range buckets {
-		if !matcher.Match(bucket.Name) {
-			continue
-		}
-		items = append(items, models.ListItem{
-			ID:     bucket.Name,
-			Name:   bucket.Name,
-			Type:   "S3",
-			Status: bucket.Versioning,
-			Region: bucket.Region,
-			Tags:   bucket.Tags,
-		})
-		details[bucket.Name] = models.DetailView{
-			Overview: map[string]string{
-				"Bucket":     bucket.Name,
-				"Region":     bucket.Region,
-				"Versioning": bucket.Versioning,
-				"Encryption": bucket.Encryption,
-			},
-			Relations: map[string][]string{
-				"Policies":  filterEmpty(bucket.Policy),
-				"Lifecycle": filterEmpty(bucket.Lifecycle),
-			},
-			Tags: bucket.Tags,
-		}
-	}
-	return items, details
-}
-
-func buildLambdaList(fns []models.LambdaFunction, matcher search.Matcher) ([]models.ListItem, map[string]models.DetailView) {
-	items := make([]models.ListItem, 0, len(fns))
-	details := make(map[string]models.DetailView, len(fns))
-
-	for _, fn := range fns {
-		if !matcher.Match(fn.Name) {
-			continue
-		}
-		items = append(items, models.ListItem{
-			ID:     fn.ARN,
-			Name:   fn.Name,
-			Type:   "Lambda",
-			Status: fn.Runtime,
-			Tags:   fn.Tags,
-			Metadata: map[string]string{
-				"memory": fmt.Sprintf("%d MB", fn.MemoryMB),
-			},
-		})
-		details[fn.ARN] = models.DetailView{
-			Overview: map[string]string{
-				"Function":   fn.Name,
-				"Runtime":    fn.Runtime,
-				"Memory":     fmt.Sprintf("%d MB", fn.MemoryMB),
-				"Timeout":    fmt.Sprintf("%d s", fn.TimeoutSec),
-				"Role":       fn.Role,
-				"LastChange": fn.LastModified,
-			},
-			Relations: map[string][]string{
-				"Environment": flattenEnv(fn.EnvVars),
-				"Triggers":    fn.Triggers,
-			},
-			Tags: fn.Tags,
-		}
-	}
-	return items, details
-}
-
-func volumeIDs(vols []models.EBSVolume) []string {
-	result := make([]string, 0, len(vols))
-	for _, vol := range vols {
-		if vol.ID != "" {
-			result = append(result, fmt.Sprintf("%s (%s)", vol.ID, vol.State))
-		}
+// withDetailError 複製詳情並在 Overview 加入錯誤訊息。
+func withDetailError(detail models.DetailView, err error) models.DetailView {
+	overview := make(map[string]string, len(detail.Overview)+1)
+	for k, v := range detail.Overview {
+		overview[k] = v
 	}
-	return result
+	overview["Error"] = err.Error()
+	detail.Overview = overview
+	return detail
 }
 
 func filterEmpty(value string) []string {
@@ -506,6 +388,19 @@ func fallback(values ...string) string {
 	return ""
 }
 
+// SetPageSize 設定分頁清單每頁筆數；小於 1 時忽略。
+func (s *Service) SetPageSize(size int) {
+	if size > 0 {
+		s.pageSize = int32(size)
+	}
+}
+
+// SetS3Options 設定 bucket 設定查詢的平行上限，以及是否先列出名稱再於背景補齊（lazy）。
+func (s *Service) SetS3Options(concurrency int, lazy bool) {
+	s.s3Repo.SetConcurrency(concurrency)
+	s.s3LazyEnrich = lazy
+}
+
 // SetCurrentBucket 設定目前瀏覽的 S3 bucket。
 func (s *Service) SetCurrentBucket(bucket string) {
 	s.currentBucket = bucket
@@ -567,144 +462,19 @@ func (s *Service) ClearCurrentZone() {
 	s.currentZoneName = ""
 }
 
-func buildRoute53ZoneList(zones []models.Route53HostedZone, matcher search.Matcher) ([]models.ListItem, map[string]models.DetailView) {
-	items := make([]models.ListItem, 0, len(zones))
-	details := make(map[string]models.DetailView, len(zones))
-
-	for _, zone := range zones {
-		if !matcher.Match(zone.Name + zone.ID) {
-			continue
-		}
-		zoneType := "Public"
-		if zone.IsPrivate {
-			zoneType = "Private"
-		}
-		items = append(items, models.ListItem{
-			ID:     zone.ID,
-			Name:   zone.Name,
-			Type:   "Route53",
-			Status: zoneType,
-			Metadata: map[string]string{
-				"records": fmt.Sprintf("%d", zone.RecordCount),
-			},
-		})
-		details[zone.ID] = models.DetailView{
-			Overview: map[string]string{
-				"Zone ID":      zone.ID,
-				"Name":         zone.Name,
-				"Type":         zoneType,
-				"Record Count": fmt.Sprintf("%d", zone.RecordCount),
-				"Comment":      zone.Comment,
-			},
-		}
-	}
-	return items, details
+// SetCurrentFunction 設定 Lambda 子清單所屬的函式。
+func (s *Service) SetCurrentFunction(arn, name string) {
+	s.currentFunction = arn
+	s.currentFunctionName = name
 }
 
-func buildRoute53RecordList(records []models.Route53Record, zoneName string, matcher search.Matcher) ([]models.ListItem, map[string]models.DetailView) {
-	items := make([]models.ListItem, 0, len(records))
-	details := make(map[string]models.DetailView, len(records))
-
-	for _, record := range records {
-		if !matcher.Match(record.Name + record.Type) {
-			continue
-		}
-		id := record.Name + "_" + record.Type
-		value := ""
-		if record.AliasTarget != "" {
-			value = "ALIAS → " + record.AliasTarget
-		} else if len(record.Values) > 0 {
-			value = record.Values[0]
-			if len(record.Values) > 1 {
-				value += fmt.Sprintf(" (+%d)", len(record.Values)-1)
-			}
-		}
-		items = append(items, models.ListItem{
-			ID:     id,
-			Name:   record.Name,
-			Type:   record.Type,
-			Status: value,
-			Metadata: map[string]string{
-				"ttl": fmt.Sprintf("%d", record.TTL),
-			},
-		})
-		details[id] = models.DetailView{
-			Overview: map[string]string{
-				"Name": record.Name,
-				"Type": record.Type,
-				"TTL":  fmt.Sprintf("%d", record.TTL),
-				"Zone": zoneName,
-			},
-			Relations: map[string][]string{
-				"Values": record.Values,
-			},
-		}
-		if record.AliasTarget != "" {
-			details[id].Overview["Alias"] = record.AliasTarget
-		}
-	}
-	return items, details
-}
-
-func buildS3ObjectList(objects []models.S3Object, bucket, prefix string, matcher search.Matcher) ([]models.ListItem, map[string]models.DetailView) {
-	items := make([]models.ListItem, 0, len(objects))
-	details := make(map[string]models.DetailView, len(objects))
-
-	for _, obj := range objects {
-		// 取得顯示名稱（移除 prefix）
-		displayName := strings.TrimPrefix(obj.Key, prefix)
-		if obj.IsDirectory {
-			displayName = strings.TrimSuffix(displayName, "/") + "/"
-		}
-
-		if !matcher.Match(displayName) {
-			continue
-		}
-
-		objType := "File"
-		status := formatSize(obj.Size)
-		if obj.IsDirectory {
-			objType = "Dir"
-			status = ""
-		}
-
-		items = append(items, models.ListItem{
-			ID:     obj.Key,
-			Name:   displayName,
-			Type:   objType,
-			Status: status,
-			Region: obj.LastModified,
-			Metadata: map[string]string{
-				"storage": obj.StorageClass,
-				"bucket":  bucket,
-			},
-		})
-
-		details[obj.Key] = models.DetailView{
-			Overview: map[string]string{
-				"Key":           obj.Key,
-				"Bucket":        bucket,
-				"Size":          formatSize(obj.Size),
-				"Last Modified": obj.LastModified,
-				"Storage Class": obj.StorageClass,
-			},
-		}
-	}
-	return items, details
+// CurrentFunctionName 回傳目前函式的名稱。
+func (s *Service) CurrentFunctionName() string {
+	return s.currentFunctionName
 }
 
-func formatSize(bytes int64) string {
-	if bytes == 0 {
-		return "0 B"
-	}
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+// ClearCurrentFunction 清除目前的函式，回到函式列表。
+func (s *Service) ClearCurrentFunction() {
+	s.currentFunction = ""
+	s.currentFunctionName = ""
 }
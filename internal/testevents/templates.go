@@ -0,0 +1,116 @@
+package testevents
+
+// Template 為內建的事件範本。
+type Template struct {
+	Name    string
+	Payload string
+}
+
+// Templates 回傳內建範本：API Gateway REST proxy、SQS、S3 ObjectCreated 與 EventBridge 排程事件。
+func Templates() []Template {
+	return []Template{
+		{Name: "API Gateway", Payload: apiGatewayEvent},
+		{Name: "SQS", Payload: sqsEvent},
+		{Name: "S3", Payload: s3Event},
+		{Name: "EventBridge", Payload: eventBridgeEvent},
+	}
+}
+
+const apiGatewayEvent = `{
+  "resource": "/items/{id}",
+  "path": "/items/42",
+  "httpMethod": "GET",
+  "headers": {
+    "Accept": "application/json",
+    "Host": "example.execute-api.us-east-1.amazonaws.com"
+  },
+  "queryStringParameters": {
+    "verbose": "true"
+  },
+  "pathParameters": {
+    "id": "42"
+  },
+  "stageVariables": null,
+  "requestContext": {
+    "accountId": "123456789012",
+    "resourceId": "abc123",
+    "stage": "prod",
+    "requestId": "c6af9ac6-7b61-11e6-9a41-93e8deadbeef",
+    "identity": {
+      "sourceIp": "203.0.113.10",
+      "userAgent": "curl/8.0"
+    },
+    "resourcePath": "/items/{id}",
+    "httpMethod": "GET",
+    "apiId": "example"
+  },
+  "body": null,
+  "isBase64Encoded": false
+}`
+
+const sqsEvent = `{
+  "Records": [
+    {
+      "messageId": "059f36b4-87a3-44ab-83d2-661975830a7d",
+      "receiptHandle": "AQEBwJnKyrHigUMZj6rYigCgxlaS3SLy0a",
+      "body": "{\"orderId\": \"1234\"}",
+      "attributes": {
+        "ApproximateReceiveCount": "1",
+        "SentTimestamp": "1545082649183",
+        "SenderId": "AIDAIENQZJOLO23YVJ4VO",
+        "ApproximateFirstReceiveTimestamp": "1545082649185"
+      },
+      "messageAttributes": {},
+      "md5OfBody": "e4e68fb7bd0e697a0ae8f1bb342846b3",
+      "eventSource": "aws:sqs",
+      "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:my-queue",
+      "awsRegion": "us-east-1"
+    }
+  ]
+}`
+
+const s3Event = `{
+  "Records": [
+    {
+      "eventVersion": "2.1",
+      "eventSource": "aws:s3",
+      "awsRegion": "us-east-1",
+      "eventTime": "2024-01-01T00:00:00.000Z",
+      "eventName": "ObjectCreated:Put",
+      "userIdentity": {
+        "principalId": "EXAMPLE"
+      },
+      "s3": {
+        "s3SchemaVersion": "1.0",
+        "configurationId": "example-trigger",
+        "bucket": {
+          "name": "example-bucket",
+          "ownerIdentity": {
+            "principalId": "EXAMPLE"
+          },
+          "arn": "arn:aws:s3:::example-bucket"
+        },
+        "object": {
+          "key": "uploads/test.json",
+          "size": 1024,
+          "eTag": "0123456789abcdef0123456789abcdef",
+          "sequencer": "0A1B2C3D4E5F678901"
+        }
+      }
+    }
+  ]
+}`
+
+const eventBridgeEvent = `{
+  "version": "0",
+  "id": "53dc4d37-cffa-4f76-80c9-8b7d4a4d2eaa",
+  "detail-type": "Scheduled Event",
+  "source": "aws.events",
+  "account": "123456789012",
+  "time": "2024-01-01T00:00:00Z",
+  "region": "us-east-1",
+  "resources": [
+    "arn:aws:events:us-east-1:123456789012:rule/example-schedule"
+  ],
+  "detail": {}
+}`
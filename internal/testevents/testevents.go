@@ -0,0 +1,141 @@
+// Package testevents 管理 Lambda 呼叫工作台的測試事件：依函式儲存於設定目錄的具名 JSON payload，
+// 以及常見事件來源（API Gateway、SQS、S3、EventBridge）的範本。
+//
+// 事件存放於 <dir>/<account>/<region>/<function>/<name>.json；以函式名稱（非 ARN）指定時省略帳號與區域，
+// ARN 上的版本或 alias 會被忽略，同一函式的所有版本共用測試事件。
+package testevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const fileExt = ".json"
+
+var (
+	namePattern     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+	functionPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,140}$`)
+	segmentPattern  = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+)
+
+// Store 讀寫單一目錄下的測試事件。
+type Store struct {
+	dir string
+}
+
+// NewStore 建立以 dir 為根目錄的事件儲存；dir 為空字串時所有操作都會回傳錯誤。
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// ValidateName 檢查事件名稱：1-64 個英數字、點、底線或連字號，且不可以點開頭。
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid event name %q: use 1-64 letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// List 依名稱排序回傳函式已儲存的事件；尚未儲存過時回傳空清單。
+func (s *Store) List(function string) ([]string, error) {
+	dir, err := s.functionDir(function)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list test events: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), fileExt)
+		if !ok || entry.IsDir() || ValidateName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// Load 讀取具名事件的 payload。
+func (s *Store) Load(function, name string) (string, error) {
+	path, err := s.eventPath(function, name)
+	if err != nil {
+		return "", err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("load test event %s: %w", name, err)
+	}
+	return string(raw), nil
+}
+
+// Save 儲存（或覆寫）具名事件；payload 必須是合法的 JSON。
+func (s *Store) Save(function, name, payload string) error {
+	path, err := s.eventPath(function, name)
+	if err != nil {
+		return err
+	}
+	if !json.Valid([]byte(payload)) {
+		return fmt.Errorf("test event %s is not valid JSON", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("save test event %s: %w", name, err)
+	}
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(payload)+"\n"), 0o600); err != nil {
+		return fmt.Errorf("save test event %s: %w", name, err)
+	}
+	return nil
+}
+
+// Delete 刪除具名事件。
+func (s *Store) Delete(function, name string) error {
+	path, err := s.eventPath(function, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("delete test event %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *Store) eventPath(function, name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	dir, err := s.functionDir(function)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+fileExt), nil
+}
+
+// functionDir 將函式 ARN（arn:aws:lambda:<region>:<account>:function:<name>[:<qualifier>]）或名稱轉為事件目錄。
+func (s *Store) functionDir(function string) (string, error) {
+	if s.dir == "" {
+		return "", errors.New("config directory is unavailable")
+	}
+	parts := []string{function}
+	if strings.HasPrefix(function, "arn:") {
+		fields := strings.Split(function, ":")
+		if len(fields) < 7 || fields[5] != "function" || !segmentPattern.MatchString(fields[3]) || !segmentPattern.MatchString(fields[4]) {
+			return "", fmt.Errorf("invalid function ARN %q", function)
+		}
+		parts = []string{fields[4], fields[3], fields[6]}
+	}
+	if !functionPattern.MatchString(parts[len(parts)-1]) {
+		return "", fmt.Errorf("invalid function name %q", function)
+	}
+	return filepath.Join(append([]string{s.dir}, parts...)...), nil
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/vincent119/awsGUITools/internal/i18n"
 	"github.com/vincent119/awsGUITools/internal/models"
-	"github.com/vincent119/awsGUITools/internal/ui/modals"
 )
 
 // View 負責呈現資源詳情。
@@ -45,14 +44,6 @@ func (v *View) SetOnAction(fn func(resourceType, resourceID, action string)) {
 	v.onAction = fn
 }
 
-// AvailableActions 回傳目前資源可用的操作。
-func (v *View) AvailableActions() []string {
-	if v.currentItem == nil {
-		return nil
-	}
-	return modals.AvailableActions(v.currentItem.Type)
-}
-
 func (v *View) handleInput(event *tcell.EventKey) *tcell.EventKey {
 	// 'a' 鍵觸發操作（由外層 UI 處理彈窗）
 	return event
@@ -87,7 +78,16 @@ func (v *View) SetDetail(detail models.DetailView) {
 			b.WriteString(" - ")
 			b.WriteString(k)
 			b.WriteString(": ")
-			b.WriteString(strings.Join(list, ", "))
+			if len(list) == 1 || !multiline(list) {
+				b.WriteString(strings.Join(list, ", "))
+				b.WriteString("\n")
+				continue
+			}
+			// 較長的項目（例如 lifecycle 規則）逐行列出，避免與項目內的分隔符號混淆
+			for _, entry := range list {
+				b.WriteString("\n   · ")
+				b.WriteString(entry)
+			}
 			b.WriteString("\n")
 		}
 	}
@@ -105,3 +105,13 @@ func (v *View) SetDetail(detail models.DetailView) {
 
 	v.text.SetText(b.String())
 }
+
+// multiline 判斷清單項目是否含有空白（描述性文字而非 ID），需要逐行顯示。
+func multiline(list []string) bool {
+	for _, entry := range list {
+		if strings.Contains(entry, " ") {
+			return true
+		}
+	}
+	return false
+}
@@ -58,7 +58,7 @@ func (t *MetricsTab) SetData(data map[string]metrics.Series, start, end time.Tim
 		}
 		// 顯示簡易 sparkline（文字版）
 		b.WriteString("  ")
-		b.WriteString(sparkline(series.Points))
+		b.WriteString(Sparkline(series.Points))
 		b.WriteString("\n")
 		// 顯示最新值
 		latest := series.Points[len(series.Points)-1]
@@ -69,8 +69,8 @@ func (t *MetricsTab) SetData(data map[string]metrics.Series, start, end time.Tim
 	t.text.SetText(b.String())
 }
 
-// sparkline 產生簡易文字 sparkline。
-func sparkline(points []metrics.Point) string {
+// Sparkline 產生簡易文字 sparkline（依序列的最小值到最大值縮放）。
+func Sparkline(points []metrics.Point) string {
 	if len(points) == 0 {
 		return ""
 	}
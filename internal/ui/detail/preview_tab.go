@@ -0,0 +1,102 @@
+package detail
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/preview"
+)
+
+// PreviewTab 顯示 S3 物件的 metadata 與內容預覽。
+type PreviewTab struct {
+	text *tview.TextView
+}
+
+// NewPreviewTab 建立預覽 tab。
+func NewPreviewTab() *PreviewTab {
+	tv := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true)
+	tv.SetBorder(true).SetTitle(i18n.T("preview.title"))
+	return &PreviewTab{text: tv}
+}
+
+// Primitive 回傳 tview 元件。
+func (t *PreviewTab) Primitive() *tview.TextView {
+	return t.text
+}
+
+// RefreshLabels 以目前語言更新標題。
+func (t *PreviewTab) RefreshLabels() {
+	t.text.SetTitle(i18n.T("preview.title"))
+}
+
+// SetLoading 顯示載入中。
+func (t *PreviewTab) SetLoading(key string) {
+	t.text.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(i18n.Tf("preview.loading", key))))
+	t.text.ScrollToBeginning()
+}
+
+// SetError 顯示錯誤。
+func (t *PreviewTab) SetError(err error) {
+	t.text.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+}
+
+// SetPreview 顯示 HeadObject 資訊與預覽內容。
+func (t *PreviewTab) SetPreview(info models.S3ObjectInfo, result preview.Result) {
+	var b strings.Builder
+	b.WriteString("[::b]Metadata[::-]\n")
+	writeField(&b, "Key", info.Key)
+	writeField(&b, "Content-Type", info.ContentType)
+	writeField(&b, "Content-Encoding", info.ContentEncoding)
+	writeField(&b, "Size", fmt.Sprintf("%d bytes", info.Size))
+	writeField(&b, "Last Modified", info.LastModified)
+	writeField(&b, "Storage Class", info.StorageClass)
+	writeField(&b, "ETag", info.ETag)
+	writeField(&b, "Version", info.VersionID)
+	writeField(&b, "Encryption", info.Encryption)
+	keys := make([]string, 0, len(info.Metadata))
+	for k := range info.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeField(&b, "x-amz-meta-"+k, info.Metadata[k])
+	}
+
+	fmt.Fprintf(&b, "\n[::b]%s[::-] [gray](%s", i18n.T("preview.content"), result.Format)
+	if result.Decompressed {
+		b.WriteString(", gunzip")
+	}
+	b.WriteString(")[-]\n")
+	if result.BrokenJSON {
+		fmt.Fprintf(&b, "[yellow]%s[-]\n", i18n.T("preview.broken_json"))
+	}
+	if result.Format == preview.FormatEmpty {
+		fmt.Fprintf(&b, "[gray]%s[-]\n", i18n.T("preview.empty"))
+	} else {
+		b.WriteString(tview.Escape(result.Text))
+		if !strings.HasSuffix(result.Text, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	if result.Truncated {
+		fmt.Fprintf(&b, "[gray]%s[-]\n", i18n.T("preview.truncated"))
+	}
+
+	t.text.SetText(b.String())
+	t.text.ScrollToBeginning()
+}
+
+func writeField(b *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, " - %s: %s\n", name, tview.Escape(value))
+}
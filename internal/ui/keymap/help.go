@@ -3,6 +3,7 @@ package keymap
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/vincent119/awsGUITools/internal/i18n"
 )
@@ -12,7 +13,7 @@ const HelpText = `
 [::b]Keyboard Shortcuts[::-]
  1-5     : Switch resource type (1=EC2, 2=RDS, 3=S3, 4=Lambda, 5=Route53)
  /       : Focus search bar
- Enter   : Select/enter bucket/zone
+ Enter   : Select/enter bucket/zone/Lambda function
  Backspace: Go back to parent
  Esc     : Exit to main list
  p       : Select AWS Profile (Region auto-switches)
@@ -20,6 +21,29 @@ const HelpText = `
  t       : Toggle theme (dark/light/high-contrast)
  l       : Toggle language (English/中文)
  g       : Refresh current resource list
+ n       : Load next page (S3 objects)
+ G       : Load all pages and jump to end
+ d       : Download selected object or folder, or a Lambda code package
+ u       : Upload file or directory into current prefix
+ x       : Cancel running transfer
+ v       : Preview selected object, or browse a Lambda code package
+ V       : Show versions of selected object
+ Space   : Mark/unmark object for batch delete
+ D       : Delete marked objects, or the selected object/folder (stage Route53 record deletion)
+ U       : Generate presigned GET/PUT URL for an object
+ A       : Analyze storage usage of bucket or prefix
+ R       : Edit bucket lifecycle/CORS rules as YAML
+ c       : Create a Route53 record (staged) or publish a Lambda version
+ E       : Edit the selected Route53 record (staged), Lambda configuration or alias routing
+ S       : Review and submit staged Route53 record changes
+ e       : Export usage analysis, or the current hosted zone as a zone file
+ i       : Import a BIND zone file into the current hosted zone
+ H       : Show Route53 health checks and the records that use them
+ P       : Show VPCs of a private zone (c: associate, D: disassociate)
+ F       : Show Resolver endpoints, rules forwarding the zone and query logging
+ o       : Show triggers of a Lambda function (a: enable/disable mapping)
+ I       : Invoke a Lambda function, version or alias with saved test events
+ C       : Show Lambda concurrency settings and throttles (r: reserved, p: provisioned)
  ?       : Show this help
  q       : Quit application
 
@@ -29,43 +53,64 @@ const HelpText = `
  Esc/q : Cancel
 `
 
+// helpKeys 為主畫面快捷鍵說明的 i18n key，依顯示順序排列。
+var helpKeys = []string{
+	"help.resource_switch",
+	"help.search",
+	"help.enter",
+	"help.backspace",
+	"help.escape",
+	"help.profile",
+	"help.action",
+	"help.theme",
+	"help.language",
+	"help.refresh",
+	"help.load_more",
+	"help.load_all",
+	"help.download",
+	"help.upload",
+	"help.cancel_transfer",
+	"help.preview",
+	"help.versions",
+	"help.mark",
+	"help.delete",
+	"help.presign",
+	"help.analyze",
+	"help.export_usage",
+	"help.rules",
+	"help.record_create",
+	"help.record_edit",
+	"help.record_submit",
+	"help.zone_import",
+	"help.health_checks",
+	"help.zone_vpcs",
+	"help.resolver",
+	"help.lambda_triggers",
+	"help.lambda_invoke",
+	"help.lambda_concurrency",
+	"help.help",
+	"help.quit",
+}
+
+// pickerHelpKeys 為 Profile 選擇器快捷鍵說明的 i18n key。
+var pickerHelpKeys = []string{
+	"help.picker_nav",
+	"help.picker_select",
+	"help.picker_cancel",
+}
+
 // GetHelpText returns the help text in the current language.
 func GetHelpText() string {
-	return fmt.Sprintf(`[::b]%s[::-]
- %s
- %s
- %s
- %s
- %s
- %s
- %s
- %s
- %s
- %s
- %s
- %s
+	var b strings.Builder
+	writeHelpSection(&b, i18n.T("help.title"), helpKeys)
+	b.WriteString("\n")
+	writeHelpSection(&b, i18n.T("help.picker_title"), pickerHelpKeys)
+	return b.String()
+}
 
-[::b]%s[::-]
- %s
- %s
- %s
-`,
-		i18n.T("help.title"),
-		i18n.T("help.resource_switch"),
-		i18n.T("help.search"),
-		i18n.T("help.enter"),
-		i18n.T("help.backspace"),
-		i18n.T("help.escape"),
-		i18n.T("help.profile"),
-		i18n.T("help.action"),
-		i18n.T("help.theme"),
-		i18n.T("help.language"),
-		i18n.T("help.refresh"),
-		i18n.T("help.help"),
-		i18n.T("help.quit"),
-		i18n.T("help.picker_title"),
-		i18n.T("help.picker_nav"),
-		i18n.T("help.picker_select"),
-		i18n.T("help.picker_cancel"),
-	)
+func writeHelpSection(b *strings.Builder, title string, keys []string) {
+	fmt.Fprintf(b, "[::b]%s[::-]\n", title)
+	for _, key := range keys {
+		fmt.Fprintf(b, " %s\n", i18n.T(key))
+	}
 }
@@ -15,6 +15,29 @@ func Entries() []Entry {
 		{Key: "4", Description: "切換 Lambda 清單"},
 		{Key: "/", Description: "開啟搜尋輸入"},
 		{Key: "g", Description: "重新整理"},
+		{Key: "n", Description: "載入下一頁"},
+		{Key: "G", Description: "載入全部分頁並跳到最後"},
+		{Key: "d", Description: "下載 S3 物件或目錄 / Lambda 程式碼套件"},
+		{Key: "u", Description: "上傳檔案或目錄"},
+		{Key: "x", Description: "取消傳輸"},
+		{Key: "v", Description: "預覽 S3 物件 / 瀏覽 Lambda 程式碼套件"},
+		{Key: "V", Description: "查看 S3 物件版本"},
+		{Key: "Space", Description: "標記 S3 物件"},
+		{Key: "D", Description: "刪除標記或選取的 S3 物件/目錄；暫存刪除 Route53 record"},
+		{Key: "U", Description: "產生 S3 presigned URL"},
+		{Key: "A", Description: "分析 S3 bucket/prefix 用量"},
+		{Key: "e", Description: "匯出用量分析結果或 Route53 zone file"},
+		{Key: "R", Description: "編輯 S3 lifecycle/CORS 規則"},
+		{Key: "c", Description: "新增 Route53 record / 發佈 Lambda 版本"},
+		{Key: "E", Description: "編輯 Route53 record / Lambda 設定 / alias 路由"},
+		{Key: "S", Description: "檢視並送出 Route53 record 變更"},
+		{Key: "i", Description: "匯入 Route53 zone file"},
+		{Key: "H", Description: "查看 Route53 health checks"},
+		{Key: "P", Description: "管理 private zone 的 VPC 關聯"},
+		{Key: "F", Description: "查看 Route53 Resolver endpoints 與 rules"},
+		{Key: "o", Description: "查看 Lambda 觸發來源"},
+		{Key: "I", Description: "開啟 Lambda 呼叫工作台"},
+		{Key: "C", Description: "查看與設定 Lambda 並行數"},
 		{Key: "p", Description: "切換 AWS Profile"},
 		{Key: "r", Description: "切換 Region"},
 		{Key: "t", Description: "切換主題"},
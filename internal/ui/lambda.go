@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+// openFunctionView 切換到選取 Lambda 函式的子清單（版本與 aliases、觸發來源）；子清單之間切換時沿用目前函式。
+func (r *Root) openFunctionView(kind resource.Kind) {
+	switch r.currentKind {
+	case resource.KindLambda:
+		item, ok := r.listView.CurrentItem()
+		if !ok {
+			r.setStatus("[yellow]No resource selected[-]")
+			return
+		}
+		r.service.SetCurrentFunction(item.ID, item.Name)
+	case kind:
+		return
+	default:
+		if !isFunctionView(r.currentKind) {
+			return
+		}
+	}
+	r.currentKind = kind
+	go r.reload()
+}
+
+// closeFunctionView 回到函式清單。
+func (r *Root) closeFunctionView() {
+	r.service.ClearCurrentFunction()
+	r.currentKind = resource.KindLambda
+	go r.reload()
+}
+
+// isFunctionView 判斷 kind 是否為單一函式的子清單。
+func isFunctionView(kind resource.Kind) bool {
+	return kind == resource.KindLambdaVersions || kind == resource.KindLambdaTriggers
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/preview"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+	"github.com/vincent119/awsGUITools/internal/ui/widgets"
+)
+
+// currentFunctionTarget 回傳函式清單或版本清單中選取的函式、版本或 alias。
+func (r *Root) currentFunctionTarget() (resource.FunctionTarget, bool) {
+	item, ok := r.listView.CurrentItem()
+	if !ok {
+		r.setStatus("[yellow]No resource selected[-]")
+		return resource.FunctionTarget{}, false
+	}
+	return r.service.FunctionTargetFor(r.currentKind, item)
+}
+
+// startCodeDownload 詢問本機目錄後下載選取函式（或版本、alias）的 zip 部署套件。
+func (r *Root) startCodeDownload() {
+	if r.transferBusy() {
+		return
+	}
+	target, ok := r.currentFunctionTarget()
+	if !ok {
+		return
+	}
+	r.promptPath(i18n.Tf("lambda.code_download_title", target.Label()), workingDir(), func(dir string) {
+		r.runTransfer(i18n.T("lambda.code_downloading"), func(ctx context.Context, onProgress func(resource.TransferProgress)) (resource.TransferResult, error) {
+			return r.service.DownloadLambdaCode(ctx, target, dir, onProgress)
+		}, false)
+	})
+}
+
+// startPackageBrowser 將部署套件下載到暫存目錄（狀態列顯示進度，x 取消）後開啟檔案瀏覽器；關閉時刪除暫存檔。
+func (r *Root) startPackageBrowser() {
+	if r.transferBusy() {
+		return
+	}
+	target, ok := r.currentFunctionTarget()
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+	label := i18n.T("lambda.code_downloading")
+	r.statusBar.SetProgress(label, 0, 0)
+	r.setStatus(i18n.T("transfer.cancel_hint"))
+
+	go func() {
+		defer cancel()
+		pkg, err := r.service.OpenLambdaPackage(ctx, target, func(p resource.TransferProgress) {
+			r.app.QueueUpdateDraw(func() {
+				r.statusBar.SetProgress(label, p.BytesDone, p.Bytes)
+			})
+		})
+		r.app.QueueUpdateDraw(func() {
+			r.transferCancel = nil
+			r.statusBar.ClearProgress()
+			switch {
+			case errors.Is(err, context.Canceled):
+				r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.T("transfer.canceled")))
+			case err != nil:
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			default:
+				r.setStatus("")
+				r.showPackageBrowser(pkg)
+			}
+		})
+	}()
+}
+
+// showPackageBrowser 顯示套件中的檔案；選取檔案時於右側預覽（文字、JSON 或十六進位）。
+func (r *Root) showPackageBrowser(pkg *resource.LambdaPackage) {
+	rows := make([]modals.PackageRow, len(pkg.Files))
+	for i, f := range pkg.Files {
+		rows[i] = modals.PackageRow{
+			Name:       f.Name,
+			Size:       widgets.FormatBytes(f.Size),
+			Compressed: widgets.FormatBytes(f.CompressedSize),
+		}
+	}
+	title := i18n.Tf("lambda.package_title", pkg.Name, len(pkg.Files), widgets.FormatBytes(pkg.TotalSize()), widgets.FormatBytes(pkg.Size))
+	browser := modals.NewPackageBrowser(title, rows)
+	browser.SetOnFocus(func(p tview.Primitive) { r.app.SetFocus(p) })
+	showFile := func(index int) {
+		browser.SetPreview(formatPackageFile(pkg, pkg.Files[index]))
+	}
+	browser.SetOnSelect(showFile)
+	browser.SetOnClose(func() {
+		r.pages.RemovePage("package-browser")
+		if err := pkg.Close(); err != nil {
+			r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+		}
+	})
+	if len(pkg.Files) > 0 {
+		showFile(0)
+	} else {
+		browser.SetPreview(fmt.Sprintf("[gray]%s[-]", i18n.T("lambda.package_empty")))
+	}
+	r.pages.AddAndSwitchToPage("package-browser", browser.Primitive(), true)
+}
+
+// formatPackageFile 組合檔案資訊與內容預覽；內容以 preview 依副檔名與內容判斷格式。
+func formatPackageFile(pkg *resource.LambdaPackage, file resource.PackageFile) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]%s[::-]\n", tview.Escape(file.Name))
+	fmt.Fprintf(&b, " - Size: %s (%s compressed)\n", widgets.FormatBytes(file.Size), widgets.FormatBytes(file.CompressedSize))
+	if !file.Modified.IsZero() {
+		fmt.Fprintf(&b, " - Modified: %s\n", file.Modified.Format("2006-01-02 15:04:05"))
+	}
+	b.WriteString("\n")
+
+	data, truncated, err := pkg.ReadFile(file.Name)
+	if err != nil {
+		fmt.Fprintf(&b, "[red]%s[-]\n", tview.Escape(err.Error()))
+		return b.String()
+	}
+	result := preview.Render(preview.Input{Key: file.Name, Data: data, Truncated: truncated})
+	switch result.Format {
+	case preview.FormatEmpty:
+		fmt.Fprintf(&b, "[gray]%s[-]\n", i18n.T("preview.empty"))
+	case preview.FormatHex:
+		fmt.Fprintf(&b, "[gray]%s[-]\n%s", i18n.T("lambda.package_binary"), tview.Escape(result.Text))
+	default:
+		b.WriteString(tview.Escape(result.Text))
+	}
+	if result.Truncated {
+		fmt.Fprintf(&b, "\n[gray]%s[-]\n", i18n.T("lambda.package_truncated"))
+	}
+	return b.String()
+}
@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/detail"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// startConcurrencyPanel 開啟選取函式的並行數面板：帳號上限、保留與 provisioned concurrency，
+// 以及最近 3 小時 concurrent/throttles 指標的對照；r 設定保留、p 設定 provisioned、g 重新整理。
+func (r *Root) startConcurrencyPanel() {
+	var item models.ListItem
+	if r.currentKind != resource.KindLambdaTriggers {
+		selected, ok := r.listView.CurrentItem()
+		if !ok {
+			r.setStatus("[yellow]No resource selected[-]")
+			return
+		}
+		item = selected
+	}
+	target, ok := r.service.FunctionTargetFor(r.currentKind, item)
+	if !ok {
+		return
+	}
+	// 並行數設定屬於函式本身；provisioned concurrency 的預設 qualifier 取自選取的版本或 alias
+	qualifier := target.Qualifier
+	target.Qualifier = ""
+
+	view := modals.NewTextModal(i18n.Tf("lambda.concurrency_title", target.Name), "")
+	var panel resource.ConcurrencyPanel
+	loaded := false
+	load := func() {
+		loaded = false
+		view.SetText(i18n.Tf("lambda.concurrency_loading", target.Name))
+		go func() {
+			result, err := r.service.LoadConcurrency(r.ctx, target)
+			r.app.QueueUpdateDraw(func() {
+				if err != nil {
+					view.SetText(fmt.Sprintf("[red]%s[-]\n\n[gray]%s[-]", tview.Escape(i18n.Tf("error.operation_failed", err)), i18n.T("lambda.concurrency_hint")))
+					return
+				}
+				panel, loaded = result, true
+				view.SetText(formatConcurrencyPanel(panel))
+			})
+		}()
+	}
+	view.SetOnClose(func() {
+		r.pages.RemovePage("lambda-concurrency")
+	})
+	view.SetOnRune(func(ch rune) bool {
+		switch ch {
+		case 'g':
+			load()
+		case 'r':
+			if loaded {
+				r.promptReservedConcurrency(target, panel, load)
+			}
+		case 'p':
+			if loaded {
+				r.promptProvisionedConcurrency(target, panel, qualifier, load)
+			}
+		default:
+			return false
+		}
+		return true
+	})
+	r.pages.AddAndSwitchToPage("lambda-concurrency", view.Primitive(), true)
+	load()
+}
+
+// promptReservedConcurrency 詢問新的保留並行數（空白表示移除），檢查帳號容量後確認並套用。
+func (r *Root) promptReservedConcurrency(target resource.FunctionTarget, panel resource.ConcurrencyPanel, reload func()) {
+	current := ""
+	if panel.Reserved != nil {
+		current = strconv.Itoa(int(*panel.Reserved))
+	}
+	prompt := modals.NewInputModal(i18n.Tf("lambda.concurrency_reserved_title", target.Name), i18n.T("lambda.concurrency_reserved_label"), current)
+	prompt.SetOnDone(func(text string, ok bool) {
+		r.pages.RemovePage("concurrency-input")
+		if !ok {
+			return
+		}
+		reserved, err := resource.ParseReservedConcurrency(text)
+		if err == nil {
+			err = panel.ValidateReserved(reserved)
+		}
+		if err != nil {
+			r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			return
+		}
+		message := i18n.Tf("lambda.concurrency_reserved_confirm", target.Name, reservedLabel(panel.Reserved), reservedLabel(reserved))
+		if reserved != nil && *reserved == 0 {
+			message += "\n\n" + i18n.T("lambda.concurrency_zero_warning")
+		}
+		r.confirmConcurrencyChange(target, message, func() error {
+			return r.service.ApplyReservedConcurrency(r.ctx, target, reserved)
+		}, reload)
+	})
+	r.pages.AddAndSwitchToPage("concurrency-input", prompt.Primitive(), true)
+}
+
+// promptProvisionedConcurrency 詢問 "<alias 或版本> <數量>"（0 表示移除），檢查保留並行數與帳號容量後確認並套用。
+func (r *Root) promptProvisionedConcurrency(target resource.FunctionTarget, panel resource.ConcurrencyPanel, qualifier string, reload func()) {
+	if qualifier == "" && len(panel.Provisioned) > 0 {
+		qualifier = panel.Provisioned[0].Qualifier
+	}
+	value := ""
+	if qualifier != "" {
+		value = fmt.Sprintf("%s %d", qualifier, provisionedFor(panel, qualifier))
+	}
+	prompt := modals.NewInputModal(i18n.Tf("lambda.concurrency_provisioned_title", target.Name), i18n.T("lambda.concurrency_provisioned_label"), value)
+	prompt.SetOnDone(func(text string, ok bool) {
+		r.pages.RemovePage("concurrency-input")
+		if !ok {
+			return
+		}
+		qualifier, count, err := resource.ParseProvisionedConcurrency(text)
+		if err == nil {
+			err = panel.ValidateProvisioned(qualifier, count)
+		}
+		if err != nil {
+			r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			return
+		}
+		message := i18n.Tf("lambda.concurrency_provisioned_confirm", target.Name, qualifier, provisionedFor(panel, qualifier), count)
+		r.confirmConcurrencyChange(target, message, func() error {
+			return r.service.ApplyProvisionedConcurrency(r.ctx, target, qualifier, count)
+		}, reload)
+	})
+	r.pages.AddAndSwitchToPage("concurrency-input", prompt.Primitive(), true)
+}
+
+// confirmConcurrencyChange 確認後於背景套用變更，完成後重新載入面板。
+func (r *Root) confirmConcurrencyChange(target resource.FunctionTarget, message string, apply func() error, reload func()) {
+	confirm := modals.NewConfirmModal()
+	confirm.Show(i18n.T("action.confirm"), message, func(confirmed bool) {
+		r.pages.RemovePage("confirm")
+		if !confirmed {
+			return
+		}
+		r.setStatus(i18n.Tf("lambda.concurrency_updating", target.Name))
+		go func() {
+			err := apply()
+			r.app.QueueUpdateDraw(func() {
+				if err != nil {
+					r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+					r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+					return
+				}
+				r.setStatus(i18n.Tf("lambda.concurrency_updated", target.Name))
+				reload()
+			})
+		}()
+	})
+	r.pages.AddAndSwitchToPage("confirm", confirm.Primitive(), true)
+}
+
+// formatConcurrencyPanel 組合面板內容：設定值、指標 sparkline 與 throttle 原因的推斷。
+func formatConcurrencyPanel(panel resource.ConcurrencyPanel) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]%s[::-]\n", i18n.T("lambda.concurrency_account"))
+	fmt.Fprintf(&b, " - %s\n\n", i18n.Tf("lambda.concurrency_account_limit", panel.Account.Limit, panel.Account.Unreserved))
+
+	fmt.Fprintf(&b, "[::b]%s[::-]\n", tview.Escape(panel.Function))
+	if panel.Reserved != nil {
+		fmt.Fprintf(&b, " - %s\n", i18n.Tf("lambda.concurrency_reserved", *panel.Reserved))
+	} else {
+		fmt.Fprintf(&b, " - %s\n", i18n.Tf("lambda.concurrency_unreserved", panel.Account.Unreserved))
+	}
+	fmt.Fprintf(&b, " - %s:\n", i18n.T("lambda.concurrency_provisioned"))
+	if len(panel.Provisioned) == 0 {
+		fmt.Fprintf(&b, "   [gray]%s[-]\n", i18n.T("lambda.concurrency_provisioned_none"))
+	}
+	for _, c := range panel.Provisioned {
+		fmt.Fprintf(&b, "   %s\n", i18n.Tf("lambda.concurrency_provisioned_row", tview.Escape(c.Qualifier), c.Requested, c.Allocated, c.Available, provisionedStatus(c)))
+		if c.StatusReason != "" {
+			fmt.Fprintf(&b, "     [gray]%s[-]\n", tview.Escape(c.StatusReason))
+		}
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "[::b]%s[::-]\n", i18n.Tf("lambda.concurrency_metrics", panel.End.Sub(panel.Start).Round(time.Minute)))
+	if panel.MetricsErr != nil {
+		fmt.Fprintf(&b, "[red]%s[-]\n", tview.Escape(i18n.Tf("lambda.concurrency_metrics_failed", panel.MetricsErr)))
+	} else {
+		stats := panel.Stats
+		fmt.Fprintf(&b, " concurrent %s\n", detail.Sparkline(panel.Concurrent))
+		if stats.PeakConcurrent > 0 {
+			fmt.Fprintf(&b, "   %s\n", i18n.Tf("lambda.concurrency_peak", stats.PeakConcurrent, stats.PeakAt.Local().Format("15:04"), panel.Limit()))
+		}
+		fmt.Fprintf(&b, " throttles  %s\n", detail.Sparkline(panel.Throttles))
+		if stats.ThrottledMinutes == 0 {
+			fmt.Fprintf(&b, "   [green]%s[-]\n", i18n.T("lambda.concurrency_no_throttles"))
+		} else {
+			fmt.Fprintf(&b, "   %s\n", i18n.Tf("lambda.concurrency_throttles", stats.Throttles, stats.ThrottledMinutes, stats.LastThrottle.Local().Format("15:04")))
+			fmt.Fprintf(&b, "\n[yellow]%s[-]\n", throttleDiagnosis(stats))
+		}
+	}
+	fmt.Fprintf(&b, "\n[gray]%s[-]", i18n.T("lambda.concurrency_hint"))
+	return b.String()
+}
+
+// throttleDiagnosis 依 throttle 原因回傳建議。
+func throttleDiagnosis(stats resource.ConcurrencyStats) string {
+	switch stats.Cause {
+	case resource.ThrottleReserved:
+		return i18n.Tf("lambda.concurrency_cause_reserved", stats.AtLimitMinutes, stats.ThrottledMinutes)
+	case resource.ThrottleAccount:
+		return i18n.Tf("lambda.concurrency_cause_account", stats.AtLimitMinutes, stats.ThrottledMinutes)
+	default:
+		return i18n.Tf("lambda.concurrency_cause_burst", stats.AtLimitMinutes, stats.ThrottledMinutes)
+	}
+}
+
+// provisionedStatus 以顏色標示 provisioned concurrency 的配置狀態。
+func provisionedStatus(c models.LambdaProvisionedConcurrency) string {
+	switch c.Status {
+	case "READY":
+		return "[green]" + c.Status + "[-]"
+	case "FAILED":
+		return "[red]" + c.Status + "[-]"
+	default:
+		return "[yellow]" + c.Status + "[-]"
+	}
+}
+
+// provisionedFor 回傳 alias 或版本目前要求的 provisioned concurrency（未設定為 0）。
+func provisionedFor(panel resource.ConcurrencyPanel, qualifier string) int32 {
+	for _, c := range panel.Provisioned {
+		if c.Qualifier == qualifier {
+			return c.Requested
+		}
+	}
+	return 0
+}
+
+// reservedLabel 回傳保留並行數的顯示文字；未設定時為 none。
+func reservedLabel(reserved *int32) string {
+	if reserved == nil {
+		return i18n.T("lambda.concurrency_none")
+	}
+	return strconv.Itoa(int(*reserved))
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// startLambdaConfigEditor 取得選取函式的記憶體、逾時與環境變數後開啟 YAML 編輯器；Ctrl-S 驗證並顯示差異。
+func (r *Root) startLambdaConfigEditor() {
+	item, ok := r.listView.CurrentItem()
+	if !ok {
+		r.setStatus("[yellow]No resource selected[-]")
+		return
+	}
+	if r.transferBusy() {
+		return
+	}
+	r.setStatus(i18n.Tf("lambda.config_loading", item.Name))
+	go func() {
+		edit, err := r.service.LoadLambdaConfig(r.ctx, item.ID)
+		r.app.QueueUpdateDraw(func() {
+			if err != nil {
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+				return
+			}
+			r.setStatus("")
+			r.openLambdaConfigEditor(edit)
+		})
+	}()
+}
+
+func (r *Root) openLambdaConfigEditor(edit resource.LambdaConfigEdit) {
+	editor := modals.NewYAMLEditor(i18n.Tf("lambda.config_title", edit.Name), edit.Text)
+	editor.SetOnCancel(func() {
+		r.pages.RemovePage("lambda-config-editor")
+	})
+	editor.SetOnSave(func(text string) {
+		change, err := resource.PlanLambdaConfig(edit, text)
+		if err != nil {
+			editor.SetError(err)
+			return
+		}
+		if !change.Changed() {
+			editor.SetInfo(i18n.T("rules.no_changes"))
+			return
+		}
+		editor.SetError(nil)
+		r.reviewLambdaConfig(editor, change)
+	})
+	r.pages.AddAndSwitchToPage("lambda-config-editor", editor.Primitive(), true)
+}
+
+// reviewLambdaConfig 顯示目前設定與編輯後設定的差異（機密值已遮蔽）；y 套用，Esc 回到編輯器。
+func (r *Root) reviewLambdaConfig(editor *modals.YAMLEditor, change resource.LambdaConfigChange) {
+	var b strings.Builder
+	inserted, deleted := diff.Stats(change.Diff)
+	fmt.Fprintf(&b, "%s\n\n", i18n.Tf("lambda.config_diff_stats", change.Name, inserted, deleted))
+	b.WriteString(formatDiff(change.Diff, rulesDiffContext))
+	fmt.Fprintf(&b, "\n[gray]%s[-]", i18n.T("rules.review_hint"))
+
+	view := modals.NewTextModal(i18n.Tf("lambda.config_review_title", change.Name), b.String())
+	view.SetOnClose(func() {
+		r.pages.RemovePage("lambda-config-diff")
+	})
+	view.SetOnRune(func(ch rune) bool {
+		if ch != 'y' {
+			return false
+		}
+		r.pages.RemovePage("lambda-config-diff")
+		r.applyLambdaConfig(editor, change)
+		return true
+	})
+	r.pages.AddAndSwitchToPage("lambda-config-diff", view.Primitive(), true)
+}
+
+// applyLambdaConfig 於背景送出 UpdateFunctionConfiguration；失敗時保留編輯器內容並顯示錯誤，
+// 成功後關閉編輯器並輪詢 LastUpdateStatus 直到 Successful（x 停止等待，不影響已送出的更新）。
+func (r *Root) applyLambdaConfig(editor *modals.YAMLEditor, change resource.LambdaConfigChange) {
+	if r.transferBusy() {
+		return
+	}
+	editor.SetInfo(i18n.Tf("lambda.config_applying", change.Name))
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+
+	go func() {
+		defer cancel()
+		err := r.service.ApplyLambdaConfig(ctx, change)
+		if err != nil {
+			r.app.QueueUpdateDraw(func() {
+				r.transferCancel = nil
+				if errors.Is(err, repo.ErrLambdaConfigChanged) {
+					editor.SetError(errors.New(i18n.T("lambda.config_changed")))
+					return
+				}
+				editor.SetError(err)
+			})
+			return
+		}
+		r.app.QueueUpdateDraw(func() {
+			r.pages.RemovePage("lambda-config-editor")
+		})
+
+		start := time.Now()
+		err = r.service.WaitLambdaUpdate(ctx, change.Function, func(status string) {
+			r.app.QueueUpdateDraw(func() {
+				elapsed := time.Since(start).Round(time.Second)
+				r.setStatus(fmt.Sprintf("%s %s", i18n.Tf("lambda.config_waiting", change.Name, status, elapsed), i18n.T("transfer.cancel_hint")))
+			})
+		})
+		r.app.QueueUpdateDraw(func() {
+			r.transferCancel = nil
+			switch {
+			case errors.Is(err, context.Canceled):
+				r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.Tf("lambda.config_wait_stopped", change.Name)))
+			case err != nil:
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			default:
+				message := i18n.Tf("lambda.config_applied", change.Name, time.Since(start).Round(time.Second))
+				r.setStatus(message)
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+			}
+			if item, ok := r.listView.CurrentItem(); ok && r.currentKind == resource.KindLambda && item.ID == change.Function {
+				r.showDetail(item)
+			}
+		})
+	}()
+}
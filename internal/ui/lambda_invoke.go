@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/app/config"
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/ops"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/testevents"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// defaultInvokePayload 為開啟工作台時編輯器的初始內容。
+const defaultInvokePayload = "{}"
+
+// lambdaEventsDir 回傳測試事件的儲存目錄（設定目錄下的 lambda-events）。
+func lambdaEventsDir() string {
+	dir := config.Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "lambda-events")
+}
+
+// startInvokeWorkbench 開啟選取函式（或版本清單中選取的版本、alias）的呼叫工作台。
+// 呼叫在背景執行，Esc 關閉工作台時一併取消進行中的呼叫。
+func (r *Root) startInvokeWorkbench() {
+	var item models.ListItem
+	if r.currentKind != resource.KindLambdaTriggers {
+		selected, ok := r.listView.CurrentItem()
+		if !ok {
+			r.setStatus("[yellow]No resource selected[-]")
+			return
+		}
+		item = selected
+	}
+	target, ok := r.service.FunctionTargetFor(r.currentKind, item)
+	if !ok {
+		return
+	}
+	events := testevents.NewStore(lambdaEventsDir())
+	workbench := modals.NewInvokeWorkbench(i18n.Tf("lambda.invoke_title", target.Label()), defaultInvokePayload)
+	workbench.SetOnFocus(func(p tview.Primitive) { r.app.SetFocus(p) })
+
+	var cancel context.CancelFunc
+	var eventName string
+	workbench.SetOnClose(func() {
+		if cancel != nil {
+			cancel()
+		}
+		r.pages.RemovePage("invoke-workbench")
+	})
+	workbench.SetOnInvoke(func(text string) {
+		if cancel != nil {
+			workbench.SetInfo(i18n.Tf("lambda.invoking", target.Label()))
+			return
+		}
+		payload, err := resource.ParseInvokePayload(text)
+		if err != nil {
+			workbench.SetError(err)
+			return
+		}
+		ctx, invokeCancel := context.WithCancel(r.ctx)
+		cancel = invokeCancel
+		workbench.SetInfo(i18n.Tf("lambda.invoking", target.Label()))
+		start := time.Now()
+		go func() {
+			defer invokeCancel()
+			result, err := r.service.InvokeLambda(ctx, target, payload)
+			elapsed := time.Since(start).Round(time.Millisecond)
+			r.app.QueueUpdateDraw(func() {
+				cancel = nil
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				if err != nil {
+					workbench.SetError(err)
+					return
+				}
+				workbench.SetResult(formatInvokeResponse(result), invokeLog(result))
+				message := i18n.Tf("lambda.invoke_done", target.Label(), result.StatusCode, elapsed)
+				if result.FunctionError != "" {
+					workbench.SetError(errors.New(message + " · " + i18n.Tf("lambda.invoke_function_error", result.FunctionError)))
+					return
+				}
+				workbench.SetInfo(message)
+			})
+		}()
+	})
+	workbench.SetOnSave(func(text string) {
+		if _, err := resource.ParseInvokePayload(text); err != nil {
+			workbench.SetError(err)
+			return
+		}
+		prompt := modals.NewInputModal(i18n.Tf("lambda.event_save_title", target.Name), i18n.T("lambda.event_name"), eventName)
+		prompt.SetOnDone(func(name string, ok bool) {
+			r.pages.RemovePage("event-name")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return
+			}
+			if err := events.Save(target.Function, name, text); err != nil {
+				workbench.SetError(err)
+				return
+			}
+			eventName = name
+			workbench.SetInfo(i18n.Tf("lambda.event_saved", name))
+		})
+		r.pages.AddAndSwitchToPage("event-name", prompt.Primitive(), true)
+	})
+	workbench.SetOnLoad(func() {
+		r.showEventPicker(events, target, workbench, func(name string) { eventName = name })
+	})
+	r.pages.AddAndSwitchToPage("invoke-workbench", workbench.Primitive(), true)
+}
+
+// showEventPicker 列出函式已儲存的測試事件與內建範本；選取後載入編輯器，d 刪除已儲存的事件。
+func (r *Root) showEventPicker(events *testevents.Store, target resource.FunctionTarget, workbench *modals.InvokeWorkbench, onLoaded func(name string)) {
+	saved, err := events.List(target.Function)
+	if err != nil {
+		workbench.SetError(err)
+		return
+	}
+	templates := testevents.Templates()
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	picker := modals.NewEventPicker(i18n.Tf("lambda.event_pick_title", target.Name), saved, names, func(index int) {
+		r.pages.RemovePage("event-picker")
+		workbench.SetPayload(templates[index].Payload)
+		workbench.SetInfo(i18n.Tf("lambda.event_loaded", templates[index].Name))
+	})
+	picker.SetOnSaved(func(name string) {
+		r.pages.RemovePage("event-picker")
+		payload, err := events.Load(target.Function, name)
+		if err != nil {
+			workbench.SetError(err)
+			return
+		}
+		workbench.SetPayload(strings.TrimSpace(payload))
+		workbench.SetInfo(i18n.Tf("lambda.event_loaded", name))
+		onLoaded(name)
+	})
+	picker.SetOnDelete(func(name string) {
+		r.pages.RemovePage("event-picker")
+		if err := events.Delete(target.Function, name); err != nil {
+			workbench.SetError(err)
+			return
+		}
+		workbench.SetInfo(i18n.Tf("lambda.event_deleted", name))
+	})
+	picker.SetOnCancel(func() {
+		r.pages.RemovePage("event-picker")
+	})
+	r.pages.AddAndSwitchToPage("event-picker", picker.Primitive(), true)
+}
+
+// formatInvokeResponse 組合回應窗格：狀態、執行版本、function error 與縮排後的 payload。
+func formatInvokeResponse(result *ops.InvokeResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]Status:[::-] %d\n", result.StatusCode)
+	if result.ExecutedVersion != "" {
+		fmt.Fprintf(&b, "[::b]Version:[::-] %s\n", tview.Escape(result.ExecutedVersion))
+	}
+	if result.FunctionError != "" {
+		fmt.Fprintf(&b, "[red]%s[-]\n", tview.Escape(i18n.Tf("lambda.invoke_function_error", result.FunctionError)))
+	}
+	b.WriteString("\n")
+	if result.Payload == "" {
+		fmt.Fprintf(&b, "[gray]%s[-]", i18n.T("lambda.invoke_no_payload"))
+	} else {
+		b.WriteString(tview.Escape(resource.FormatInvokePayload(result.Payload)))
+	}
+	return b.String()
+}
+
+// invokeLog 回傳解碼後的 tail log；未回傳 log 時顯示提示。
+func invokeLog(result *ops.InvokeResult) string {
+	if result.Log == "" {
+		return i18n.T("lambda.invoke_no_log")
+	}
+	return result.Log
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// startPublishVersion 輸入說明後將目前函式的 $LATEST 發佈為新版本。
+func (r *Root) startPublishVersion() {
+	if r.transferBusy() {
+		return
+	}
+	name := r.service.CurrentFunctionName()
+	prompt := modals.NewInputModal(i18n.Tf("lambda.publish_title", name), i18n.T("lambda.publish_label"), "")
+	prompt.SetOnDone(func(description string, ok bool) {
+		r.pages.RemovePage("publish-prompt")
+		if !ok {
+			return
+		}
+		r.setStatus(i18n.Tf("lambda.publishing", name))
+		go func() {
+			version, created, err := r.service.PublishLambdaVersion(r.ctx, description)
+			r.app.QueueUpdateDraw(func() {
+				if err != nil {
+					r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+					r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+					return
+				}
+				message := i18n.Tf("lambda.published", name, version.Version)
+				if !created {
+					message = i18n.Tf("lambda.publish_unchanged", name, version.Version)
+				}
+				r.setStatus(message)
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+				if r.currentKind == resource.KindLambdaVersions {
+					go r.refresh()
+				}
+			})
+		}()
+	})
+	r.pages.AddAndSwitchToPage("publish-prompt", prompt.Primitive(), true)
+}
+
+// startAliasRouting 輸入選取 alias 的新路由（"5" 移動 alias，"4 5=10" 將 10% 流量分給版本 5），確認前後差異後套用。
+func (r *Root) startAliasRouting() {
+	if r.transferBusy() {
+		return
+	}
+	item, ok := r.listView.CurrentItem()
+	if !ok {
+		r.setStatus("[yellow]No resource selected[-]")
+		return
+	}
+	if item.Type != "Alias" {
+		r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.T("lambda.select_alias")))
+		return
+	}
+	current := strings.TrimPrefix(item.Status, "→ ")
+	prompt := modals.NewInputModal(i18n.Tf("lambda.alias_title", item.Name, item.Metadata["routing"]), i18n.T("lambda.alias_label"), current)
+	prompt.SetOnDone(func(text string, ok bool) {
+		r.pages.RemovePage("alias-prompt")
+		if !ok || strings.TrimSpace(text) == "" {
+			return
+		}
+		r.setStatus(i18n.T("action.checking"))
+		go func() {
+			change, err := r.service.PlanAliasRouting(r.ctx, item.Name, text)
+			r.app.QueueUpdateDraw(func() {
+				if err != nil {
+					r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+					r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+					return
+				}
+				r.setStatus("")
+				r.confirmAliasChange(change)
+			})
+		}()
+	})
+	r.pages.AddAndSwitchToPage("alias-prompt", prompt.Primitive(), true)
+}
+
+// confirmAliasChange 顯示 alias 路由的前後差異，確認後於背景套用。
+func (r *Root) confirmAliasChange(change resource.AliasChange) {
+	confirm := modals.NewConfirmModal()
+	confirm.Show(
+		i18n.T("action.confirm"),
+		i18n.Tf("lambda.alias_confirm", change.Function, change.After.Name, resource.AliasRouting(change.Before), resource.AliasRouting(change.After)),
+		func(confirmed bool) {
+			r.pages.RemovePage("confirm")
+			if !confirmed {
+				return
+			}
+			r.setStatus(i18n.Tf("lambda.alias_updating", change.After.Name))
+			go func() {
+				alias, err := r.service.ApplyAliasChange(r.ctx, change)
+				r.app.QueueUpdateDraw(func() {
+					if errors.Is(err, repo.ErrLambdaConfigChanged) {
+						err = errors.New(i18n.Tf("lambda.alias_changed", change.After.Name))
+					}
+					if err != nil {
+						r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+						r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+						return
+					}
+					message := i18n.Tf("lambda.alias_updated", alias.Name, resource.AliasRouting(alias))
+					r.setStatus(message)
+					r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+					if r.currentKind == resource.KindLambdaVersions {
+						go r.refresh()
+					}
+				})
+			}()
+		},
+	)
+	r.pages.AddAndSwitchToPage("confirm", confirm.Primitive(), true)
+}
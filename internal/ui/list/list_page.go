@@ -15,6 +15,8 @@ import (
 type View struct {
 	table    *tview.Table
 	items    []models.ListItem
+	columns  []models.Column
+	marked   map[string]bool
 	onSelect func(models.ListItem)
 }
 
@@ -25,7 +27,7 @@ func NewView() *View {
 		SetFixed(1, 0)
 	table.SetBorder(true).SetTitle(i18n.T("ui.resource_list"))
 
-	v := &View{table: table}
+	v := &View{table: table, marked: make(map[string]bool)}
 	table.SetSelectedFunc(func(row, _ int) {
 		if row <= 0 || row-1 >= len(v.items) {
 			return
@@ -57,33 +59,30 @@ func (v *View) Primitive() *tview.Table {
 	return v.table
 }
 
-// SetItems 更新清單內容。
+// SetColumns 設定清單欄位（標題為 i18n key）；傳入 nil 會使用預設欄位。
+// 需在 SetItems 之前呼叫。
+func (v *View) SetColumns(columns []models.Column) {
+	v.columns = columns
+}
+
+// SetItems 更新清單內容；已標記的項目若仍在清單中會保留標記。
 func (v *View) SetItems(items []models.ListItem) {
 	v.items = items
 	v.table.Clear()
 
-	headers := []string{
-		i18n.T("column.name"),
-		i18n.T("column.type"),
-		i18n.T("column.status"),
-		i18n.T("column.region"),
-	}
-	for col, header := range headers {
-		v.table.SetCell(0, col, headerCell(header))
-	}
-
-	for i, item := range items {
-		row := i + 1
-		v.table.SetCell(row, 0, textCell(item.Name))
-		v.table.SetCell(row, 1, textCell(item.Type))
-		v.table.SetCell(row, 2, textCell(item.Status))
-		region := item.Region
-		if region == "" && item.Metadata != nil {
-			if ep, ok := item.Metadata["endpoint"]; ok {
-				region = ep
-			}
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[item.ID] = true
+	}
+	for id := range v.marked {
+		if !present[id] {
+			delete(v.marked, id)
 		}
-		v.table.SetCell(row, 3, textCell(region))
+	}
+
+	v.setHeaders(v.activeColumns())
+	for i := range items {
+		v.renderRow(i)
 	}
 
 	if len(items) > 0 {
@@ -91,6 +90,65 @@ func (v *View) SetItems(items []models.ListItem) {
 	}
 }
 
+// ToggleMark 切換目前選取項目的標記並移到下一列，回傳標記總數。
+func (v *View) ToggleMark() int {
+	row, _ := v.table.GetSelection()
+	if row <= 0 || row-1 >= len(v.items) {
+		return len(v.marked)
+	}
+	id := v.items[row-1].ID
+	if v.marked[id] {
+		delete(v.marked, id)
+	} else {
+		v.marked[id] = true
+	}
+	v.renderRow(row - 1)
+	if row < len(v.items) {
+		v.table.Select(row+1, 0)
+	}
+	return len(v.marked)
+}
+
+// Marked 依清單順序回傳已標記的項目。
+func (v *View) Marked() []models.ListItem {
+	var items []models.ListItem
+	for _, item := range v.items {
+		if v.marked[item.ID] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ClearMarks 清除所有標記。
+func (v *View) ClearMarks() {
+	if len(v.marked) == 0 {
+		return
+	}
+	clear(v.marked)
+	for i := range v.items {
+		v.renderRow(i)
+	}
+}
+
+// SelectID 選取指定 ID 的項目，回傳是否找到（背景刷新後用於保留原本的選取位置）。
+func (v *View) SelectID(id string) bool {
+	for i, item := range v.items {
+		if item.ID == id {
+			v.table.Select(i+1, 0)
+			return true
+		}
+	}
+	return false
+}
+
+// SelectLast 選取最後一列；清單為空時不做任何事。
+func (v *View) SelectLast() {
+	if len(v.items) > 0 {
+		v.table.Select(len(v.items), 0)
+	}
+}
+
 // SetOnSelect 設定選取事件。
 func (v *View) SetOnSelect(fn func(models.ListItem)) {
 	v.onSelect = fn
@@ -113,18 +171,47 @@ func (v *View) Count() int {
 // RefreshLabels 刷新標題與欄位名稱（語言切換時使用）。
 func (v *View) RefreshLabels() {
 	v.table.SetTitle(i18n.T("ui.resource_list"))
-	// 更新欄位標題
-	headers := []string{
-		i18n.T("column.name"),
-		i18n.T("column.type"),
-		i18n.T("column.status"),
-		i18n.T("column.region"),
+	v.setHeaders(v.activeColumns())
+}
+
+func (v *View) activeColumns() []models.Column {
+	if len(v.columns) == 0 {
+		return defaultColumns
 	}
-	for col, header := range headers {
-		v.table.SetCell(0, col, headerCell(header))
+	return v.columns
+}
+
+// renderRow 繪製第 i 個項目；已標記的項目在第一欄前加上 ●。
+func (v *View) renderRow(i int) {
+	item := v.items[i]
+	for col, column := range v.activeColumns() {
+		text := column.Value(item)
+		if col == 0 && v.marked[item.ID] {
+			text = "[::b]●[::-] " + text
+		}
+		v.table.SetCell(i+1, col, textCell(text))
 	}
 }
 
+func (v *View) setHeaders(columns []models.Column) {
+	for col, column := range columns {
+		v.table.SetCell(0, col, headerCell(i18n.T(column.Title)))
+	}
+}
+
+// defaultColumns 為未指定欄位時使用的通用欄位。
+var defaultColumns = []models.Column{
+	{Title: "column.name", Value: func(item models.ListItem) string { return item.Name }},
+	{Title: "column.type", Value: func(item models.ListItem) string { return item.Type }},
+	{Title: "column.status", Value: func(item models.ListItem) string { return item.Status }},
+	{Title: "column.region", Value: func(item models.ListItem) string {
+		if item.Region == "" && item.Metadata != nil {
+			return item.Metadata["endpoint"]
+		}
+		return item.Region
+	}},
+}
+
 func headerCell(text string) *tview.TableCell {
 	return tview.NewTableCell(fmt.Sprintf("[::b]%s", text)).
 		SetTextColor(tcell.ColorYellow).
@@ -130,19 +130,3 @@ func (p *ActionPanel) SetActions(actions []string, onAction func(action string))
 		}
 	})
 }
-
-// AvailableActions 根據資源類型回傳可用操作（已 i18n）。
-func AvailableActions(resourceType string) []string {
-	switch resourceType {
-	case "EC2":
-		return []string{i18n.T("action.start"), i18n.T("action.stop"), i18n.T("action.reboot")}
-	case "RDS":
-		return []string{i18n.T("action.start"), i18n.T("action.stop"), i18n.T("action.reboot")}
-	case "Lambda":
-		return []string{i18n.T("action.invoke")}
-	case "S3":
-		return []string{} // S3 目前無操作
-	default:
-		return []string{}
-	}
-}
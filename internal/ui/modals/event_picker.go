@@ -0,0 +1,103 @@
+package modals
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+)
+
+// EventPicker 列出已儲存的測試事件與內建範本；Enter 載入、d 刪除已儲存的事件、Esc/q 取消。
+type EventPicker struct {
+	list     *tview.List
+	flex     *tview.Flex
+	saved    []string
+	onSaved  func(name string)
+	onDelete func(name string)
+	onCancel func()
+}
+
+// NewEventPicker 建立事件選擇器；saved 為已儲存的事件名稱，templates 為範本名稱，onTemplate 以索引回呼。
+func NewEventPicker(title string, saved, templates []string, onTemplate func(index int)) *EventPicker {
+	p := &EventPicker{
+		list:  tview.NewList().ShowSecondaryText(false).SetHighlightFullLine(true),
+		saved: saved,
+	}
+	p.list.SetBorder(true).SetTitle(fmt.Sprintf(" %s ", title))
+
+	for _, name := range saved {
+		p.list.AddItem(name, "", 0, func() {
+			if p.onSaved != nil {
+				p.onSaved(name)
+			}
+		})
+	}
+	for i, name := range templates {
+		p.list.AddItem(fmt.Sprintf("[gray]%s:[-] %s", i18n.T("lambda.event_template"), name), "", 0, func() {
+			onTemplate(i)
+		})
+	}
+
+	p.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			p.cancel()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'q':
+				p.cancel()
+				return nil
+			case 'j':
+				return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+			case 'k':
+				return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+			case 'd':
+				if index := p.list.GetCurrentItem(); index < len(p.saved) && p.onDelete != nil {
+					p.onDelete(p.saved[index])
+				}
+				return nil
+			}
+		}
+		return event
+	})
+
+	// 置中顯示
+	p.flex = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p.list, min(len(saved)+len(templates)+2, 20), 0, true).
+			AddItem(nil, 0, 1, false), 50, 0, true).
+		AddItem(nil, 0, 1, false)
+	return p
+}
+
+// Primitive 回傳 tview 元件。
+func (p *EventPicker) Primitive() tview.Primitive {
+	return p.flex
+}
+
+// SetOnSaved 註冊選取已儲存事件的回呼。
+func (p *EventPicker) SetOnSaved(fn func(name string)) {
+	p.onSaved = fn
+}
+
+// SetOnDelete 註冊刪除已儲存事件的回呼（d）。
+func (p *EventPicker) SetOnDelete(fn func(name string)) {
+	p.onDelete = fn
+}
+
+// SetOnCancel 註冊取消回呼。
+func (p *EventPicker) SetOnCancel(fn func()) {
+	p.onCancel = fn
+}
+
+func (p *EventPicker) cancel() {
+	if p.onCancel != nil {
+		p.onCancel()
+	}
+}
@@ -0,0 +1,61 @@
+package modals
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+)
+
+// InputModal 顯示單一輸入欄位的對話框（例如輸入本機路徑）。
+type InputModal struct {
+	form   *tview.Form
+	input  *tview.InputField
+	layout *tview.Flex
+	onDone func(value string, ok bool)
+}
+
+// NewInputModal 建立輸入對話框，value 為預設值。
+func NewInputModal(title, label, value string) *InputModal {
+	m := &InputModal{
+		form:  tview.NewForm(),
+		input: tview.NewInputField().SetLabel(label).SetText(value).SetFieldWidth(0),
+	}
+	m.form.AddFormItem(m.input).
+		AddButton(i18n.T("action.ok"), func() { m.done(true) }).
+		AddButton(i18n.T("action.cancel"), func() { m.done(false) })
+	m.form.SetBorder(true).SetTitle(" " + title + " ")
+	m.form.SetCancelFunc(func() { m.done(false) })
+	m.input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			m.done(true)
+		}
+	})
+
+	// 置中顯示
+	m.layout = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(m.form, 7, 0, true).
+			AddItem(nil, 0, 1, false), 0, 3, true).
+		AddItem(nil, 0, 1, false)
+	return m
+}
+
+// Primitive 回傳 tview 元件。
+func (m *InputModal) Primitive() *tview.Flex {
+	return m.layout
+}
+
+// SetOnDone 註冊完成回呼；ok 為 false 表示取消。
+func (m *InputModal) SetOnDone(fn func(value string, ok bool)) {
+	m.onDone = fn
+}
+
+func (m *InputModal) done(ok bool) {
+	if m.onDone != nil {
+		m.onDone(m.input.GetText(), ok)
+	}
+}
@@ -0,0 +1,161 @@
+package modals
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+)
+
+// InvokeWorkbench 為 Lambda 呼叫工作台：上方為多行 JSON payload 編輯器，下方左右並列回應與 tail log。
+// Ctrl-R 呼叫、Ctrl-S 儲存測試事件、Ctrl-O 載入事件或範本、Tab 切換窗格、Esc 關閉。
+type InvokeWorkbench struct {
+	editor   *tview.TextArea
+	response *tview.TextView
+	log      *tview.TextView
+	message  *tview.TextView
+	layout   *tview.Flex
+	panes    []tview.Primitive
+	onInvoke func(payload string)
+	onSave   func(payload string)
+	onLoad   func()
+	onClose  func()
+	onFocus  func(p tview.Primitive)
+}
+
+// NewInvokeWorkbench 建立呼叫工作台；payload 為編輯器的初始內容。
+func NewInvokeWorkbench(title, payload string) *InvokeWorkbench {
+	w := &InvokeWorkbench{
+		editor:   tview.NewTextArea().SetText(payload, false),
+		response: tview.NewTextView().SetDynamicColors(true).SetScrollable(true).SetWrap(true),
+		log:      tview.NewTextView().SetScrollable(true).SetWrap(true),
+		message:  tview.NewTextView().SetDynamicColors(true),
+	}
+	w.editor.SetBorder(true).SetTitle(" " + i18n.T("lambda.invoke_payload") + " ")
+	w.response.SetBorder(true).SetTitle(" " + i18n.T("lambda.invoke_response") + " ")
+	w.log.SetBorder(true).SetTitle(" " + i18n.T("lambda.invoke_log") + " ")
+	w.panes = []tview.Primitive{w.editor, w.response, w.log}
+	w.SetInfo("")
+
+	results := tview.NewFlex().
+		AddItem(w.response, 0, 1, false).
+		AddItem(w.log, 0, 1, false)
+	frame := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(w.editor, 0, 2, true).
+		AddItem(results, 0, 3, false).
+		AddItem(w.message, 1, 0, false)
+	frame.SetBorder(true).SetTitle(" " + title + " ")
+	frame.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyCtrlR:
+			if w.onInvoke != nil {
+				w.onInvoke(w.editor.GetText())
+			}
+			return nil
+		case tcell.KeyCtrlS:
+			if w.onSave != nil {
+				w.onSave(w.editor.GetText())
+			}
+			return nil
+		case tcell.KeyCtrlO:
+			if w.onLoad != nil {
+				w.onLoad()
+			}
+			return nil
+		case tcell.KeyTab:
+			w.focusPane(w.currentPane() + 1)
+			return nil
+		case tcell.KeyBacktab:
+			w.focusPane(w.currentPane() + len(w.panes) - 1)
+			return nil
+		case tcell.KeyEscape:
+			if w.onClose != nil {
+				w.onClose()
+			}
+			return nil
+		}
+		return event
+	})
+
+	// 置中顯示
+	w.layout = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false).
+			AddItem(frame, 0, 1, true).
+			AddItem(nil, 1, 0, false), 0, 10, true).
+		AddItem(nil, 0, 1, false)
+	return w
+}
+
+// Primitive 回傳 tview 元件。
+func (w *InvokeWorkbench) Primitive() *tview.Flex {
+	return w.layout
+}
+
+// SetOnInvoke 註冊呼叫回呼（Ctrl-R），參數為編輯器內容。
+func (w *InvokeWorkbench) SetOnInvoke(fn func(payload string)) {
+	w.onInvoke = fn
+}
+
+// SetOnSave 註冊儲存測試事件回呼（Ctrl-S）。
+func (w *InvokeWorkbench) SetOnSave(fn func(payload string)) {
+	w.onSave = fn
+}
+
+// SetOnLoad 註冊載入事件或範本回呼（Ctrl-O）。
+func (w *InvokeWorkbench) SetOnLoad(fn func()) {
+	w.onLoad = fn
+}
+
+// SetOnClose 註冊關閉回呼（Esc）。
+func (w *InvokeWorkbench) SetOnClose(fn func()) {
+	w.onClose = fn
+}
+
+// SetOnFocus 註冊切換窗格時設定焦點的回呼（通常為 Application.SetFocus）。
+func (w *InvokeWorkbench) SetOnFocus(fn func(p tview.Primitive)) {
+	w.onFocus = fn
+}
+
+// SetPayload 取代編輯器內容並回到編輯器。
+func (w *InvokeWorkbench) SetPayload(payload string) {
+	w.editor.SetText(payload, false)
+	w.focusPane(0)
+}
+
+// SetResult 顯示回應（可含 tview 顏色標籤）與 tail log；回應捲到開頭，log 捲到結尾。
+func (w *InvokeWorkbench) SetResult(response, log string) {
+	w.response.SetText(response).ScrollToBeginning()
+	w.log.SetText(log).ScrollToEnd()
+}
+
+// SetError 於下方顯示錯誤。
+func (w *InvokeWorkbench) SetError(err error) {
+	w.message.SetText("[red]" + tview.Escape(err.Error()) + "[-]")
+}
+
+// SetInfo 於下方顯示訊息；message 為空字串時顯示操作提示。
+func (w *InvokeWorkbench) SetInfo(message string) {
+	if message == "" {
+		w.message.SetText("[gray]" + i18n.T("lambda.invoke_hint") + "[-]")
+		return
+	}
+	w.message.SetText("[yellow]" + tview.Escape(message) + "[-]")
+}
+
+func (w *InvokeWorkbench) currentPane() int {
+	for i, pane := range w.panes {
+		if pane.HasFocus() {
+			return i
+		}
+	}
+	return 0
+}
+
+func (w *InvokeWorkbench) focusPane(index int) {
+	if w.onFocus != nil {
+		w.onFocus(w.panes[index%len(w.panes)])
+	}
+}
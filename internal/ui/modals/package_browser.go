@@ -0,0 +1,111 @@
+package modals
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+)
+
+// PackageBrowser 左側列出部署套件中的檔案與大小，右側預覽選取的檔案；Tab 切換窗格、Esc/q 關閉。
+type PackageBrowser struct {
+	table    *tview.Table
+	viewer   *tview.TextView
+	layout   *tview.Flex
+	onSelect func(index int)
+	onClose  func()
+	onFocus  func(p tview.Primitive)
+}
+
+// PackageRow 為檔案清單的一列。
+type PackageRow struct {
+	Name       string
+	Size       string
+	Compressed string
+}
+
+// NewPackageBrowser 建立套件瀏覽器；title 通常包含套件名稱與檔案數。
+func NewPackageBrowser(title string, rows []PackageRow) *PackageBrowser {
+	b := &PackageBrowser{
+		table:  tview.NewTable().SetSelectable(true, false).SetFixed(1, 0),
+		viewer: tview.NewTextView().SetDynamicColors(true).SetScrollable(true).SetWrap(false),
+	}
+	b.table.SetBorder(true).SetTitle(" " + title + " ")
+	b.viewer.SetBorder(true).SetTitle(" " + i18n.T("lambda.package_viewer") + " ")
+
+	b.table.SetCell(0, 0, tview.NewTableCell("[::b]"+i18n.T("column.name")).SetSelectable(false).SetExpansion(1))
+	b.table.SetCell(0, 1, tview.NewTableCell("[::b]"+i18n.T("column.size")).SetSelectable(false).SetAlign(tview.AlignRight))
+	b.table.SetCell(0, 2, tview.NewTableCell("[::b]"+i18n.T("lambda.package_compressed")).SetSelectable(false).SetAlign(tview.AlignRight))
+	for i, row := range rows {
+		b.table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(row.Name)).SetExpansion(1))
+		b.table.SetCell(i+1, 1, tview.NewTableCell(row.Size).SetAlign(tview.AlignRight))
+		b.table.SetCell(i+1, 2, tview.NewTableCell(row.Compressed).SetAlign(tview.AlignRight))
+	}
+	if len(rows) > 0 {
+		b.table.Select(1, 0)
+	}
+	b.table.SetSelectionChangedFunc(func(row, _ int) {
+		if row > 0 && b.onSelect != nil {
+			b.onSelect(row - 1)
+		}
+	})
+
+	content := tview.NewFlex().
+		AddItem(b.table, 0, 2, true).
+		AddItem(b.viewer, 0, 3, false)
+	content.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape, event.Key() == tcell.KeyRune && event.Rune() == 'q':
+			if b.onClose != nil {
+				b.onClose()
+			}
+			return nil
+		case event.Key() == tcell.KeyTab, event.Key() == tcell.KeyBacktab:
+			if b.onFocus != nil {
+				if b.table.HasFocus() {
+					b.onFocus(b.viewer)
+				} else {
+					b.onFocus(b.table)
+				}
+			}
+			return nil
+		}
+		return event
+	})
+
+	// 置中顯示
+	b.layout = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false).
+			AddItem(content, 0, 1, true).
+			AddItem(nil, 1, 0, false), 0, 10, true).
+		AddItem(nil, 0, 1, false)
+	return b
+}
+
+// Primitive 回傳 tview 元件。
+func (b *PackageBrowser) Primitive() *tview.Flex {
+	return b.layout
+}
+
+// SetOnSelect 註冊選取檔案的回呼（參數為 rows 的索引）。
+func (b *PackageBrowser) SetOnSelect(fn func(index int)) {
+	b.onSelect = fn
+}
+
+// SetOnClose 註冊關閉回呼。
+func (b *PackageBrowser) SetOnClose(fn func()) {
+	b.onClose = fn
+}
+
+// SetOnFocus 註冊切換窗格時設定焦點的回呼（通常為 Application.SetFocus）。
+func (b *PackageBrowser) SetOnFocus(fn func(p tview.Primitive)) {
+	b.onFocus = fn
+}
+
+// SetPreview 顯示預覽內容（可含 tview 顏色標籤）並捲回開頭。
+func (b *PackageBrowser) SetPreview(text string) {
+	b.viewer.SetText(text).ScrollToBeginning()
+}
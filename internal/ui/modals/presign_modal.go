@@ -0,0 +1,100 @@
+package modals
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+)
+
+// PresignRequest 為 presigned URL 對話框的輸入結果。
+type PresignRequest struct {
+	Method  string
+	Key     string
+	Expires time.Duration
+}
+
+// presignExpiries 為可選的有效期限（SigV4 最長 7 天）。
+var presignExpiries = []struct {
+	label string
+	value time.Duration
+}{
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"6h", 6 * time.Hour},
+	{"12h", 12 * time.Hour},
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+}
+
+// defaultPresignExpiry 為預設選取的有效期限（1h）。
+const defaultPresignExpiry = 1
+
+// PresignModal 讓使用者選擇 presigned URL 的方法、key 與有效期限。
+type PresignModal struct {
+	form   *tview.Form
+	layout *tview.Flex
+	onDone func(req PresignRequest, ok bool)
+}
+
+// NewPresignModal 建立 presigned URL 對話框，key 為預設物件 key。
+func NewPresignModal(key string) *PresignModal {
+	m := &PresignModal{form: tview.NewForm()}
+
+	labels := make([]string, len(presignExpiries))
+	for i, e := range presignExpiries {
+		labels[i] = e.label
+	}
+	m.form.
+		AddDropDown(i18n.T("presign.method"), []string{
+			http.MethodGet + " " + i18n.T("presign.download"),
+			http.MethodPut + " " + i18n.T("presign.upload"),
+		}, 0, nil).
+		AddInputField(i18n.T("presign.key"), key, 0, nil, nil).
+		AddDropDown(i18n.T("presign.expiry"), labels, defaultPresignExpiry, nil).
+		AddButton(i18n.T("presign.generate"), func() { m.done(true) }).
+		AddButton(i18n.T("action.cancel"), func() { m.done(false) })
+	m.form.SetBorder(true).SetTitle(" " + i18n.T("presign.title") + " ")
+	m.form.SetCancelFunc(func() { m.done(false) })
+
+	// 置中顯示
+	m.layout = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(m.form, 11, 0, true).
+			AddItem(nil, 0, 1, false), 0, 3, true).
+		AddItem(nil, 0, 1, false)
+	return m
+}
+
+// Primitive 回傳 tview 元件。
+func (m *PresignModal) Primitive() *tview.Flex {
+	return m.layout
+}
+
+// SetOnDone 註冊完成回呼；ok 為 false 表示取消。
+func (m *PresignModal) SetOnDone(fn func(req PresignRequest, ok bool)) {
+	m.onDone = fn
+}
+
+func (m *PresignModal) done(ok bool) {
+	if m.onDone == nil {
+		return
+	}
+	method, expiry := http.MethodGet, presignExpiries[defaultPresignExpiry].value
+	if i, _ := m.form.GetFormItem(0).(*tview.DropDown).GetCurrentOption(); i == 1 {
+		method = http.MethodPut
+	}
+	if i, _ := m.form.GetFormItem(2).(*tview.DropDown).GetCurrentOption(); i >= 0 {
+		expiry = presignExpiries[i].value
+	}
+	m.onDone(PresignRequest{
+		Method:  method,
+		Key:     m.form.GetFormItem(1).(*tview.InputField).GetText(),
+		Expires: expiry,
+	}, ok)
+}
@@ -0,0 +1,149 @@
+package modals
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// RecordForm 提供新增與編輯 DNS record 的表單；Values 每行一個值，填寫 alias target 時忽略 TTL 與 Values。
+// 路由政策與 health check 不在表單中編輯，沿用原 record 的設定。
+type RecordForm struct {
+	original models.Route53Record
+	form     *tview.Form
+	name     *tview.InputField
+	kind     *tview.DropDown
+	ttl      *tview.InputField
+	values   *tview.TextArea
+	alias    *tview.InputField
+	zoneID   *tview.InputField
+	health   *tview.Checkbox
+	message  *tview.TextView
+	layout   *tview.Flex
+	types    []string
+	onSave   func(record models.Route53Record) error
+	onCancel func()
+}
+
+// NewRecordForm 建立 record 表單，types 為可選的 record 類型，record 為預設內容。
+func NewRecordForm(title string, types []string, record models.Route53Record) *RecordForm {
+	f := &RecordForm{
+		original: record,
+		form:     tview.NewForm(),
+		name:     tview.NewInputField().SetLabel(i18n.T("route53.field_name")).SetText(record.Name).SetFieldWidth(0),
+		kind:     tview.NewDropDown().SetLabel(i18n.T("route53.field_type")),
+		ttl:      tview.NewInputField().SetLabel("TTL").SetFieldWidth(12).SetAcceptanceFunc(tview.InputFieldInteger),
+		values:   tview.NewTextArea().SetText(strings.Join(record.Values, "\n"), false),
+		alias:    tview.NewInputField().SetLabel(i18n.T("route53.field_alias")).SetText(record.AliasTarget).SetFieldWidth(0),
+		zoneID:   tview.NewInputField().SetLabel(i18n.T("route53.field_alias_zone")).SetText(record.AliasZoneID).SetFieldWidth(0),
+		health:   tview.NewCheckbox().SetLabel(i18n.T("route53.field_evaluate_health")).SetChecked(record.EvaluateTargetHealth),
+		message:  tview.NewTextView().SetDynamicColors(true),
+		types:    types,
+	}
+	f.kind.SetOptions(types, nil)
+	if i := slices.Index(types, record.Type); i >= 0 {
+		f.kind.SetCurrentOption(i)
+	} else {
+		f.kind.SetCurrentOption(0)
+	}
+	if record.TTL > 0 || record.AliasTarget == "" {
+		ttl := record.TTL
+		if ttl == 0 {
+			ttl = 300
+		}
+		f.ttl.SetText(strconv.FormatInt(ttl, 10))
+	}
+	f.values.SetLabel(i18n.T("route53.field_values")).SetSize(5, 0)
+	f.message.SetText("[gray]" + i18n.T("route53.form_hint") + "[-]")
+
+	f.form.AddFormItem(f.name).
+		AddFormItem(f.kind).
+		AddFormItem(f.ttl).
+		AddFormItem(f.values).
+		AddFormItem(f.alias).
+		AddFormItem(f.zoneID).
+		AddFormItem(f.health).
+		AddButton(i18n.T("action.ok"), f.save).
+		AddButton(i18n.T("action.cancel"), f.cancel)
+	f.form.SetCancelFunc(f.cancel)
+
+	frame := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(f.form, 0, 1, true).
+		AddItem(f.message, 2, 0, false)
+	frame.SetBorder(true).SetTitle(" " + title + " ")
+
+	// 置中顯示
+	f.layout = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(frame, 24, 0, true).
+			AddItem(nil, 0, 1, false), 0, 4, true).
+		AddItem(nil, 0, 1, false)
+	return f
+}
+
+// Primitive 回傳 tview 元件。
+func (f *RecordForm) Primitive() *tview.Flex {
+	return f.layout
+}
+
+// SetOnSave 註冊儲存回呼；回傳錯誤時表單保持開啟並顯示錯誤。
+func (f *RecordForm) SetOnSave(fn func(record models.Route53Record) error) {
+	f.onSave = fn
+}
+
+// SetOnCancel 註冊取消回呼。
+func (f *RecordForm) SetOnCancel(fn func()) {
+	f.onCancel = fn
+}
+
+func (f *RecordForm) save() {
+	record, err := f.record()
+	if err == nil && f.onSave != nil {
+		err = f.onSave(record)
+	}
+	if err != nil {
+		f.message.SetText("[red]" + tview.Escape(err.Error()) + "[-]")
+	}
+}
+
+func (f *RecordForm) cancel() {
+	if f.onCancel != nil {
+		f.onCancel()
+	}
+}
+
+func (f *RecordForm) record() (models.Route53Record, error) {
+	_, kind := f.kind.GetCurrentOption()
+	record := f.original
+	record.Name = strings.TrimSpace(f.name.GetText())
+	record.Type = kind
+	record.TTL, record.Values = 0, nil
+	record.AliasTarget = strings.TrimSpace(f.alias.GetText())
+	record.AliasZoneID = strings.TrimSpace(f.zoneID.GetText())
+	record.EvaluateTargetHealth = f.health.IsChecked()
+	if record.AliasTarget != "" {
+		return record, nil
+	}
+	if text := strings.TrimSpace(f.ttl.GetText()); text != "" {
+		ttl, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return record, fmt.Errorf("invalid TTL %q", text)
+		}
+		record.TTL = ttl
+	}
+	for _, line := range strings.Split(f.values.GetText(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			record.Values = append(record.Values, line)
+		}
+	}
+	return record, nil
+}
@@ -0,0 +1,79 @@
+package modals
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TextModal 以可捲動的文字框顯示較長的內容（例如 URL 或 JSON），Esc/Enter/q 關閉。
+type TextModal struct {
+	text    *tview.TextView
+	layout  *tview.Flex
+	onClose func()
+	onRune  func(r rune) bool
+}
+
+// NewTextModal 建立文字對話框；text 可包含 tview 顏色標籤。
+func NewTextModal(title, text string) *TextModal {
+	m := &TextModal{
+		text: tview.NewTextView().
+			SetDynamicColors(true).
+			SetWrap(true).
+			SetScrollable(true).
+			SetText(text),
+	}
+	m.text.SetBorder(true).SetTitle(" " + title + " ")
+	m.text.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape, tcell.KeyEnter:
+			m.close()
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'q' {
+				m.close()
+				return nil
+			}
+			if m.onRune != nil && m.onRune(event.Rune()) {
+				return nil
+			}
+		}
+		return event
+	})
+
+	// 置中顯示
+	m.layout = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(m.text, 0, 3, true).
+			AddItem(nil, 0, 1, false), 0, 4, true).
+		AddItem(nil, 0, 1, false)
+	return m
+}
+
+// Primitive 回傳 tview 元件。
+func (m *TextModal) Primitive() *tview.Flex {
+	return m.layout
+}
+
+// SetOnClose 註冊關閉回呼。
+func (m *TextModal) SetOnClose(fn func()) {
+	m.onClose = fn
+}
+
+// SetOnRune 註冊額外按鍵處理；回傳 true 表示已處理。
+func (m *TextModal) SetOnRune(fn func(r rune) bool) {
+	m.onRune = fn
+}
+
+// SetText 更新內容。
+func (m *TextModal) SetText(text string) {
+	m.text.SetText(text)
+}
+
+func (m *TextModal) close() {
+	if m.onClose != nil {
+		m.onClose()
+	}
+}
@@ -0,0 +1,86 @@
+package modals
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+)
+
+// YAMLEditor 以多行文字框編輯 YAML 設定，Ctrl-S 送出、Esc 取消；驗證錯誤顯示於下方。
+type YAMLEditor struct {
+	area     *tview.TextArea
+	message  *tview.TextView
+	layout   *tview.Flex
+	onSave   func(text string)
+	onCancel func()
+}
+
+// NewYAMLEditor 建立 YAML 編輯器。
+func NewYAMLEditor(title, text string) *YAMLEditor {
+	e := &YAMLEditor{
+		area:    tview.NewTextArea().SetText(text, false),
+		message: tview.NewTextView().SetDynamicColors(true),
+	}
+	e.message.SetText("[gray]" + i18n.T("rules.editor_hint") + "[-]")
+
+	frame := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(e.area, 0, 1, true).
+		AddItem(e.message, 2, 0, false)
+	frame.SetBorder(true).SetTitle(" " + title + " ")
+	frame.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyCtrlS:
+			if e.onSave != nil {
+				e.onSave(e.area.GetText())
+			}
+			return nil
+		case tcell.KeyEscape:
+			if e.onCancel != nil {
+				e.onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+
+	// 置中顯示
+	e.layout = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false).
+			AddItem(frame, 0, 1, true).
+			AddItem(nil, 1, 0, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+	return e
+}
+
+// Primitive 回傳 tview 元件。
+func (e *YAMLEditor) Primitive() *tview.Flex {
+	return e.layout
+}
+
+// SetOnSave 註冊送出回呼（Ctrl-S）。
+func (e *YAMLEditor) SetOnSave(fn func(text string)) {
+	e.onSave = fn
+}
+
+// SetOnCancel 註冊取消回呼（Esc）。
+func (e *YAMLEditor) SetOnCancel(fn func()) {
+	e.onCancel = fn
+}
+
+// SetError 於編輯器下方顯示錯誤；err 為 nil 時恢復操作提示。
+func (e *YAMLEditor) SetError(err error) {
+	if err == nil {
+		e.message.SetText("[gray]" + i18n.T("rules.editor_hint") + "[-]")
+		return
+	}
+	e.message.SetText("[red]" + tview.Escape(err.Error()) + "[-]")
+}
+
+// SetInfo 於編輯器下方顯示提示訊息。
+func (e *YAMLEditor) SetInfo(message string) {
+	e.message.SetText("[yellow]" + tview.Escape(message) + "[-]")
+}
@@ -4,6 +4,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -36,13 +37,27 @@ type Root struct {
 
 	listView   *list.View
 	detailView *detail.View
-	statusBar  *widgets.StatusBar
-	searchBox  *tview.InputField
+	// detailPages 切換資源詳情與 S3 物件預覽
+	detailPages *tview.Pages
+	previewTab  *detail.PreviewTab
+	statusBar   *widgets.StatusBar
+	searchBox   *tview.InputField
 
 	ctx         context.Context
 	currentKind resource.Kind
 	themeCycle  []string
 	lastMessage string
+	loadSeq     atomic.Uint64 // 每次載入清單遞增，較舊的查詢結果不再套用
+	// transferCancel 取消進行中的 S3 傳輸；nil 表示沒有傳輸（只在 UI goroutine 存取）
+	transferCancel context.CancelFunc
+	// screen 為最近一次繪製使用的 tcell 畫面，供 OSC 52 剪貼簿使用（只在 UI goroutine 存取）
+	screen tcell.Screen
+	// usageReturn 為關閉用量分析後要回到的資源類型
+	usageReturn resource.Kind
+	// route53Return 為關閉 health check、VPC 或 Resolver 清單後要回到的 Route53 清單
+	route53Return resource.Kind
+	// commands 為資源類型專屬指令的處理函式，按鍵由各 Definition.Keys 指定
+	commands map[resource.Command]func()
 }
 
 // NewRoot 建立 Root，並套用預設主題與內容。
@@ -67,13 +82,21 @@ func NewRoot(cfg config.Config, mgr *theme.Manager, st *state.Store, svc *resour
 		currentKind: resource.KindEC2,
 		themeCycle:  []string{"dark", "light", "high-contrast"},
 	}
+	r.commands = r.commandHandlers()
 	r.initLayout()
+	svc.SetOnUpdate(func(kind resource.Kind) {
+		r.showCached(kind)
+	})
 	return r, nil
 }
 
 func (r *Root) initLayout() {
 	r.listView = list.NewView()
 	r.detailView = detail.NewView()
+	r.previewTab = detail.NewPreviewTab()
+	r.detailPages = tview.NewPages().
+		AddPage("overview", r.detailView.Primitive(), true, true).
+		AddPage("preview", r.previewTab.Primitive(), true, false)
 	r.statusBar = widgets.NewStatusBar()
 	r.searchBox = tview.NewInputField().
 		SetLabel(i18n.T("search.label")).
@@ -81,7 +104,7 @@ func (r *Root) initLayout() {
 
 	columns := tview.NewFlex().
 		AddItem(r.listView.Primitive(), 0, 2, true).
-		AddItem(r.detailView.Primitive(), 0, 3, false)
+		AddItem(r.detailPages, 0, 3, false)
 
 	r.layout = tview.NewFlex().
 		SetDirection(tview.FlexRow).
@@ -98,10 +121,14 @@ func (r *Root) initLayout() {
 
 	r.searchBox.SetDoneFunc(func(key tcell.Key) {
 		r.app.SetFocus(r.listView.Primitive())
-		r.reload()
+		go r.reload()
 	})
 
 	r.app.SetInputCapture(r.handleKeys)
+	r.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		r.screen = screen
+		return false
+	})
 }
 
 // Run 開啟 UI loop，並可透過 context 取消。
@@ -112,6 +139,7 @@ func (r *Root) Run(ctx context.Context) error {
 	r.ctx = ctx
 
 	go r.reload()
+	go r.tickDataAge(ctx)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -140,6 +168,7 @@ func (r *Root) handleKeys(event *tcell.EventKey) *tcell.EventKey {
 	isMainPage := focus == r.searchBox ||
 		focus == r.listView.Primitive() ||
 		focus == r.detailView.Primitive() ||
+		focus == r.previewTab.Primitive() ||
 		focus == r.statusBar.Primitive()
 
 	// 如果不在主頁面（例如在 Modal 中），讓元件自己處理按鍵
@@ -147,6 +176,10 @@ func (r *Root) handleKeys(event *tcell.EventKey) *tcell.EventKey {
 		return event
 	}
 
+	if focus == r.previewTab.Primitive() {
+		return r.handlePreviewKeys(event)
+	}
+
 	// 如果焦點在搜尋欄，處理特殊鍵
 	if focus == r.searchBox {
 		switch event.Key() {
@@ -166,22 +199,19 @@ func (r *Root) handleKeys(event *tcell.EventKey) *tcell.EventKey {
 
 	switch event.Key() {
 	case tcell.KeyRune:
-		switch event.Rune() {
-		case '1':
-			r.changeKind(resource.KindEC2)
-			return nil
-		case '2':
-			r.changeKind(resource.KindRDS)
-			return nil
-		case '3':
-			r.changeKind(resource.KindS3)
+		// 數字鍵切換資源類型（由 Registry 定義）
+		if def, ok := r.service.Registry().ByHotkey(event.Rune()); ok {
+			r.changeKind(def.Kind)
 			return nil
-		case '4':
-			r.changeKind(resource.KindLambda)
-			return nil
-		case '5':
-			r.changeKind(resource.KindRoute53)
+		}
+		// 資源類型專屬按鍵（由 Registry 定義）
+		if cmd, ok := r.service.Registry().Command(r.currentKind, event.Rune()); ok {
+			if run := r.commands[cmd]; run != nil {
+				run()
+			}
 			return nil
+		}
+		switch event.Rune() {
 		case '/':
 			r.app.SetFocus(r.searchBox)
 			return nil
@@ -198,7 +228,55 @@ func (r *Root) handleKeys(event *tcell.EventKey) *tcell.EventKey {
 			r.showActionPanel()
 			return nil
 		case 'g':
-			go r.reload()
+			go r.refresh()
+			return nil
+		case 'n':
+			go r.loadMore(false)
+			return nil
+		case 'G':
+			go r.loadMore(true)
+			return nil
+		case 'u':
+			r.startUpload()
+			return nil
+		case 'x':
+			r.cancelTransfer()
+			return nil
+		case 'V':
+			r.showVersions()
+			return nil
+		case ' ':
+			r.toggleMark()
+			return nil
+		case 'U':
+			r.startPresign()
+			return nil
+		case 'A':
+			r.startAnalyze()
+			return nil
+		case 'i':
+			r.startZoneImport()
+			return nil
+		case 'H':
+			r.showHealthChecks()
+			return nil
+		case 'P':
+			r.openZoneView(resource.KindRoute53ZoneVPCs)
+			return nil
+		case 'F':
+			r.openZoneView(resource.KindRoute53Resolver)
+			return nil
+		case 'o':
+			r.openFunctionView(resource.KindLambdaTriggers)
+			return nil
+		case 'R':
+			r.startRulesEditor()
+			return nil
+		case 'c':
+			r.startRecordCreate()
+			return nil
+		case 'S':
+			r.startRecordReview()
 			return nil
 		case '?':
 			r.showHelp()
@@ -223,6 +301,26 @@ func (r *Root) handleKeys(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+// commandHandlers 回傳各資源類型專屬指令的處理函式；新增指令時在此對應，按鍵則由 Definition.Keys 指定。
+func (r *Root) commandHandlers() map[resource.Command]func() {
+	return map[resource.Command]func(){
+		resource.CommandDownload:        r.startDownload,
+		resource.CommandPreview:         r.openPreview,
+		resource.CommandDelete:          r.startDelete,
+		resource.CommandUsageExport:     r.exportUsage,
+		resource.CommandCodeDownload:    r.startCodeDownload,
+		resource.CommandPackageBrowser:  r.startPackageBrowser,
+		resource.CommandInvoke:          r.startInvokeWorkbench,
+		resource.CommandConcurrency:     r.startConcurrencyPanel,
+		resource.CommandConfigEdit:      r.startLambdaConfigEditor,
+		resource.CommandAliasRouting:    r.startAliasRouting,
+		resource.CommandRecordEdit:      r.startRecordEdit,
+		resource.CommandRecordDelete:    r.toggleRecordDelete,
+		resource.CommandZoneExport:      r.exportZoneFile,
+		resource.CommandVPCDisassociate: r.startVPCDisassociate,
+	}
+}
+
 func (r *Root) changeKind(kind resource.Kind) {
 	if r.currentKind == kind {
 		return
@@ -231,33 +329,186 @@ func (r *Root) changeKind(kind resource.Kind) {
 	go r.reload()
 }
 
+// reload 先顯示目前 profile/region 的快取清單，沒有快取或已超過 TTL 時再於背景重新查詢。
 func (r *Root) reload() {
-	r.setStatus(i18n.T("app.loading"))
+	r.load(false)
+}
+
+// refresh 忽略快取 TTL，強制重新查詢目前清單。
+func (r *Root) refresh() {
+	r.load(true)
+}
+
+func (r *Root) load(force bool) {
+	seq := r.loadSeq.Add(1)
+	kind := r.currentKind
+	matcher := search.NewMatcher(r.searchBox.GetText())
+
+	snapshot, cached := r.service.Cached(kind, matcher)
+	fetch := force || !cached || snapshot.Stale
+	r.app.QueueUpdateDraw(func() {
+		if r.loadSeq.Load() != seq {
+			return
+		}
+		if cached {
+			r.showItems(kind, snapshot, fetch)
+			return
+		}
+		r.statusBar.SetDataAge(time.Time{}, false)
+		r.setStatus(i18n.T("app.loading"))
+	})
+	if !fetch {
+		return
+	}
 
-	query := r.searchBox.GetText()
-	matcher := search.NewMatcher(query)
 	ctx, cancel := context.WithTimeout(r.ctx, 20*time.Second)
 	defer cancel()
 
-	items, err := r.service.ListItems(ctx, r.currentKind, matcher)
+	items, err := r.service.ListItems(ctx, kind, matcher)
+	fresh, ok := r.service.Cached(kind, matcher)
+	if !ok {
+		fresh = resource.ListSnapshot{Items: items, FetchedAt: time.Now()}
+	}
 	r.app.QueueUpdateDraw(func() {
+		// 查詢期間已切換類型、profile/region 或重新載入時，結果只保留在快取中
+		if r.loadSeq.Load() != seq {
+			return
+		}
 		if err != nil {
+			if cached {
+				r.statusBar.SetDataAge(snapshot.FetchedAt, false)
+			}
 			r.setStatus(fmt.Sprintf("[red]%v[-]", err))
 			return
 		}
-		r.listView.SetItems(items)
-		count := r.listView.Count()
-		r.setStatus(i18n.Tf("app.loaded", count))
-		if count > 0 {
+		r.showItems(kind, fresh, false)
+	})
+}
+
+// loadMore 取得分頁清單的下一頁；all 為 true 時持續取到最後一頁並選取最後一列。
+// 期間切換類型或重新載入時停止，已取得的頁面仍保留在快取中。
+func (r *Root) loadMore(all bool) {
+	kind := r.currentKind
+	def, ok := r.service.Registry().Lookup(kind)
+	if !ok || def.Page == nil {
+		return
+	}
+	matcher := search.NewMatcher(r.searchBox.GetText())
+	if snapshot, ok := r.service.Cached(kind, matcher); !ok || !snapshot.More {
+		return
+	}
+	seq := r.loadSeq.Add(1)
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+
+	r.app.QueueUpdateDraw(func() {
+		r.setStatus(i18n.T("app.loading"))
+	})
+
+	var err error
+	if all {
+		err = r.service.LoadAll(ctx, kind, func() {
+			if r.loadSeq.Load() != seq {
+				cancel()
+				return
+			}
+			snapshot, _ := r.service.Cached(kind, search.NewMatcher(""))
+			r.app.QueueUpdateDraw(func() {
+				if r.loadSeq.Load() == seq {
+					r.setStatus(i18n.Tf("app.loading_pages", len(snapshot.Items)))
+				}
+			})
+		})
+	} else {
+		_, err = r.service.LoadMore(ctx, kind)
+	}
+
+	r.app.QueueUpdateDraw(func() {
+		if r.loadSeq.Load() != seq {
+			return
+		}
+		if err != nil {
+			r.setStatus(fmt.Sprintf("[red]%v[-]", err))
+			return
+		}
+		snapshot, ok := r.service.Cached(kind, matcher)
+		if !ok {
+			return
+		}
+		r.showItems(kind, snapshot, false)
+		if all {
+			r.listView.SelectLast()
 			if item, ok := r.listView.CurrentItem(); ok {
 				go r.loadDetail(item)
 			}
-		} else {
-			r.detailView.SetDetail(models.DetailView{})
 		}
 	})
 }
 
+// showItems 顯示清單並盡量保留原本選取的項目，再載入選取項目的詳情。
+// 分頁清單會提示是否還有下一頁，全部取完時顯示總數。
+func (r *Root) showItems(kind resource.Kind, snapshot resource.ListSnapshot, refreshing bool) {
+	selected, hasSelection := r.listView.CurrentItem()
+	def, ok := r.service.Registry().Lookup(kind)
+	if ok {
+		r.listView.SetColumns(def.Columns)
+	}
+	paged := ok && def.Page != nil
+	r.listView.SetItems(snapshot.Items)
+	if hasSelection {
+		r.listView.SelectID(selected.ID)
+	}
+	r.statusBar.SetDataAge(snapshot.FetchedAt, refreshing)
+
+	count := r.listView.Count()
+	switch {
+	case snapshot.More:
+		r.setStatus(i18n.Tf("app.loaded_more", count))
+	case paged:
+		r.setStatus(i18n.Tf("app.loaded_total", count))
+	default:
+		r.setStatus(i18n.Tf("app.loaded", count))
+	}
+	if count == 0 {
+		r.detailView.SetDetail(models.DetailView{})
+		return
+	}
+	if item, ok := r.listView.CurrentItem(); ok {
+		go r.loadDetail(item)
+	}
+}
+
+// showCached 只以快取內容重繪清單，不觸發查詢（背景補齊欄位時使用）。
+func (r *Root) showCached(kind resource.Kind) {
+	r.app.QueueUpdateDraw(func() {
+		if r.currentKind != kind {
+			return
+		}
+		snapshot, ok := r.service.Cached(kind, search.NewMatcher(r.searchBox.GetText()))
+		if !ok {
+			return
+		}
+		r.showItems(kind, snapshot, false)
+	})
+}
+
+// tickDataAge 定期重繪狀態列，讓資料時間持續更新。
+func (r *Root) tickDataAge(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.app.QueueUpdateDraw(func() {
+				r.setStatus(r.lastMessage)
+			})
+		}
+	}
+}
+
 func (r *Root) showDetail(item models.ListItem) {
 	go r.loadDetail(item)
 }
@@ -267,6 +518,9 @@ func (r *Root) loadDetail(item models.ListItem) {
 	defer cancel()
 	detail, err := r.service.Detail(ctx, r.currentKind, item.ID)
 	r.app.QueueUpdateDraw(func() {
+		if r.app.GetFocus() != r.previewTab.Primitive() {
+			r.detailPages.SwitchToPage("overview")
+		}
 		if err != nil {
 			r.detailView.SetDetail(models.DetailView{
 				Overview: map[string]string{
@@ -335,6 +589,13 @@ func (r *Root) refreshTheme() {
 	detailView.SetTitleColor(text)
 	detailView.SetTextColor(text)
 
+	previewView := r.previewTab.Primitive()
+	previewView.SetBackgroundColor(bg)
+	previewView.SetBorderColor(border)
+	previewView.SetTitleColor(text)
+	previewView.SetTextColor(text)
+	r.detailPages.SetBackgroundColor(bg)
+
 	// 更新狀態列
 	statusView := r.statusBar.Primitive()
 	statusView.SetBackgroundColor(contrastBg)
@@ -367,6 +628,7 @@ func (r *Root) refreshLabels() {
 
 	// 更新詳情頁標題
 	r.detailView.Primitive().SetTitle(i18n.T("ui.resource_detail"))
+	r.previewTab.RefreshLabels()
 }
 
 func (r *Root) showHelp() {
@@ -387,19 +649,28 @@ func (r *Root) showActionPanel() {
 		return
 	}
 
-	actions := modals.AvailableActions(item.Type)
-	if len(actions) == 0 {
+	def, _ := r.service.Registry().Lookup(r.currentKind)
+	if len(def.Actions) == 0 {
 		r.setStatus(fmt.Sprintf("[yellow]No actions available for %s[-]", item.Type))
 		return
 	}
 
+	// 顯示文字（已 i18n）對應回操作識別碼
+	labels := make([]string, 0, len(def.Actions))
+	byLabel := make(map[string]resource.Action, len(def.Actions))
+	for _, action := range def.Actions {
+		label := actionLabel(action)
+		labels = append(labels, label)
+		byLabel[label] = action
+	}
+
 	panel := modals.NewActionPanel()
-	panel.SetActions(actions, func(action string) {
+	panel.SetActions(labels, func(label string) {
 		r.pages.RemovePage("action-panel")
-		if action == "" {
+		if label == "" {
 			return
 		}
-		r.executeAction(item, action)
+		r.executeAction(item, byLabel[label], label)
 	})
 
 	// 建立置中的 modal
@@ -415,25 +686,139 @@ func (r *Root) showActionPanel() {
 	r.pages.AddAndSwitchToPage("action-panel", modal, true)
 }
 
-// executeAction 執行操作（帶確認）。
-func (r *Root) executeAction(item models.ListItem, action string) {
-	// 顯示確認對話框
+// executeAction 執行操作（帶確認）。RDS 停止前會先檢查限制，警告會顯示在確認對話框中。
+func (r *Root) executeAction(item models.ListItem, action resource.Action, label string) {
+	kind := r.currentKind
+
+	r.setStatus(i18n.T("action.checking"))
+	go func() {
+		ctx, cancel := context.WithTimeout(r.ctx, 20*time.Second)
+		defer cancel()
+		allowed, warning, err := r.service.PrecheckAction(ctx, kind, item.ID, action)
+		r.app.QueueUpdateDraw(func() {
+			if err != nil {
+				r.setStatus(fmt.Sprintf("[red]%v[-]", err))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+				return
+			}
+			if !allowed {
+				r.setStatus("")
+				r.showResult(func(m *modals.ResultModal, onOK func()) {
+					m.ShowInfo(i18n.Tf("action.not_allowed", label, item.Name, noticeText(warning)), onOK)
+				})
+				return
+			}
+			r.confirmAction(item, kind, action, label, noticeText(warning))
+		})
+	}()
+}
+
+// noticeText 依目前語言回傳 service 提示的文字。
+func noticeText(n resource.Notice) string {
+	if n.Key != "" {
+		return i18n.Tf(n.Key, n.Args...)
+	}
+	return n.Text
+}
+
+// confirmAction 顯示確認對話框，確認後於背景執行操作。
+func (r *Root) confirmAction(item models.ListItem, kind resource.Kind, action resource.Action, label, warning string) {
+	message := fmt.Sprintf("%s %s: %s?", label, item.Type, item.Name)
+	if warning != "" {
+		message += fmt.Sprintf("\n\n[yellow]⚠ %s[-]", warning)
+	}
+
 	confirm := modals.NewConfirmModal()
 	confirm.Show(
 		i18n.T("action.confirm"),
-		fmt.Sprintf("%s %s: %s?", action, item.Type, item.Name),
+		message,
 		func(confirmed bool) {
 			r.pages.RemovePage("confirm")
 			if !confirmed {
+				r.setStatus("")
 				return
 			}
-			r.setStatus(fmt.Sprintf("Executing %s on %s...", action, item.Name))
-			// TODO: 實際執行操作（呼叫 ops 層）
+			r.runAction(item, kind, action, label)
 		},
 	)
 	r.pages.AddAndSwitchToPage("confirm", confirm.Primitive(), true)
 }
 
+// runAction 於背景執行操作並在狀態列顯示進度，完成後以 ResultModal 顯示結果。
+func (r *Root) runAction(item models.ListItem, kind resource.Kind, action resource.Action, label string) {
+	r.setStatus(i18n.Tf("action.executing", label, item.Name))
+
+	go func() {
+		ctx, cancel := context.WithCancel(r.ctx)
+		defer cancel()
+
+		done := make(chan struct{})
+		onWait := func(target string) {
+			go r.trackActionProgress(done, item.Name, target)
+		}
+		result, err := r.service.ExecuteAction(ctx, kind, item.ID, action, onWait)
+		close(done)
+
+		r.app.QueueUpdateDraw(func() {
+			if err != nil {
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+				return
+			}
+			message := i18n.Tf("action.completed", label, item.Name)
+			if result.MessageKey != "" {
+				message += "\n\n" + i18n.Tf(result.MessageKey, result.MessageArgs...)
+			}
+			if result.Message != "" {
+				message += "\n\n" + result.Message
+			}
+			r.setStatus(i18n.Tf("action.completed", label, item.Name))
+			r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+			if (result.TargetState != "" || result.Refresh) && r.currentKind == kind {
+				go r.refresh()
+			}
+		})
+	}()
+}
+
+// trackActionProgress 每秒更新狀態列，顯示等待目標狀態的經過時間，直到 done 關閉。
+// 已排入的更新執行時若 done 已關閉則略過，避免蓋掉 runAction 的完成訊息。
+func (r *Root) trackActionProgress(done <-chan struct{}, name, target string) {
+	start := time.Now()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			elapsed := time.Since(start).Truncate(time.Second)
+			r.app.QueueUpdateDraw(func() {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				r.setStatus(i18n.Tf("action.waiting", name, target, elapsed))
+			})
+		}
+	}
+}
+
+// showResult 顯示結果對話框，按下確定後關閉。
+func (r *Root) showResult(show func(m *modals.ResultModal, onOK func())) {
+	result := modals.NewResultModal()
+	show(result, func() {
+		r.pages.RemovePage("result")
+	})
+	r.pages.AddAndSwitchToPage("result", result.Primitive(), true)
+}
+
+// actionLabel 回傳操作的顯示文字（已 i18n）。
+func actionLabel(action resource.Action) string {
+	return i18n.T("action." + string(action))
+}
+
 // showProfilePicker 顯示 AWS Profile 選擇器。
 // 選擇 Profile 後會自動切換到該 Profile 對應的 Region。
 func (r *Root) showProfilePicker() {
@@ -484,13 +869,18 @@ func (r *Root) handleEnter() {
 			// 檔案：顯示詳情
 			r.showDetail(item)
 		}
+	case resource.KindS3Usage:
+		r.usageEnter()
 	case resource.KindRoute53:
 		// 進入 hosted zone 查看 records
 		r.service.SetCurrentZone(item.ID, item.Name)
 		r.currentKind = resource.KindRoute53Records
 		go r.reload()
+	case resource.KindLambda:
+		// 進入函式查看 aliases 與版本（$LATEST 顯示函式完整詳情）
+		r.openFunctionView(resource.KindLambdaVersions)
 	default:
-		// EC2, RDS, Lambda, Route53Records：顯示詳情
+		// EC2, RDS, Route53Records：顯示詳情
 		r.showDetail(item)
 	}
 }
@@ -507,10 +897,22 @@ func (r *Root) handleBackspace() {
 			r.currentKind = resource.KindS3
 			go r.reload()
 		}
+	case resource.KindS3Versions:
+		r.closeVersions()
+	case resource.KindS3Usage:
+		r.usageUp()
 	case resource.KindRoute53Records:
 		r.service.ClearCurrentZone()
 		r.currentKind = resource.KindRoute53
 		go r.reload()
+	case resource.KindRoute53HealthChecks:
+		r.closeHealthChecks()
+	case resource.KindRoute53ZoneVPCs, resource.KindRoute53Resolver:
+		r.closeZoneView()
+	default:
+		if isFunctionView(r.currentKind) {
+			r.closeFunctionView()
+		}
 	}
 }
 
@@ -521,9 +923,21 @@ func (r *Root) handleEscape() {
 		r.service.SetCurrentBucket("")
 		r.currentKind = resource.KindS3
 		go r.reload()
+	case resource.KindS3Versions:
+		r.closeVersions()
+	case resource.KindS3Usage:
+		r.closeUsage()
 	case resource.KindRoute53Records:
 		r.service.ClearCurrentZone()
 		r.currentKind = resource.KindRoute53
 		go r.reload()
+	case resource.KindRoute53HealthChecks:
+		r.closeHealthChecks()
+	case resource.KindRoute53ZoneVPCs, resource.KindRoute53Resolver:
+		r.closeZoneView()
+	default:
+		if isFunctionView(r.currentKind) {
+			r.closeFunctionView()
+		}
 	}
 }
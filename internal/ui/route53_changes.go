@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// startRecordCreate 開啟新增 record 的表單；在 VPC 關聯清單中改為關聯 VPC，在 Lambda 版本清單中改為發佈新版本。
+func (r *Root) startRecordCreate() {
+	switch r.currentKind {
+	case resource.KindRoute53ZoneVPCs:
+		r.startVPCAssociate()
+		return
+	case resource.KindLambdaVersions:
+		r.startPublishVersion()
+		return
+	}
+	if r.currentKind != resource.KindRoute53Records {
+		return
+	}
+	r.openRecordForm(i18n.Tf("route53.create_title", r.service.CurrentZoneName()), "", models.Route53Record{Type: "A"})
+}
+
+// startRecordEdit 開啟編輯目前選取 record 的表單（含已暫存的修改）。
+func (r *Root) startRecordEdit() {
+	if r.currentKind != resource.KindRoute53Records {
+		return
+	}
+	item, ok := r.listView.CurrentItem()
+	if !ok {
+		r.setStatus("[yellow]No resource selected[-]")
+		return
+	}
+	record, ok := r.service.EditableRecord(item.ID)
+	if !ok {
+		r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.T("route53.deleted_record")))
+		return
+	}
+	r.openRecordForm(i18n.Tf("route53.edit_title", record.Name, record.Type), item.ID, record)
+}
+
+// openRecordForm 顯示 record 表單；儲存時驗證並暫存變更，驗證失敗時表單保持開啟。
+func (r *Root) openRecordForm(title, originalID string, record models.Route53Record) {
+	form := modals.NewRecordForm(title, resource.RecordTypes(), record)
+	form.SetOnCancel(func() {
+		r.pages.RemovePage("record-form")
+	})
+	form.SetOnSave(func(record models.Route53Record) error {
+		if err := r.service.StageRecord(originalID, record); err != nil {
+			return err
+		}
+		r.pages.RemovePage("record-form")
+		r.setStatus(i18n.Tf("route53.staged", r.service.StagedRecordCount()))
+		go r.showCached(resource.KindRoute53Records)
+		return nil
+	})
+	r.pages.AddAndSwitchToPage("record-form", form.Primitive(), true)
+}
+
+// toggleRecordDelete 將選取的 record 暫存為刪除，再按一次取消。
+func (r *Root) toggleRecordDelete() {
+	item, ok := r.listView.CurrentItem()
+	if !ok {
+		r.setStatus("[yellow]No resource selected[-]")
+		return
+	}
+	if _, err := r.service.ToggleRecordDelete(item.ID); err != nil {
+		r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+		return
+	}
+	r.setStatus(i18n.Tf("route53.staged", r.service.StagedRecordCount()))
+	go r.showCached(resource.KindRoute53Records)
+}
+
+// startRecordReview 以目前的 records 產生變更批次並顯示差異。
+func (r *Root) startRecordReview() {
+	if r.currentKind != resource.KindRoute53Records || r.transferBusy() {
+		return
+	}
+	if r.service.StagedRecordCount() == 0 {
+		r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.T("route53.nothing_staged")))
+		return
+	}
+	r.setStatus(i18n.T("route53.planning"))
+	go func() {
+		plan, err := r.service.PlanRecordChanges(r.ctx)
+		r.app.QueueUpdateDraw(func() {
+			if err != nil {
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+				return
+			}
+			r.setStatus("")
+			r.reviewRecordChanges(plan)
+		})
+	}()
+}
+
+// reviewRecordChanges 顯示變更批次與差異；y 送出，c 捨棄所有暫存變更（匯入時不適用），Esc 關閉。
+func (r *Root) reviewRecordChanges(plan resource.RecordChangePlan) {
+	var b strings.Builder
+	if len(plan.Changes) == 0 {
+		fmt.Fprintf(&b, "%s\n", i18n.T("route53.no_changes"))
+	} else {
+		inserted, deleted := diff.Stats(plan.Diff)
+		fmt.Fprintf(&b, "%s\n\n", i18n.Tf("route53.review_stats", plan.ZoneName, len(plan.Changes), inserted, deleted))
+		for _, change := range plan.Changes {
+			fmt.Fprintf(&b, "  %-6s %s %s\n", change.Action, change.Record.Name, change.Record.Type)
+		}
+		b.WriteString("\n")
+		b.WriteString(formatDiff(plan.Diff, len(plan.Diff)))
+	}
+	title, hint := i18n.Tf("route53.review_title", plan.ZoneName), i18n.T("route53.review_hint")
+	if plan.Source != "" {
+		title, hint = i18n.Tf("zonefile.import_title", plan.Source, plan.ZoneName), i18n.T("zonefile.import_hint")
+	}
+	fmt.Fprintf(&b, "\n[gray]%s[-]", hint)
+
+	view := modals.NewTextModal(title, b.String())
+	view.SetOnClose(func() {
+		r.pages.RemovePage("record-review")
+	})
+	view.SetOnRune(func(ch rune) bool {
+		switch ch {
+		case 'y':
+			if len(plan.Changes) == 0 {
+				return true
+			}
+			r.pages.RemovePage("record-review")
+			r.submitRecordChanges(plan)
+		case 'c':
+			if plan.Source != "" {
+				return false
+			}
+			r.pages.RemovePage("record-review")
+			r.service.DiscardRecordChanges()
+			r.setStatus(i18n.T("route53.discarded"))
+			go r.showCached(resource.KindRoute53Records)
+		default:
+			return false
+		}
+		return true
+	})
+	r.pages.AddAndSwitchToPage("record-review", view.Primitive(), true)
+}
+
+// submitRecordChanges 送出變更批次後重新查詢清單，並輪詢 GetChange 直到 INSYNC（x 停止等待，不影響已送出的變更）。
+func (r *Root) submitRecordChanges(plan resource.RecordChangePlan) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+	r.setStatus(i18n.Tf("route53.submitting", len(plan.Changes)))
+
+	go func() {
+		defer cancel()
+		info, err := r.service.SubmitRecordChanges(ctx, plan)
+		if err != nil {
+			r.app.QueueUpdateDraw(func() {
+				r.transferCancel = nil
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			})
+			return
+		}
+		r.app.QueueUpdateDraw(func() {
+			if r.currentKind == resource.KindRoute53Records {
+				go r.refresh()
+			}
+		})
+		r.waitRoute53Change(ctx, info.ID)
+	}()
+}
+
+// waitRoute53Change 在背景 goroutine 中輪詢 GetChange 直到 INSYNC，於狀態列顯示進度，完成後以 ResultModal 顯示結果。
+// 呼叫前需以 r.transferCancel 設定 ctx 的取消函式（x 停止等待），結束時清除。
+func (r *Root) waitRoute53Change(ctx context.Context, changeID string) {
+	start := time.Now()
+	_, err := r.service.WaitRecordChange(ctx, changeID, func(status models.Route53ChangeInfo) {
+		r.app.QueueUpdateDraw(func() {
+			elapsed := time.Since(start).Round(time.Second)
+			r.setStatus(fmt.Sprintf("%s %s", i18n.Tf("route53.waiting", status.ID, status.Status, elapsed), i18n.T("transfer.cancel_hint")))
+		})
+	})
+	r.app.QueueUpdateDraw(func() {
+		r.transferCancel = nil
+		switch {
+		case errors.Is(err, context.Canceled):
+			r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.Tf("route53.wait_stopped", changeID)))
+		case err != nil:
+			r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+			r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+		default:
+			message := i18n.Tf("route53.insync", changeID, time.Since(start).Round(time.Second))
+			r.setStatus(message)
+			r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+		}
+	})
+}
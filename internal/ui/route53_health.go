@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+// showHealthChecks 顯示 health check 清單；從 record 清單進入時一併顯示 zone 中使用各 health check 的 records。
+func (r *Root) showHealthChecks() {
+	if r.currentKind != resource.KindRoute53 && r.currentKind != resource.KindRoute53Records {
+		return
+	}
+	r.route53Return = r.currentKind
+	r.currentKind = resource.KindRoute53HealthChecks
+	go r.reload()
+}
+
+// closeHealthChecks 回到進入 health check 清單前的 Route53 清單。
+func (r *Root) closeHealthChecks() {
+	r.currentKind = r.route53Return
+	go r.reload()
+}
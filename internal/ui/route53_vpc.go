@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// openZoneView 切換到目前 hosted zone 的子清單（VPC 關聯或 Resolver）；
+// 從 zone 清單進入時以選取的 zone 為目前 zone，關閉時清除。
+func (r *Root) openZoneView(kind resource.Kind) {
+	switch r.currentKind {
+	case resource.KindRoute53:
+		item, ok := r.listView.CurrentItem()
+		if !ok {
+			r.setStatus("[yellow]No resource selected[-]")
+			return
+		}
+		r.service.SetCurrentZone(item.ID, item.Name)
+		r.route53Return = r.currentKind
+	case resource.KindRoute53Records:
+		r.route53Return = r.currentKind
+	case resource.KindRoute53ZoneVPCs, resource.KindRoute53Resolver:
+		// 在兩個子清單之間切換，保留原本的返回目標
+	default:
+		return
+	}
+	r.currentKind = kind
+	go r.reload()
+}
+
+// closeZoneView 回到進入 VPC 或 Resolver 清單前的 Route53 清單。
+func (r *Root) closeZoneView() {
+	if r.route53Return == resource.KindRoute53 {
+		r.service.ClearCurrentZone()
+	}
+	r.currentKind = r.route53Return
+	go r.reload()
+}
+
+// startVPCAssociate 輸入 "vpc-id [region]" 並將 VPC 關聯到目前的 private hosted zone。
+func (r *Root) startVPCAssociate() {
+	if r.transferBusy() {
+		return
+	}
+	prompt := modals.NewInputModal(i18n.Tf("route53.vpc_associate_title", r.service.CurrentZoneName()), i18n.T("route53.vpc_label"), "")
+	prompt.SetOnDone(func(value string, ok bool) {
+		r.pages.RemovePage("vpc-prompt")
+		fields := strings.Fields(value)
+		if !ok || len(fields) == 0 {
+			return
+		}
+		if len(fields) > 2 {
+			r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.T("route53.vpc_invalid")))
+			return
+		}
+		vpcID, region := fields[0], ""
+		if len(fields) == 2 {
+			region = fields[1]
+		}
+		r.runVPCChange(i18n.Tf("route53.vpc_associating", vpcID), func(ctx context.Context) (models.Route53ChangeInfo, error) {
+			return r.service.AssociateZoneVPC(ctx, vpcID, region)
+		})
+	})
+	r.pages.AddAndSwitchToPage("vpc-prompt", prompt.Primitive(), true)
+}
+
+// startVPCDisassociate 確認後解除選取的 VPC 與目前 private hosted zone 的關聯。
+func (r *Root) startVPCDisassociate() {
+	if r.transferBusy() {
+		return
+	}
+	item, ok := r.listView.CurrentItem()
+	if !ok {
+		r.setStatus("[yellow]No resource selected[-]")
+		return
+	}
+	confirm := modals.NewConfirmModal()
+	confirm.Show(
+		i18n.T("action.confirm"),
+		i18n.Tf("route53.vpc_disassociate_confirm", item.ID, item.Metadata["region"], r.service.CurrentZoneName()),
+		func(confirmed bool) {
+			r.pages.RemovePage("confirm")
+			if !confirmed {
+				return
+			}
+			r.runVPCChange(i18n.Tf("route53.vpc_disassociating", item.ID), func(ctx context.Context) (models.Route53ChangeInfo, error) {
+				return r.service.DisassociateZoneVPC(ctx, item.ID)
+			})
+		},
+	)
+	r.pages.AddAndSwitchToPage("confirm", confirm.Primitive(), true)
+}
+
+// runVPCChange 於背景送出 VPC 關聯變更，重新查詢清單後輪詢直到 INSYNC（x 停止等待，不影響已送出的變更）。
+func (r *Root) runVPCChange(label string, submit func(ctx context.Context) (models.Route53ChangeInfo, error)) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+	r.setStatus(label)
+
+	go func() {
+		defer cancel()
+		info, err := submit(ctx)
+		if err != nil {
+			r.app.QueueUpdateDraw(func() {
+				r.transferCancel = nil
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			})
+			return
+		}
+		r.app.QueueUpdateDraw(func() {
+			if r.currentKind == resource.KindRoute53ZoneVPCs {
+				go r.refresh()
+			}
+		})
+		r.waitRoute53Change(ctx, info.ID)
+	}()
+}
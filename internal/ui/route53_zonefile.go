@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// exportZoneFile 詢問路徑後將目前 zone 匯出為 BIND zone file。
+func (r *Root) exportZoneFile() {
+	if r.currentKind != resource.KindRoute53Records {
+		return
+	}
+	zone := r.service.CurrentZoneName()
+	value := filepath.Join(workingDir(), strings.TrimSuffix(zone, ".")+".zone")
+	r.promptPath(i18n.Tf("zonefile.export_title", zone), value, func(path string) {
+		r.setStatus(i18n.Tf("zonefile.exporting", zone))
+		go func() {
+			count, err := r.service.ExportZoneFile(r.ctx, path)
+			r.app.QueueUpdateDraw(func() {
+				if err != nil {
+					r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+					r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+					return
+				}
+				r.setStatus(i18n.Tf("zonefile.exported", count, path))
+			})
+		}()
+	})
+}
+
+// startZoneImport 詢問 zone file 路徑，與目前的 records 比較後顯示變更批次供確認。
+func (r *Root) startZoneImport() {
+	if r.currentKind != resource.KindRoute53Records || r.transferBusy() {
+		return
+	}
+	zone := r.service.CurrentZoneName()
+	value := filepath.Join(workingDir(), strings.TrimSuffix(zone, ".")+".zone")
+	r.promptPath(i18n.Tf("zonefile.import_prompt", zone), value, func(path string) {
+		r.setStatus(i18n.Tf("zonefile.planning", path))
+		go func() {
+			plan, err := r.service.PlanZoneImport(r.ctx, path)
+			r.app.QueueUpdateDraw(func() {
+				if err != nil {
+					r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+					r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+					return
+				}
+				r.setStatus("")
+				r.reviewRecordChanges(plan)
+			})
+		}()
+	})
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+	"github.com/vincent119/awsGUITools/internal/ui/widgets"
+)
+
+const (
+	// deletePreviewKeys 為確認對話框中列出的 key 數。
+	deletePreviewKeys = 10
+	// deleteReportErrors 為結果中列出的失敗 key 數。
+	deleteReportErrors = 15
+)
+
+// toggleMark 切換目前物件的標記（供批次刪除）。
+func (r *Root) toggleMark() {
+	if r.currentKind != resource.KindS3Objects {
+		return
+	}
+	r.setStatus(i18n.Tf("delete.marked", r.listView.ToggleMark()))
+}
+
+// startDelete 刪除已標記的物件；沒有標記時刪除目前選取的物件或目錄。
+// 先以 dry-run 列出實際會刪除的 key 數與總大小，確認後才刪除。
+func (r *Root) startDelete() {
+	if r.currentKind != resource.KindS3Objects || r.transferBusy() {
+		return
+	}
+	items := r.listView.Marked()
+	if len(items) == 0 {
+		item, ok := r.listView.CurrentItem()
+		if !ok {
+			r.setStatus("[yellow]No resource selected[-]")
+			return
+		}
+		items = []models.ListItem{item}
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+	r.setStatus(fmt.Sprintf("%s %s", i18n.T("delete.planning"), i18n.T("transfer.cancel_hint")))
+
+	go func() {
+		defer cancel()
+		plan, err := r.service.PlanDelete(ctx, items)
+		r.app.QueueUpdateDraw(func() {
+			r.transferCancel = nil
+			switch {
+			case errors.Is(err, context.Canceled):
+				r.setStatus("")
+			case err != nil:
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			default:
+				r.confirmDelete(plan)
+			}
+		})
+	}()
+}
+
+// confirmDelete 顯示 dry-run 結果並等待確認。
+func (r *Root) confirmDelete(plan resource.DeletePlan) {
+	var b strings.Builder
+	b.WriteString(i18n.Tf("delete.summary", len(plan.Keys), widgets.FormatBytes(plan.Bytes), plan.Bucket))
+	b.WriteString("\n")
+	for i, key := range plan.Keys {
+		if i == deletePreviewKeys {
+			b.WriteString(i18n.Tf("delete.more", len(plan.Keys)-deletePreviewKeys) + "\n")
+			break
+		}
+		b.WriteString(tview.Escape(key) + "\n")
+	}
+	if plan.Versioning != "" && plan.Versioning != "Disabled" {
+		fmt.Fprintf(&b, "\n[yellow]⚠ %s[-]", i18n.T("delete.versioned"))
+	} else {
+		fmt.Fprintf(&b, "\n[yellow]⚠ %s[-]", i18n.T("delete.permanent"))
+	}
+
+	confirm := modals.NewConfirmModal()
+	confirm.Show(i18n.T("delete.title"), b.String(), func(confirmed bool) {
+		r.pages.RemovePage("confirm")
+		if !confirmed {
+			r.setStatus("")
+			return
+		}
+		r.runDelete(plan)
+	})
+	r.pages.AddAndSwitchToPage("confirm", confirm.Primitive(), true)
+}
+
+// runDelete 於背景執行刪除（x 取消剩餘批次），完成後顯示結果並重新查詢清單。
+func (r *Root) runDelete(plan resource.DeletePlan) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+	r.setStatus(fmt.Sprintf("%s %s", i18n.Tf("delete.deleting", 0, len(plan.Keys)), i18n.T("transfer.cancel_hint")))
+
+	go func() {
+		defer cancel()
+		result, err := r.service.DeleteObjects(ctx, plan, func(done, total int) {
+			r.app.QueueUpdateDraw(func() {
+				r.setStatus(fmt.Sprintf("%s %s", i18n.Tf("delete.deleting", done, total), i18n.T("transfer.cancel_hint")))
+			})
+		})
+
+		r.app.QueueUpdateDraw(func() {
+			r.transferCancel = nil
+			r.listView.ClearMarks()
+			switch {
+			case errors.Is(err, context.Canceled):
+				r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.Tf("delete.canceled", result.Deleted)))
+			case err != nil:
+				err = fmt.Errorf("%s: %w", i18n.Tf("delete.stopped", result.Deleted), err)
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			case len(result.Failed) > 0:
+				message := deleteFailureReport(result)
+				r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.Tf("delete.partial", result.Deleted, len(result.Failed))))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowInfo(message, onOK) })
+			default:
+				message := i18n.Tf("delete.completed", result.Deleted)
+				r.setStatus(message)
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+			}
+			if r.currentKind == resource.KindS3Objects {
+				go r.refresh()
+			}
+		})
+	}()
+}
+
+// deleteFailureReport 列出刪除失敗的 key 與錯誤碼。
+func deleteFailureReport(result resource.DeleteResult) string {
+	var b strings.Builder
+	b.WriteString(i18n.Tf("delete.partial", result.Deleted, len(result.Failed)))
+	b.WriteString("\n\n")
+	for i, f := range result.Failed {
+		if i == deleteReportErrors {
+			b.WriteString(i18n.Tf("delete.more", len(result.Failed)-deleteReportErrors) + "\n")
+			break
+		}
+		fmt.Fprintf(&b, "%s: %s %s\n", tview.Escape(f.Key), f.Code, tview.Escape(f.Message))
+	}
+	return b.String()
+}
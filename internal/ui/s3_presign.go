@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// startPresign 為選取的物件（或目前 prefix 下的新 key）產生 presigned URL。
+func (r *Root) startPresign() {
+	if r.currentKind != resource.KindS3Objects {
+		return
+	}
+	key := r.service.CurrentPrefix()
+	if item, ok := r.listView.CurrentItem(); ok && item.Type == "File" {
+		key = item.ID
+	}
+
+	form := modals.NewPresignModal(key)
+	form.SetOnDone(func(req modals.PresignRequest, ok bool) {
+		r.pages.RemovePage("presign")
+		req.Key = strings.TrimSpace(req.Key)
+		if !ok || req.Key == "" {
+			return
+		}
+		go func() {
+			url, err := r.service.PresignObject(r.ctx, req.Method, req.Key, req.Expires)
+			r.app.QueueUpdateDraw(func() {
+				if err != nil {
+					r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+					r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+					return
+				}
+				r.showPresigned(url)
+			})
+		}()
+	})
+	r.pages.AddAndSwitchToPage("presign", form.Primitive(), true)
+}
+
+// showPresigned 複製 URL 到剪貼簿並以對話框顯示（c 再次複製）。
+func (r *Root) showPresigned(url models.S3PresignedURL) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]%s[::-] %s\n", url.Method, tview.Escape(url.Key))
+	fmt.Fprintf(&b, "%s\n", i18n.Tf("presign.expires", url.ExpiresAt.Local().Format(time.DateTime)))
+	if !url.CredentialsExpireAt.IsZero() && url.CredentialsExpireAt.Before(url.ExpiresAt) {
+		fmt.Fprintf(&b, "[yellow]⚠ %s[-]\n", i18n.Tf("presign.credentials_expire", url.CredentialsExpireAt.Local().Format(time.DateTime)))
+	}
+	if url.Method == http.MethodPut {
+		fmt.Fprintf(&b, "\n[gray]curl -X PUT --upload-file <file> '<url>'[-]\n")
+	}
+	fmt.Fprintf(&b, "\n%s\n\n[gray]%s[-]", tview.Escape(url.URL), i18n.T("presign.hint"))
+
+	r.copyToClipboard(url.URL)
+	view := modals.NewTextModal(i18n.T("presign.title"), b.String())
+	view.SetOnClose(func() {
+		r.pages.RemovePage("presigned")
+	})
+	view.SetOnRune(func(ch rune) bool {
+		if ch != 'c' {
+			return false
+		}
+		r.copyToClipboard(url.URL)
+		return true
+	})
+	r.pages.AddAndSwitchToPage("presigned", view.Primitive(), true)
+}
+
+// copyToClipboard 以 OSC 52 將文字送到終端機剪貼簿；終端機不支援時不會有任何效果，因此同時顯示內容。
+func (r *Root) copyToClipboard(text string) {
+	if r.screen == nil {
+		return
+	}
+	r.screen.SetClipboard([]byte(text))
+	r.setStatus(i18n.T("presign.copied"))
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/vincent119/awsGUITools/internal/preview"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+// openPreview 於詳情欄顯示選取物件的預覽，焦點移到預覽以便捲動（Esc 或 v 關閉）。
+func (r *Root) openPreview() {
+	if r.currentKind != resource.KindS3Objects {
+		return
+	}
+	item, ok := r.listView.CurrentItem()
+	if !ok || item.Type != "File" {
+		return
+	}
+
+	r.previewTab.SetLoading(item.Name)
+	r.detailPages.SwitchToPage("preview")
+	r.app.SetFocus(r.previewTab.Primitive())
+
+	go func() {
+		ctx, cancel := context.WithTimeout(r.ctx, 20*time.Second)
+		defer cancel()
+		result, err := r.service.PreviewObject(ctx, item.ID)
+		r.app.QueueUpdateDraw(func() {
+			if err != nil {
+				r.previewTab.SetError(err)
+				return
+			}
+			r.previewTab.SetPreview(result.Info, preview.Render(preview.Input{
+				Key:         result.Info.Key,
+				ContentType: result.Info.ContentType,
+				Data:        result.Data,
+				Truncated:   result.Truncated,
+			}))
+		})
+	}()
+}
+
+// closePreview 切回資源詳情並將焦點還給清單。
+func (r *Root) closePreview() {
+	r.detailPages.SwitchToPage("overview")
+	r.app.SetFocus(r.listView.Primitive())
+}
+
+// handlePreviewKeys 處理焦點在預覽時的按鍵；其餘按鍵交給 TextView 捲動。
+func (r *Root) handlePreviewKeys(event *tcell.EventKey) *tcell.EventKey {
+	switch {
+	case event.Key() == tcell.KeyEscape, event.Key() == tcell.KeyRune && event.Rune() == 'v':
+		r.closePreview()
+		return nil
+	case event.Key() == tcell.KeyRune && event.Rune() == 'q':
+		r.app.Stop()
+		return nil
+	}
+	return event
+}
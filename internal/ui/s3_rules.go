@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// rulesDiffContext 為差異中每段變更前後保留的未變更行數。
+const rulesDiffContext = 3
+
+// startRulesEditor 選擇要編輯的 bucket 設定（lifecycle 或 CORS）；bucket 清單使用選取的 bucket，物件清單使用目前 bucket。
+func (r *Root) startRulesEditor() {
+	if r.currentKind != resource.KindS3 && r.currentKind != resource.KindS3Objects {
+		return
+	}
+	bucket := r.service.CurrentBucket()
+	if r.currentKind == resource.KindS3 {
+		item, ok := r.listView.CurrentItem()
+		if !ok {
+			r.setStatus("[yellow]No resource selected[-]")
+			return
+		}
+		bucket = item.ID
+	}
+
+	labels := make([]string, len(resource.BucketConfigs))
+	byLabel := make(map[string]resource.BucketConfig, len(resource.BucketConfigs))
+	for i, cfg := range resource.BucketConfigs {
+		labels[i] = rulesLabel(cfg)
+		byLabel[labels[i]] = cfg
+	}
+
+	panel := modals.NewActionPanel()
+	panel.SetActions(labels, func(label string) {
+		r.pages.RemovePage("rules-picker")
+		if label == "" {
+			return
+		}
+		r.openRulesEditor(bucket, byLabel[label])
+	})
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(panel.Primitive(), len(labels)+2, 0, true).
+			AddItem(nil, 0, 1, false), 40, 0, true).
+		AddItem(nil, 0, 1, false)
+	r.pages.AddAndSwitchToPage("rules-picker", modal, true)
+}
+
+// openRulesEditor 取得目前設定後開啟 YAML 編輯器；Ctrl-S 驗證並顯示差異。
+func (r *Root) openRulesEditor(bucket string, cfg resource.BucketConfig) {
+	label := rulesLabel(cfg)
+	r.setStatus(i18n.Tf("rules.loading", label, bucket))
+	go func() {
+		original, err := r.service.BucketConfigYAML(r.ctx, bucket, cfg)
+		r.app.QueueUpdateDraw(func() {
+			if err != nil {
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+				return
+			}
+			r.setStatus("")
+
+			text := original
+			if strings.TrimSpace(original) == "rules: []" {
+				text += resource.BucketConfigTemplate(cfg)
+			}
+			editor := modals.NewYAMLEditor(i18n.Tf("rules.editor_title", label, bucket), text)
+			editor.SetOnCancel(func() {
+				r.pages.RemovePage("rules-editor")
+			})
+			editor.SetOnSave(func(text string) {
+				change, err := resource.PlanBucketConfig(bucket, cfg, original, text)
+				if err != nil {
+					editor.SetError(err)
+					return
+				}
+				if !diff.Changed(change.Diff) {
+					editor.SetInfo(i18n.T("rules.no_changes"))
+					return
+				}
+				editor.SetError(nil)
+				r.reviewRules(editor, change)
+			})
+			r.pages.AddAndSwitchToPage("rules-editor", editor.Primitive(), true)
+		})
+	}()
+}
+
+// reviewRules 顯示目前設定與編輯後設定的差異；y 套用，Esc 回到編輯器。
+func (r *Root) reviewRules(editor *modals.YAMLEditor, change resource.BucketConfigChange) {
+	var b strings.Builder
+	inserted, deleted := diff.Stats(change.Diff)
+	fmt.Fprintf(&b, "%s\n\n", i18n.Tf("rules.diff_stats", change.Bucket, inserted, deleted, change.Rules))
+	b.WriteString(formatDiff(change.Diff, rulesDiffContext))
+	fmt.Fprintf(&b, "\n[gray]%s[-]", i18n.T("rules.review_hint"))
+
+	view := modals.NewTextModal(i18n.Tf("rules.review_title", rulesLabel(change.Config)), b.String())
+	view.SetOnClose(func() {
+		r.pages.RemovePage("rules-diff")
+	})
+	view.SetOnRune(func(ch rune) bool {
+		if ch != 'y' {
+			return false
+		}
+		r.pages.RemovePage("rules-diff")
+		r.applyRules(editor, change)
+		return true
+	})
+	r.pages.AddAndSwitchToPage("rules-diff", view.Primitive(), true)
+}
+
+// applyRules 於背景套用設定；失敗時保留編輯器內容並顯示錯誤，成功後關閉編輯器並重新查詢詳情。
+func (r *Root) applyRules(editor *modals.YAMLEditor, change resource.BucketConfigChange) {
+	label := rulesLabel(change.Config)
+	editor.SetInfo(i18n.Tf("rules.applying", label, change.Bucket))
+	go func() {
+		err := r.service.ApplyBucketConfig(r.ctx, change)
+		r.app.QueueUpdateDraw(func() {
+			if err != nil {
+				editor.SetError(err)
+				return
+			}
+			r.pages.RemovePage("rules-editor")
+			message := i18n.Tf("rules.applied", label, change.Bucket, change.Rules)
+			r.setStatus(message)
+			r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+			if item, ok := r.listView.CurrentItem(); ok && r.currentKind == resource.KindS3 && item.ID == change.Bucket {
+				r.showDetail(item)
+			}
+		})
+	}()
+}
+
+// formatDiff 以顏色標示新增（+）與刪除（-）的行；距離變更超過 context 行的未變更內容以 … 省略。
+func formatDiff(lines []diff.Line, context int) string {
+	near := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Op == diff.Equal {
+			continue
+		}
+		for j := max(i-context, 0); j <= min(i+context, len(lines)-1); j++ {
+			near[j] = true
+		}
+	}
+
+	var b strings.Builder
+	for i, l := range lines {
+		if !near[i] {
+			if i == 0 || near[i-1] {
+				b.WriteString("[gray]  …[-]\n")
+			}
+			continue
+		}
+		text := tview.Escape(l.Text)
+		switch l.Op {
+		case diff.Insert:
+			fmt.Fprintf(&b, "[green]+ %s[-]\n", text)
+		case diff.Delete:
+			fmt.Fprintf(&b, "[red]- %s[-]\n", text)
+		default:
+			fmt.Fprintf(&b, "  %s\n", text)
+		}
+	}
+	return b.String()
+}
+
+func rulesLabel(cfg resource.BucketConfig) string {
+	return i18n.T("rules." + string(cfg))
+}
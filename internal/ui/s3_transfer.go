@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+	"github.com/vincent119/awsGUITools/internal/ui/widgets"
+)
+
+// transferFunc 執行一次下載或上傳，並以 onProgress 回報進度。
+type transferFunc func(ctx context.Context, onProgress func(resource.TransferProgress)) (resource.TransferResult, error)
+
+// startDownload 詢問本機目錄後下載選取的物件、目錄或版本。
+func (r *Root) startDownload() {
+	if r.currentKind != resource.KindS3Objects && r.currentKind != resource.KindS3Versions || r.transferBusy() {
+		return
+	}
+	item, ok := r.listView.CurrentItem()
+	if !ok {
+		r.setStatus("[yellow]No resource selected[-]")
+		return
+	}
+
+	if r.currentKind == resource.KindS3Versions {
+		r.promptPath(i18n.Tf("transfer.download_title", item.Name), workingDir(), func(dir string) {
+			r.runTransfer(i18n.T("transfer.downloading"), func(ctx context.Context, onProgress func(resource.TransferProgress)) (resource.TransferResult, error) {
+				return r.service.DownloadVersion(ctx, item.ID, dir, onProgress)
+			}, false)
+		})
+		return
+	}
+
+	r.promptPath(i18n.Tf("transfer.download_title", item.Name), workingDir(), func(dir string) {
+		label := i18n.T("transfer.downloading")
+		r.runTransfer(label, func(ctx context.Context, onProgress func(resource.TransferProgress)) (resource.TransferResult, error) {
+			return r.service.DownloadObjects(ctx, item.ID, dir, onProgress)
+		}, false)
+	})
+}
+
+// startUpload 詢問本機檔案或目錄後上傳到目前 prefix。
+func (r *Root) startUpload() {
+	if r.currentKind != resource.KindS3Objects || r.transferBusy() {
+		return
+	}
+
+	r.promptPath(i18n.T("transfer.upload_title"), workingDir()+string(filepath.Separator), func(local string) {
+		label := i18n.T("transfer.uploading")
+		r.runTransfer(label, func(ctx context.Context, onProgress func(resource.TransferProgress)) (resource.TransferResult, error) {
+			return r.service.UploadPath(ctx, local, onProgress)
+		}, true)
+	})
+}
+
+// cancelTransfer 取消進行中的傳輸。
+func (r *Root) cancelTransfer() {
+	if r.transferCancel != nil {
+		r.transferCancel()
+	}
+}
+
+func (r *Root) transferBusy() bool {
+	if r.transferCancel == nil {
+		return false
+	}
+	r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.T("transfer.busy")))
+	return true
+}
+
+// promptPath 顯示路徑輸入框，確認且路徑非空時呼叫 onPath（支援 ~ 開頭）。
+func (r *Root) promptPath(title, value string, onPath func(path string)) {
+	prompt := modals.NewInputModal(title, i18n.T("transfer.path_label"), value)
+	prompt.SetOnDone(func(value string, ok bool) {
+		r.pages.RemovePage("path-prompt")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return
+		}
+		onPath(expandHome(value))
+	})
+	r.pages.AddAndSwitchToPage("path-prompt", prompt.Primitive(), true)
+}
+
+// runTransfer 於背景執行傳輸，在狀態列顯示進度列（x 取消），完成後以 ResultModal 顯示結果。
+// refresh 為 true 時完成後重新查詢目前清單（上傳後顯示新物件）。
+func (r *Root) runTransfer(label string, fn transferFunc, refresh bool) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+	r.statusBar.SetProgress(label, 0, 0)
+	r.setStatus(i18n.T("transfer.cancel_hint"))
+
+	go func() {
+		defer cancel()
+		result, err := fn(ctx, func(p resource.TransferProgress) {
+			r.app.QueueUpdateDraw(func() {
+				r.statusBar.SetProgress(fmt.Sprintf("%s %d/%d", label, p.FilesDone, p.Files), p.BytesDone, p.Bytes)
+				r.setStatus(fmt.Sprintf("%s %s", p.File, i18n.T("transfer.cancel_hint")))
+			})
+		})
+
+		r.app.QueueUpdateDraw(func() {
+			r.transferCancel = nil
+			r.statusBar.ClearProgress()
+			summary := i18n.Tf("transfer.summary", result.Files, widgets.FormatBytes(result.Bytes))
+			switch {
+			case errors.Is(err, context.Canceled):
+				r.setStatus(fmt.Sprintf("[yellow]%s[-] %s", i18n.T("transfer.canceled"), summary))
+			case err != nil:
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			default:
+				message := i18n.Tf("transfer.completed", label, summary)
+				r.setStatus(message)
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowSuccess(message, onOK) })
+				if refresh && r.currentKind == resource.KindS3Objects {
+					go r.refresh()
+				}
+			}
+		})
+	}()
+}
+
+func workingDir() string {
+	if dir, err := os.Getwd(); err == nil {
+		return dir
+	}
+	return "."
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/ui/modals"
+)
+
+// startAnalyze 分析選取 bucket（bucket 清單）或選取目錄/目前 prefix（物件清單）的用量。
+func (r *Root) startAnalyze() {
+	if r.currentKind != resource.KindS3 && r.currentKind != resource.KindS3Objects || r.transferBusy() {
+		return
+	}
+	bucket, prefix := r.service.CurrentBucket(), r.service.CurrentPrefix()
+	item, ok := r.listView.CurrentItem()
+	switch {
+	case r.currentKind == resource.KindS3 && !ok:
+		r.setStatus("[yellow]No resource selected[-]")
+		return
+	case r.currentKind == resource.KindS3:
+		bucket, prefix = item.ID, ""
+	case ok && item.Type == "Dir":
+		prefix = item.ID
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.transferCancel = cancel
+	target := fmt.Sprintf("s3://%s/%s", bucket, prefix)
+	r.setStatus(fmt.Sprintf("%s %s", i18n.Tf("usage.analyzing", target, 0), i18n.T("transfer.cancel_hint")))
+
+	returnKind := r.currentKind
+	go func() {
+		defer cancel()
+		err := r.service.AnalyzeUsage(ctx, bucket, prefix, func(objects int64) {
+			r.app.QueueUpdateDraw(func() {
+				r.setStatus(fmt.Sprintf("%s %s", i18n.Tf("usage.analyzing", target, objects), i18n.T("transfer.cancel_hint")))
+			})
+		})
+		r.app.QueueUpdateDraw(func() {
+			r.transferCancel = nil
+			switch {
+			case errors.Is(err, context.Canceled):
+				r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.T("usage.canceled")))
+			case err != nil:
+				r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+				r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			case r.currentKind == returnKind:
+				r.usageReturn = returnKind
+				r.currentKind = resource.KindS3Usage
+				go r.reload()
+			}
+		})
+	}()
+}
+
+// usageEnter 進入選取的子 prefix。
+func (r *Root) usageEnter() {
+	item, ok := r.listView.CurrentItem()
+	if !ok || item.Type != "Dir" {
+		return
+	}
+	if r.service.UsageEnter(item.ID) {
+		go r.reload()
+	}
+}
+
+// usageUp 回到上一層 prefix；已在分析的根 prefix 時關閉分析結果。
+func (r *Root) usageUp() {
+	if r.service.UsageUp() {
+		go r.reload()
+		return
+	}
+	r.closeUsage()
+}
+
+// closeUsage 回到開始分析時的清單。
+func (r *Root) closeUsage() {
+	r.service.ClearUsage()
+	r.currentKind = r.usageReturn
+	go r.reload()
+}
+
+// exportUsage 將分析結果匯出為 CSV 或 JSON（依副檔名）。
+func (r *Root) exportUsage() {
+	if r.currentKind != resource.KindS3Usage {
+		return
+	}
+	value := filepath.Join(workingDir(), fmt.Sprintf("s3-usage-%s.csv", r.service.UsageBucket()))
+	r.promptPath(i18n.T("usage.export_title"), value, func(path string) {
+		if err := r.service.ExportUsage(path); err != nil {
+			r.setStatus(fmt.Sprintf("[red]%s[-]", i18n.Tf("error.operation_failed", err)))
+			r.showResult(func(m *modals.ResultModal, onOK func()) { m.ShowError(err, onOK) })
+			return
+		}
+		r.setStatus(i18n.Tf("usage.exported", path))
+	})
+}
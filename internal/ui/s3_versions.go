@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/vincent119/awsGUITools/internal/i18n"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+// showVersions 顯示選取物件的版本清單（需 bucket 已啟用版本控制）。
+func (r *Root) showVersions() {
+	if r.currentKind != resource.KindS3Objects {
+		return
+	}
+	item, ok := r.listView.CurrentItem()
+	if !ok || item.Type != "File" {
+		r.setStatus(fmt.Sprintf("[yellow]%s[-]", i18n.T("versions.select_file")))
+		return
+	}
+	r.service.SetCurrentVersionKey(item.ID)
+	r.currentKind = resource.KindS3Versions
+	go r.reload()
+}
+
+// closeVersions 回到版本清單所屬的物件清單。
+func (r *Root) closeVersions() {
+	r.service.SetCurrentVersionKey("")
+	r.currentKind = resource.KindS3Objects
+	go r.reload()
+}
@@ -3,6 +3,8 @@ package widgets
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/rivo/tview"
 
@@ -12,7 +14,10 @@ import (
 
 // StatusBar 顯示目前 profile/region/theme/resource 狀態。
 type StatusBar struct {
-	view *tview.TextView
+	view       *tview.TextView
+	fetchedAt  time.Time // 目前清單資料的取得時間；零值表示尚無資料
+	refreshing bool
+	progress   string // 進行中的傳輸進度列；空字串表示沒有傳輸
 }
 
 // NewStatusBar 建立狀態列。
@@ -29,6 +34,38 @@ func (s *StatusBar) Primitive() *tview.TextView {
 	return s.view
 }
 
+// SetDataAge 設定目前清單資料的取得時間與是否正在背景刷新，於下次 SetStatus 時顯示。
+func (s *StatusBar) SetDataAge(fetchedAt time.Time, refreshing bool) {
+	s.fetchedAt = fetchedAt
+	s.refreshing = refreshing
+}
+
+// progressWidth 為進度列的字元寬度。
+const progressWidth = 20
+
+// SetProgress 設定傳輸進度列，於下次 SetStatus 時顯示；total 為 0 時只顯示已傳輸量。
+func (s *StatusBar) SetProgress(label string, done, total int64) {
+	if total <= 0 {
+		s.progress = fmt.Sprintf("[aqua]%s %s[-]", label, FormatBytes(done))
+		return
+	}
+	done = min(done, total)
+	filled := int(done * progressWidth / total)
+	s.progress = fmt.Sprintf("[aqua]%s [%s%s] %d%% %s/%s[-]",
+		label,
+		strings.Repeat("█", filled),
+		strings.Repeat("░", progressWidth-filled),
+		done*100/total,
+		FormatBytes(done),
+		FormatBytes(total),
+	)
+}
+
+// ClearProgress 移除傳輸進度列。
+func (s *StatusBar) ClearProgress() {
+	s.progress = ""
+}
+
 // SetStatus 更新顯示。
 func (s *StatusBar) SetStatus(profile, region, theme string, kind resource.Kind, count int, message string) {
 	// 快捷鍵提示（使用 <key:label> 格式避免被當成顏色標籤）
@@ -44,8 +81,20 @@ func (s *StatusBar) SetStatus(profile, region, theme string, kind resource.Kind,
 		kind,
 		count,
 	)
+	// 資料新鮮度
+	if !s.fetchedAt.IsZero() {
+		age := i18n.Tf("status.data_age", formatAge(time.Since(s.fetchedAt)))
+		if s.refreshing {
+			age += " " + i18n.T("status.refreshing")
+		}
+		status += fmt.Sprintf(" [gray]%s[-]", age)
+	}
 	// 組合
 	text := shortcuts + " " + status
+	// 傳輸進度
+	if s.progress != "" {
+		text += "  " + s.progress
+	}
 	// 訊息（放最後，超長時會被截斷）
 	if message != "" {
 		text += "  " + message
@@ -59,3 +108,29 @@ func emptyFallback(val, fallback string) string {
 	}
 	return val
 }
+
+// formatAge 以最大的單位顯示經過時間，例如 12s、3m、2h。
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+}
+
+// FormatBytes 以 1024 進位顯示位元組數，例如 1.5 MiB。
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
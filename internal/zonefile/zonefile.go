@@ -0,0 +1,433 @@
+// Package zonefile 讀寫 RFC 1035 BIND zone file，與 Route53 record 互相轉換。
+//
+// Route53 的 alias record 沒有標準表示法，以延伸語法 "AWS ALIAS <target> <zone-id> [true|false]"
+// 寫在 RDATA 位置（最後一個欄位為是否評估目標健康狀態），例如：
+//
+//	cdn	IN	A	AWS ALIAS d111111abcdef8.cloudfront.net. Z2FDTNDATAQYW2 false
+package zonefile
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// wildcardLabel 為 Route53 回傳的萬用字元標籤（\052 即 *）。
+const wildcardLabel = `\052`
+
+// Write 以 zone file 格式輸出 records：SOA、apex NS 在前，其餘依名稱與類型排序；
+// owner 名稱相對於 origin，每個值一行。使用路由政策（有 SetIdentifier）或關聯 health check 的 record
+// 無法以 zone file 表示，以註解列出。
+func Write(w io.Writer, origin string, records []models.Route53Record) error {
+	origin = Fqdn(origin)
+	sorted := slices.Clone(records)
+	slices.SortStableFunc(sorted, func(a, b models.Route53Record) int {
+		return cmp.Or(
+			cmp.Compare(writeRank(a, origin), writeRank(b, origin)),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Type, b.Type),
+		)
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	for _, record := range sorted {
+		owner := relativeName(record.Name, origin)
+		if record.SetIdentifier != "" {
+			fmt.Fprintf(bw, "; skipped %s %s set %q: routing policies are not supported in zone files\n", owner, record.Type, record.SetIdentifier)
+			continue
+		}
+		if record.HealthCheckID != "" {
+			fmt.Fprintf(bw, "; skipped %s %s health check %s: health checks are not supported in zone files\n", owner, record.Type, record.HealthCheckID)
+			continue
+		}
+		if record.AliasTarget != "" {
+			fmt.Fprintf(bw, "%s\tIN\t%s\tAWS ALIAS %s %s %t\n", owner, record.Type, record.AliasTarget, record.AliasZoneID, record.EvaluateTargetHealth)
+			continue
+		}
+		for _, value := range record.Values {
+			fmt.Fprintf(bw, "%s\t%d\tIN\t%s\t%s\n", owner, record.TTL, record.Type, value)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeRank(record models.Route53Record, origin string) int {
+	switch {
+	case record.Type == "SOA":
+		return 0
+	case record.Type == "NS" && record.Name == origin:
+		return 1
+	}
+	return 2
+}
+
+// relativeName 將完整名稱轉為相對於 origin 的 owner 名稱（apex 為 @），並將 \052 還原為 *。
+func relativeName(name, origin string) string {
+	if strings.HasPrefix(name, wildcardLabel) {
+		name = "*" + strings.TrimPrefix(name, wildcardLabel)
+	}
+	switch {
+	case name == origin:
+		return "@"
+	case strings.HasSuffix(name, "."+origin):
+		return strings.TrimSuffix(name, "."+origin)
+	}
+	return name
+}
+
+// Fqdn 回傳小寫、結尾加點的完整網域名稱。
+func Fqdn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+// Parse 解析 zone file，origin 為 $ORIGIN 的預設值。支援 $ORIGIN、$TTL、@、相對名稱、
+// 省略 owner（沿用上一筆）、括號跨行與 ; 註解；TTL 的單位可為 s、m、h、d、w。
+// 相同名稱與類型的資料合併為一個 record（重複的值只保留一個），名稱轉為小寫並以 \052 表示萬用字元。
+func Parse(r io.Reader, origin string) ([]models.Route53Record, error) {
+	entries, err := readEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{origin: Fqdn(origin), defaultTTL: -1, lastTTL: -1}
+	var (
+		records []models.Route53Record
+		index   = make(map[string]int)
+		owner   string
+	)
+	for _, e := range entries {
+		tokens := e.tokens
+		if !e.blankOwner && strings.HasPrefix(tokens[0], "$") {
+			if err := p.directive(tokens); err != nil {
+				return nil, fmt.Errorf("line %d: %w", e.line, err)
+			}
+			continue
+		}
+
+		if e.blankOwner {
+			if owner == "" {
+				return nil, fmt.Errorf("line %d: no previous owner name", e.line)
+			}
+		} else {
+			name, err := p.absolute(tokens[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", e.line, err)
+			}
+			owner = strings.ToLower(name)
+			if strings.HasPrefix(owner, "*") {
+				owner = wildcardLabel + owner[1:]
+			}
+			tokens = tokens[1:]
+		}
+
+		record, err := p.record(owner, tokens)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", e.line, err)
+		}
+		key := record.Name + "_" + record.Type
+		i, exists := index[key]
+		if !exists {
+			index[key] = len(records)
+			records = append(records, record)
+			continue
+		}
+		existing := &records[i]
+		switch {
+		case existing.AliasTarget != "" || record.AliasTarget != "":
+			return nil, fmt.Errorf("line %d: alias %s %s cannot have other values", e.line, record.Name, record.Type)
+		case existing.TTL != record.TTL:
+			return nil, fmt.Errorf("line %d: TTL %d of %s %s differs from %d", e.line, record.TTL, record.Name, record.Type, existing.TTL)
+		case !slices.Contains(existing.Values, record.Values[0]):
+			existing.Values = append(existing.Values, record.Values[0])
+		}
+	}
+	return records, nil
+}
+
+type parser struct {
+	origin     string
+	defaultTTL int64 // $TTL，-1 表示未設定
+	lastTTL    int64 // 上一筆明確指定的 TTL，-1 表示沒有
+}
+
+func (p *parser) directive(tokens []string) error {
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$ORIGIN needs one name")
+		}
+		origin, err := p.absolute(tokens[1])
+		if err != nil {
+			return err
+		}
+		p.origin = strings.ToLower(origin)
+	case "$TTL":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$TTL needs one value")
+		}
+		ttl, ok := parseTTL(tokens[1])
+		if !ok {
+			return fmt.Errorf("invalid $TTL %q", tokens[1])
+		}
+		p.defaultTTL = ttl
+	default:
+		return fmt.Errorf("unsupported directive %s", tokens[0])
+	}
+	return nil
+}
+
+// record 解析 owner 之後的 [TTL] [class] type rdata（TTL 與 class 順序不拘）。
+func (p *parser) record(owner string, tokens []string) (models.Route53Record, error) {
+	record := models.Route53Record{Name: owner}
+	ttl := int64(-1)
+	for len(tokens) > 0 {
+		if class := strings.ToUpper(tokens[0]); class == "IN" || class == "CH" || class == "HS" {
+			if class != "IN" {
+				return record, fmt.Errorf("unsupported class %s", class)
+			}
+			tokens = tokens[1:]
+			continue
+		}
+		if value, ok := parseTTL(tokens[0]); ok && ttl < 0 {
+			ttl = value
+			tokens = tokens[1:]
+			continue
+		}
+		break
+	}
+	if len(tokens) == 0 {
+		return record, fmt.Errorf("missing record type")
+	}
+	record.Type = strings.ToUpper(tokens[0])
+	rdata := tokens[1:]
+	if len(rdata) == 0 {
+		return record, fmt.Errorf("missing data for %s %s", owner, record.Type)
+	}
+
+	if len(rdata) >= 2 && strings.EqualFold(rdata[0], "AWS") && strings.EqualFold(rdata[1], "ALIAS") {
+		return p.alias(record, rdata[2:])
+	}
+
+	switch {
+	case ttl >= 0:
+		p.lastTTL = ttl
+	case p.defaultTTL >= 0:
+		ttl = p.defaultTTL
+	case p.lastTTL >= 0:
+		ttl = p.lastTTL
+	default:
+		return record, fmt.Errorf("no TTL for %s %s and no $TTL", owner, record.Type)
+	}
+	record.TTL = ttl
+
+	// RDATA 中的網域名稱補上 origin
+	var names []int
+	switch record.Type {
+	case "CNAME", "NS", "PTR":
+		names = []int{0}
+	case "MX":
+		names = []int{1}
+	case "SRV":
+		names = []int{3}
+	case "SOA":
+		names = []int{0, 1}
+	}
+	for _, i := range names {
+		if i >= len(rdata) {
+			return record, fmt.Errorf("incomplete %s data", record.Type)
+		}
+		name, err := p.absolute(rdata[i])
+		if err != nil {
+			return record, err
+		}
+		rdata[i] = name
+	}
+	record.Values = []string{strings.Join(rdata, " ")}
+	return record, nil
+}
+
+func (p *parser) alias(record models.Route53Record, args []string) (models.Route53Record, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return record, fmt.Errorf("alias needs target and hosted zone ID")
+	}
+	target, err := p.absolute(args[0])
+	if err != nil {
+		return record, err
+	}
+	record.AliasTarget = target
+	record.AliasZoneID = args[1]
+	if len(args) == 3 {
+		evaluate, err := strconv.ParseBool(args[2])
+		if err != nil {
+			return record, fmt.Errorf("invalid evaluate-target-health %q", args[2])
+		}
+		record.EvaluateTargetHealth = evaluate
+	}
+	return record, nil
+}
+
+// absolute 將相對名稱補上 origin；@ 表示 origin。
+func (p *parser) absolute(name string) (string, error) {
+	switch {
+	case name == "@":
+		name = p.origin
+	case strings.HasSuffix(name, "."):
+	case p.origin == "." || p.origin == "":
+		return "", fmt.Errorf("relative name %q without $ORIGIN", name)
+	default:
+		name += "." + p.origin
+	}
+	if name == "." || name == "" {
+		return "", fmt.Errorf("no $ORIGIN for @")
+	}
+	return name, nil
+}
+
+// parseTTL 解析秒數或 BIND 單位（例如 1h30m）。
+func parseTTL(text string) (int64, bool) {
+	if text == "" || text[0] < '0' || text[0] > '9' {
+		return 0, false
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, true
+	}
+	var total, current int64
+	digits := false
+	for _, c := range strings.ToLower(text) {
+		if c >= '0' && c <= '9' {
+			current = current*10 + int64(c-'0')
+			digits = true
+			continue
+		}
+		unit := ttlUnit(c)
+		if unit == 0 || !digits {
+			return 0, false
+		}
+		total += current * unit
+		current, digits = 0, false
+	}
+	if digits {
+		return 0, false
+	}
+	return total, true
+}
+
+func ttlUnit(c rune) int64 {
+	switch c {
+	case 's':
+		return 1
+	case 'm':
+		return 60
+	case 'h':
+		return 3600
+	case 'd':
+		return 86400
+	case 'w':
+		return 604800
+	}
+	return 0
+}
+
+// entry 為一筆邏輯上的資料（括號內跨行的內容合併為一筆）。
+type entry struct {
+	line       int
+	blankOwner bool
+	tokens     []string
+}
+
+func readEntries(r io.Reader) ([]entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var (
+		entries []entry
+		current *entry
+		depth   int
+		n       int
+	)
+	for scanner.Scan() {
+		n++
+		line := scanner.Text()
+		if current == nil {
+			current = &entry{line: n, blankOwner: line != "" && (line[0] == ' ' || line[0] == '\t')}
+		}
+		tokens, d, err := tokenize(line, depth)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		depth = d
+		current.tokens = append(current.tokens, tokens...)
+		if depth == 0 {
+			if len(current.tokens) > 0 {
+				entries = append(entries, *current)
+			}
+			current = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.line)
+	}
+	return entries, nil
+}
+
+// tokenize 以空白切分一行，保留引號字串（含引號）與跳脫字元，忽略 ; 之後的註解。
+func tokenize(line string, depth int) ([]string, int, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		quoted  bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			current.WriteByte(c)
+			current.WriteByte(line[i+1])
+			i++
+		case c == '"':
+			current.WriteByte(c)
+			quoted = !quoted
+		case quoted:
+			current.WriteByte(c)
+		case c == ';':
+			flush()
+			return tokens, depth, nil
+		case c == ' ' || c == '\t':
+			flush()
+		case c == '(':
+			flush()
+			depth++
+		case c == ')':
+			flush()
+			if depth--; depth < 0 {
+				return nil, 0, fmt.Errorf("unbalanced parentheses")
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, 0, fmt.Errorf("unterminated quoted string")
+	}
+	flush()
+	return tokens, depth, nil
+}
@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	"github.com/vincent119/awsGUITools/internal/ops"
+)
+
+// fakeLambda 實作 ops.LambdaAPI 介面，記錄呼叫參數並回傳預設結果。
+type fakeLambda struct {
+	input *lambda.InvokeInput
+	out   *lambda.InvokeOutput
+}
+
+func (f *fakeLambda) Invoke(_ context.Context, params *lambda.InvokeInput, _ ...func(*lambda.Options)) (*lambda.InvokeOutput, error) {
+	f.input = params
+	return f.out, nil
+}
+
+func TestTestInvoke_DecodesTailLog(t *testing.T) {
+	log := "START RequestId: 1\nEND RequestId: 1\n"
+	client := &fakeLambda{out: &lambda.InvokeOutput{
+		StatusCode:      200,
+		ExecutedVersion: aws.String("4"),
+		FunctionError:   aws.String("Unhandled"),
+		LogResult:       aws.String(base64.StdEncoding.EncodeToString([]byte(log))),
+		Payload:         []byte(`{"errorMessage":"boom"}`),
+	}}
+
+	result, err := ops.NewLambdaOps(client).TestInvoke(context.Background(), "fn:live", []byte(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("TestInvoke() error = %v", err)
+	}
+	if result.Log != log || result.FunctionError != "Unhandled" || result.ExecutedVersion != "4" {
+		t.Errorf("result = %+v", result)
+	}
+	if aws.ToString(client.input.FunctionName) != "fn:live" || string(client.input.Payload) != `{"a":1}` {
+		t.Errorf("input = %+v", client.input)
+	}
+}
+
+func TestTestInvoke_KeepsResultWhenLogUndecodable(t *testing.T) {
+	client := &fakeLambda{out: &lambda.InvokeOutput{
+		StatusCode:      200,
+		ExecutedVersion: aws.String("4"),
+		FunctionError:   aws.String("Unhandled"),
+		LogResult:       aws.String("not base64!"),
+		Payload:         []byte(`{"errorMessage":"boom"}`),
+	}}
+
+	result, err := ops.NewLambdaOps(client).TestInvoke(context.Background(), "fn", nil)
+	if err != nil {
+		t.Fatalf("TestInvoke() error = %v", err)
+	}
+	if result.Payload != `{"errorMessage":"boom"}` || result.FunctionError != "Unhandled" || result.ExecutedVersion != "4" {
+		t.Errorf("result = %+v, want the invocation output kept", result)
+	}
+	if !strings.Contains(result.Log, "decode log result") {
+		t.Errorf("Log = %q, want a decode error note", result.Log)
+	}
+}
+
+func TestDecodeLogResult_Invalid(t *testing.T) {
+	if _, err := ops.DecodeLogResult("not base64!"); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+}
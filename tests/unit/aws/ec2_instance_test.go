@@ -0,0 +1,108 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+// ec2Stub 模擬 DescribeInstances 與 DescribeVolumes，記錄每次呼叫的 action 與查詢的 ID。
+type ec2Stub struct {
+	calls []string
+}
+
+func (s *ec2Stub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := req.Form.Get("Action")
+	w.Header().Set("Content-Type", "text/xml")
+	switch action {
+	case "DescribeInstances":
+		id := req.Form.Get("InstanceId.1")
+		s.calls = append(s.calls, action+" "+id)
+		fmt.Fprint(w, `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><reservationSet><item><instancesSet>`+
+			ec2InstanceXML(id)+`</instancesSet></item></reservationSet></DescribeInstancesResponse>`)
+	case "DescribeVolumes":
+		s.calls = append(s.calls, action+" "+req.Form.Get("VolumeId.1")+","+req.Form.Get("VolumeId.2"))
+		fmt.Fprint(w, `<DescribeVolumesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><volumeSet>
+<item><volumeId>vol-root</volumeId><size>30</size><volumeType>gp3</volumeType><encrypted>true</encrypted></item>
+<item><volumeId>vol-data</volumeId><size>500</size><volumeType>st1</volumeType><encrypted>false</encrypted></item>
+</volumeSet></DescribeVolumesResponse>`)
+	default:
+		http.Error(w, "unexpected "+action, http.StatusNotImplemented)
+	}
+}
+
+// ec2InstanceXML 回傳掛載兩個 EBS volume 的實例。
+func ec2InstanceXML(id string) string {
+	return `<item><instanceId>` + id + `</instanceId><instanceType>t3.micro</instanceType>
+<instanceState><code>16</code><name>running</name></instanceState>
+<placement><availabilityZone>us-east-1a</availabilityZone></placement>
+<privateIpAddress>10.0.0.5</privateIpAddress><vpcId>vpc-1</vpcId><subnetId>subnet-1</subnetId>
+<groupSet><item><groupId>sg-1</groupId><groupName>web</groupName></item></groupSet>
+<blockDeviceMapping>
+<item><deviceName>/dev/xvda</deviceName><ebs><volumeId>vol-root</volumeId><status>attached</status></ebs></item>
+<item><deviceName>/dev/sdf</deviceName><ebs><volumeId>vol-data</volumeId><status>attached</status></ebs></item>
+</blockDeviceMapping>
+<tagSet><item><key>Name</key><value>web-` + id + `</value></item></tagSet></item>`
+}
+
+func newStubEC2Client(url string) *ec2.Client {
+	return ec2.New(ec2.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(url),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+}
+
+func TestEC2Repository_GetInstance(t *testing.T) {
+	stub := &ec2Stub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	inst, err := repo.NewEC2Repository().GetInstance(context.Background(), newStubEC2Client(srv.URL), "i-1")
+	if err != nil {
+		t.Fatalf("GetInstance() error = %v", err)
+	}
+	if inst.ID != "i-1" || inst.Name != "web-i-1" || inst.State != "running" || inst.AvailabilityZone != "us-east-1a" {
+		t.Errorf("instance = %+v", inst)
+	}
+	if len(inst.Volumes) != 2 {
+		t.Fatalf("volumes = %+v", inst.Volumes)
+	}
+	root, data := inst.Volumes[0], inst.Volumes[1]
+	if root.ID != "vol-root" || root.DeviceName != "/dev/xvda" || root.SizeGiB != 30 || root.VolumeType != "gp3" || !root.Encrypted {
+		t.Errorf("root volume = %+v", root)
+	}
+	if data.ID != "vol-data" || data.SizeGiB != 500 || data.VolumeType != "st1" || data.Encrypted {
+		t.Errorf("data volume = %+v", data)
+	}
+
+	want := "DescribeInstances i-1\nDescribeVolumes vol-root,vol-data"
+	if got := strings.Join(stub.calls, "\n"); got != want {
+		t.Errorf("calls:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEC2Repository_GetInstanceNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><reservationSet/></DescribeInstancesResponse>`)
+	}))
+	defer srv.Close()
+
+	if _, err := repo.NewEC2Repository().GetInstance(context.Background(), newStubEC2Client(srv.URL), "i-missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("GetInstance(i-missing) error = %v, want not found", err)
+	}
+}
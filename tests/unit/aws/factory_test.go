@@ -0,0 +1,77 @@
+package aws_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/aws/clients"
+)
+
+// blockingLoader 在 region 為 blocked 時等待 release 關閉才回傳設定，模擬緩慢的 credentials 載入。
+type blockingLoader struct {
+	release chan struct{}
+}
+
+func (l blockingLoader) Config(ctx context.Context, _, region string) (aws.Config, error) {
+	if region == "blocked" {
+		select {
+		case <-l.release:
+		case <-ctx.Done():
+			return aws.Config{}, ctx.Err()
+		}
+	}
+	return aws.Config{Region: region, Credentials: aws.AnonymousCredentials{}}, nil
+}
+
+func TestFactory_S3DoesNotBlockOtherRegions(t *testing.T) {
+	loader := blockingLoader{release: make(chan struct{})}
+	factory := clients.NewFactory(loader)
+	ctx := context.Background()
+
+	const callers = 4
+	results := make(chan *s3.Client, callers)
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := factory.S3(ctx, "dev", "blocked")
+			if err != nil {
+				t.Errorf("S3(blocked) error = %v", err)
+			}
+			results <- client
+		}()
+	}
+
+	// 其他 region 的 client 不需等待 blocked 載入完成
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := factory.S3(ctx, "dev", "us-east-1"); err != nil {
+			t.Errorf("S3(us-east-1) error = %v", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("S3(us-east-1) blocked behind another region's config load")
+	}
+
+	close(loader.release)
+	wg.Wait()
+	close(results)
+	first, err := factory.S3(ctx, "dev", "blocked")
+	if err != nil {
+		t.Fatalf("S3(blocked) error = %v", err)
+	}
+	for client := range results {
+		if client != first {
+			t.Error("concurrent S3(blocked) calls returned different clients")
+		}
+	}
+}
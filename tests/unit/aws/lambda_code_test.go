@@ -0,0 +1,121 @@
+package aws_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// lambdaCodeStub 模擬 GetFunction（Code.Location 指回同一個 server）、ListFunctions 與 presigned URL 下載。
+type lambdaCodeStub struct {
+	url     string
+	archive []byte
+	sha     string
+}
+
+func (s *lambdaCodeStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch req.URL.Path {
+	case "/2015-03-31/functions/fn":
+		fmt.Fprintf(w, `{"Configuration":{"FunctionName":"fn","Version":"$LATEST","PackageType":"Zip","CodeSha256":%q,"CodeSize":%d},
+"Code":{"RepositoryType":"S3","Location":"%s/code.zip"}}`, s.sha, len(s.archive), s.url)
+	case "/2015-03-31/functions":
+		fmt.Fprint(w, `{"Functions":[{"FunctionName":"fn","Layers":[
+{"Arn":"arn:aws:lambda:us-east-1:123456789012:layer:shared-deps:3","CodeSize":2048},
+{"Arn":"not-a-layer-arn","CodeSize":1}]}]}`)
+	case "/code.zip":
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(s.archive)
+	default:
+		http.Error(w, "unexpected "+req.Method+" "+req.URL.Path, http.StatusNotImplemented)
+	}
+}
+
+func testArchive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, err := zw.Create("index.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(f, "exports.handler = async () => 'ok';\n")
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLambdaRepository_DownloadCode(t *testing.T) {
+	archive := testArchive(t)
+	sum := sha256.Sum256(archive)
+	stub := &lambdaCodeStub{archive: archive, sha: base64.StdEncoding.EncodeToString(sum[:])}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	stub.url = srv.URL
+	client := newStubLambdaClient(srv.URL)
+	r := repo.NewLambdaRepository()
+
+	code, err := r.GetCode(context.Background(), client, "fn")
+	if err != nil {
+		t.Fatalf("GetCode() error = %v", err)
+	}
+	if code.Location != srv.URL+"/code.zip" || code.CodeSizeBytes != int64(len(archive)) || code.Version != "$LATEST" {
+		t.Fatalf("code = %+v", code)
+	}
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "fn.zip")
+	var progress int64
+	if err := r.DownloadCode(context.Background(), code, dest, func(n int64) { progress += n }); err != nil {
+		t.Fatalf("DownloadCode() error = %v", err)
+	}
+	got, err := os.ReadFile(dest)
+	if err != nil || !bytes.Equal(got, archive) || progress != int64(len(archive)) {
+		t.Errorf("downloaded %d bytes (progress %d), err %v", len(got), progress, err)
+	}
+	if info, err := os.Stat(dest); err == nil && info.Mode().Perm() != 0o644 {
+		t.Errorf("downloaded file mode = %v, want 0644", info.Mode().Perm())
+	}
+
+	// CodeSha256 不符時不留下任何檔案
+	code.SHA256 = base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	if err := r.DownloadCode(context.Background(), code, filepath.Join(dir, "bad.zip"), nil); err == nil {
+		t.Error("expected CodeSha256 mismatch error")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("files left after mismatch: %v", entries)
+	}
+
+	image := models.LambdaCode{Function: "fn", PackageType: "Image", ImageURI: "123456789012.dkr.ecr.us-east-1.amazonaws.com/fn:latest"}
+	if err := r.DownloadCode(context.Background(), image, filepath.Join(dir, "image.zip"), nil); err == nil {
+		t.Error("expected error for container image function")
+	}
+}
+
+func TestLambdaRepository_Layers(t *testing.T) {
+	srv := httptest.NewServer(&lambdaCodeStub{})
+	defer srv.Close()
+	fns, err := repo.NewLambdaRepository().ListFunctions(context.Background(), newStubLambdaClient(srv.URL), nil)
+	if err != nil {
+		t.Fatalf("ListFunctions() error = %v", err)
+	}
+	want := []models.LambdaLayer{
+		{ARN: "arn:aws:lambda:us-east-1:123456789012:layer:shared-deps:3", Name: "shared-deps", Version: "3", CodeSizeBytes: 2048},
+		{ARN: "not-a-layer-arn", Name: "not-a-layer-arn", CodeSizeBytes: 1},
+	}
+	if len(fns) != 1 || fmt.Sprint(fns[0].Layers) != fmt.Sprint(want) {
+		t.Errorf("layers = %+v, want %+v", fns[0].Layers, want)
+	}
+}
@@ -0,0 +1,130 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// lambdaConcurrencyStub 模擬帳號設定、保留並行數與 provisioned concurrency 的 API，記錄寫入的請求。
+type lambdaConcurrencyStub struct {
+	reserved string // GetFunctionConcurrency 的回應
+	requests []string
+}
+
+func (s *lambdaConcurrencyStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	body, _ := io.ReadAll(req.Body)
+	route := req.Method + " " + req.URL.Path
+	switch route {
+	case "GET /2016-08-19/account-settings":
+		fmt.Fprint(w, `{"AccountLimit":{"ConcurrentExecutions":1000,"UnreservedConcurrentExecutions":850}}`)
+	case "GET /2019-09-30/functions/fn/concurrency":
+		fmt.Fprint(w, s.reserved)
+	case "GET /2019-09-30/functions/fn/provisioned-concurrency":
+		fmt.Fprint(w, `{"ProvisionedConcurrencyConfigs":[
+{"FunctionArn":"arn:aws:lambda:us-east-1:123456789012:function:fn:live","RequestedProvisionedConcurrentExecutions":10,
+"AllocatedProvisionedConcurrentExecutions":4,"AvailableProvisionedConcurrentExecutions":4,"Status":"IN_PROGRESS"},
+{"FunctionArn":"arn:aws:lambda:us-east-1:123456789012:function:fn:3","RequestedProvisionedConcurrentExecutions":5,
+"AllocatedProvisionedConcurrentExecutions":0,"AvailableProvisionedConcurrentExecutions":0,"Status":"FAILED","StatusReason":"not enough capacity"}]}`)
+	case "PUT /2017-10-31/functions/fn/concurrency",
+		"PUT /2019-09-30/functions/fn/provisioned-concurrency":
+		s.requests = append(s.requests, route+"?"+req.URL.RawQuery+" "+string(body))
+		fmt.Fprint(w, `{}`)
+	case "DELETE /2017-10-31/functions/fn/concurrency",
+		"DELETE /2019-09-30/functions/fn/provisioned-concurrency":
+		s.requests = append(s.requests, route+"?"+req.URL.RawQuery)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unexpected "+route, http.StatusNotImplemented)
+	}
+}
+
+func TestLambdaRepository_ReadConcurrency(t *testing.T) {
+	stub := &lambdaConcurrencyStub{reserved: `{"ReservedConcurrentExecutions":50}`}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	client := newStubLambdaClient(srv.URL)
+	r := repo.NewLambdaRepository()
+	ctx := context.Background()
+
+	account, err := r.GetAccountConcurrency(ctx, client)
+	if err != nil {
+		t.Fatalf("GetAccountConcurrency() error = %v", err)
+	}
+	if account != (models.LambdaAccountConcurrency{Limit: 1000, Unreserved: 850}) {
+		t.Errorf("account = %+v", account)
+	}
+
+	reserved, err := r.GetReservedConcurrency(ctx, client, "fn")
+	if err != nil {
+		t.Fatalf("GetReservedConcurrency() error = %v", err)
+	}
+	if reserved == nil || *reserved != 50 {
+		t.Errorf("reserved = %v, want 50", reserved)
+	}
+	stub.reserved = `{}`
+	if reserved, err = r.GetReservedConcurrency(ctx, client, "fn"); err != nil || reserved != nil {
+		t.Errorf("unset reserved = %v, %v; want nil", reserved, err)
+	}
+
+	configs, err := r.ListProvisionedConcurrency(ctx, client, "fn")
+	if err != nil {
+		t.Fatalf("ListProvisionedConcurrency() error = %v", err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("configs = %+v", configs)
+	}
+	live := configs[0]
+	if live.Qualifier != "live" || live.Requested != 10 || live.Allocated != 4 || live.Status != "IN_PROGRESS" {
+		t.Errorf("live = %+v", live)
+	}
+	if configs[1].Qualifier != "3" || configs[1].StatusReason != "not enough capacity" {
+		t.Errorf("version 3 = %+v", configs[1])
+	}
+}
+
+func TestLambdaRepository_SetConcurrency(t *testing.T) {
+	stub := &lambdaConcurrencyStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	client := newStubLambdaClient(srv.URL)
+	r := repo.NewLambdaRepository()
+	ctx := context.Background()
+
+	if err := r.SetReservedConcurrency(ctx, client, "fn", aws.Int32(0)); err != nil {
+		t.Fatalf("SetReservedConcurrency(0) error = %v", err)
+	}
+	if err := r.SetReservedConcurrency(ctx, client, "fn", nil); err != nil {
+		t.Fatalf("SetReservedConcurrency(nil) error = %v", err)
+	}
+	if err := r.SetProvisionedConcurrency(ctx, client, "fn", "live", 20); err != nil {
+		t.Fatalf("SetProvisionedConcurrency(20) error = %v", err)
+	}
+	if err := r.SetProvisionedConcurrency(ctx, client, "fn", "live", 0); err != nil {
+		t.Fatalf("SetProvisionedConcurrency(0) error = %v", err)
+	}
+	// $LATEST 不能設定 provisioned concurrency，不應送出請求
+	if err := r.SetProvisionedConcurrency(ctx, client, "fn", "$LATEST", 5); err == nil {
+		t.Error("expected error for $LATEST")
+	}
+
+	want := []string{
+		`PUT /2017-10-31/functions/fn/concurrency? {"ReservedConcurrentExecutions":0}`,
+		`DELETE /2017-10-31/functions/fn/concurrency?`,
+		`PUT /2019-09-30/functions/fn/provisioned-concurrency?Qualifier=live {"ProvisionedConcurrentExecutions":20}`,
+		`DELETE /2019-09-30/functions/fn/provisioned-concurrency?Qualifier=live`,
+	}
+	if got := strings.Join(stub.requests, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,135 @@
+package aws_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// lambdaConfigStub 模擬 UpdateFunctionConfiguration 與 GetFunctionConfiguration；
+// RevisionId 不是 rev-1 時回傳 PreconditionFailedException，GetFunctionConfiguration 前 pending 次回傳 InProgress。
+type lambdaConfigStub struct {
+	mu      sync.Mutex
+	pending int
+	polls   int
+	update  string
+}
+
+func (s *lambdaConfigStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.URL.Path != "/2015-03-31/functions/fn/configuration" {
+		http.Error(w, "unexpected "+req.Method+" "+req.URL.Path, http.StatusNotImplemented)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch req.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(req.Body)
+		if !strings.Contains(string(body), `"RevisionId":"rev-1"`) {
+			w.Header().Set("X-Amzn-ErrorType", "PreconditionFailedException")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `{"Type":"User","message":"The Revision Id provided does not match the latest Revision Id."}`)
+			return
+		}
+		s.update = string(body)
+		fmt.Fprint(w, `{"FunctionName":"fn","LastUpdateStatus":"InProgress"}`)
+	case http.MethodGet:
+		s.polls++
+		status := "Successful"
+		if s.polls <= s.pending {
+			status = "InProgress"
+		}
+		fmt.Fprintf(w, `{"FunctionName":"fn","LastUpdateStatus":%q}`, status)
+	}
+}
+
+func newStubLambdaClient(url string) *lambda.Client {
+	return lambda.New(lambda.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(url),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+}
+
+func TestLambdaRepository_UpdateConfigurationAndWait(t *testing.T) {
+	stub := &lambdaConfigStub{pending: 2}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	client := newStubLambdaClient(srv.URL)
+	r := repo.NewLambdaRepository()
+
+	// 只送出有變更的欄位：記憶體不變（0），清除全部環境變數
+	cfg := models.LambdaConfig{TimeoutSec: 30, Environment: map[string]string{}}
+	if err := r.UpdateConfiguration(context.Background(), client, "fn", "rev-1", cfg); err != nil {
+		t.Fatalf("UpdateConfiguration() error = %v", err)
+	}
+	for _, want := range []string{`"Timeout":30`, `"Environment":{"Variables":{}}`} {
+		if !strings.Contains(stub.update, want) {
+			t.Errorf("request missing %s: %s", want, stub.update)
+		}
+	}
+	if strings.Contains(stub.update, "MemorySize") {
+		t.Errorf("unchanged memory must not be sent: %s", stub.update)
+	}
+
+	var statuses []string
+	err := r.WaitForUpdate(context.Background(), client, "fn", time.Millisecond, func(status string) {
+		statuses = append(statuses, status)
+	})
+	if err != nil || strings.Join(statuses, ",") != "InProgress,InProgress,Successful" {
+		t.Errorf("WaitForUpdate() error = %v, polled %v", err, statuses)
+	}
+}
+
+func TestLambdaRepository_UpdateConfigurationRevisionChanged(t *testing.T) {
+	srv := httptest.NewServer(&lambdaConfigStub{})
+	defer srv.Close()
+
+	err := repo.NewLambdaRepository().UpdateConfiguration(context.Background(), newStubLambdaClient(srv.URL), "fn", "rev-0", models.LambdaConfig{MemoryMB: 256})
+	if !errors.Is(err, repo.ErrLambdaConfigChanged) {
+		t.Fatalf("error = %v, want ErrLambdaConfigChanged", err)
+	}
+}
+
+func TestValidateLambdaConfig(t *testing.T) {
+	valid := models.LambdaConfig{MemoryMB: 512, TimeoutSec: 30, Environment: map[string]string{"LOG_LEVEL": "info"}}
+	tests := []struct {
+		name    string
+		mutate  func(*models.LambdaConfig)
+		wantErr bool
+	}{
+		{"valid", func(*models.LambdaConfig) {}, false},
+		{"no environment", func(c *models.LambdaConfig) { c.Environment = nil }, false},
+		{"memory too small", func(c *models.LambdaConfig) { c.MemoryMB = 64 }, true},
+		{"memory too large", func(c *models.LambdaConfig) { c.MemoryMB = 20480 }, true},
+		{"timeout zero", func(c *models.LambdaConfig) { c.TimeoutSec = 0 }, true},
+		{"timeout too long", func(c *models.LambdaConfig) { c.TimeoutSec = 901 }, true},
+		{"invalid name", func(c *models.LambdaConfig) { c.Environment = map[string]string{"1BAD": "x"} }, true},
+		{"reserved name", func(c *models.LambdaConfig) { c.Environment = map[string]string{"AWS_REGION": "us-east-1"} }, true},
+		{"too large", func(c *models.LambdaConfig) { c.Environment = map[string]string{"BIG": strings.Repeat("x", 4096)} }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.mutate(&cfg)
+			if err := repo.ValidateLambdaConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLambdaConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
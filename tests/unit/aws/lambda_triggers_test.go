@@ -0,0 +1,161 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+const functionPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "s3-invoke", "Effect": "Allow", "Principal": {"Service": "s3.amazonaws.com"}, "Action": "lambda:InvokeFunction",
+     "Condition": {"StringEquals": {"AWS:SourceAccount": "123456789012"}, "ArnLike": {"AWS:SourceArn": "arn:aws:s3:::uploads"}}},
+    {"Sid": "rule", "Effect": "Allow", "Principal": {"Service": "events.amazonaws.com"}, "Action": "lambda:InvokeFunction",
+     "Condition": {"ArnLike": {"aws:sourcearn": "arn:aws:events:us-east-1:123456789012:rule/nightly"}}},
+    {"Sid": "api", "Effect": "Allow", "Principal": {"Service": "apigateway.amazonaws.com"}, "Action": "lambda:InvokeFunction"},
+    {"Sid": "url", "Effect": "Allow", "Principal": "*", "Action": "lambda:InvokeFunctionUrl",
+     "Condition": {"StringEquals": {"lambda:FunctionUrlAuthType": "NONE"}}},
+    {"Sid": "deny", "Effect": "Deny", "Principal": "*", "Action": "lambda:InvokeFunction"}
+  ]
+}`
+
+func TestParseLambdaPolicy(t *testing.T) {
+	triggers, err := repo.ParseLambdaPolicy(functionPolicy)
+	if err != nil {
+		t.Fatalf("ParseLambdaPolicy() error = %v", err)
+	}
+	var got []string
+	for _, tr := range triggers {
+		got = append(got, fmt.Sprintf("%s|%s|%s|%s", tr.ID, tr.Service, tr.Source, tr.SourceAccount))
+	}
+	want := []string{
+		"s3-invoke|S3|arn:aws:s3:::uploads|123456789012",
+		"rule|EventBridge|arn:aws:events:us-east-1:123456789012:rule/nightly|",
+		"api|API Gateway|apigateway.amazonaws.com|",
+		"url|Function URL|*|",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("triggers =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := repo.ParseLambdaPolicy("not json"); err == nil {
+		t.Error("expected error for invalid policy")
+	}
+}
+
+// lambdaMappingStub 模擬 ListEventSourceMappings、UpdateEventSourceMapping 與 GetEventSourceMapping；
+// 停用後 GetEventSourceMapping 前 pending 次回傳 Disabling。
+type lambdaMappingStub struct {
+	mu      sync.Mutex
+	pending int
+	polls   int
+	update  string
+}
+
+func (s *lambdaMappingStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == "/2015-03-31/event-source-mappings":
+		fmt.Fprint(w, `{"EventSourceMappings":[
+{"UUID":"m-1","EventSourceArn":"arn:aws:sqs:us-east-1:123456789012:orders","State":"Enabled","BatchSize":10,"LastProcessingResult":"OK"},
+{"UUID":"m-2","State":"Disabled","SelfManagedEventSource":{"Endpoints":{"KAFKA_BOOTSTRAP_SERVERS":["b1:9092","b2:9092"]}}}]}`)
+	case req.Method == http.MethodPut && req.URL.Path == "/2015-03-31/event-source-mappings/m-1":
+		body, _ := io.ReadAll(req.Body)
+		s.update = string(body)
+		fmt.Fprint(w, `{"UUID":"m-1","State":"Disabling"}`)
+	case req.Method == http.MethodGet && req.URL.Path == "/2015-03-31/event-source-mappings/m-1":
+		s.polls++
+		state := "Disabled"
+		if s.polls <= s.pending {
+			state = "Disabling"
+		}
+		fmt.Fprintf(w, `{"UUID":"m-1","State":%q}`, state)
+	default:
+		http.Error(w, "unexpected "+req.Method+" "+req.URL.Path, http.StatusNotImplemented)
+	}
+}
+
+func TestLambdaRepository_EventSourceMappings(t *testing.T) {
+	stub := &lambdaMappingStub{pending: 2}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	client := newStubLambdaClient(srv.URL)
+	r := repo.NewLambdaRepository()
+
+	mappings, err := r.ListEventSourceMappings(context.Background(), client, "fn")
+	if err != nil {
+		t.Fatalf("ListEventSourceMappings() error = %v", err)
+	}
+	if len(mappings) != 2 {
+		t.Fatalf("mappings = %+v", mappings)
+	}
+	if m := mappings[0]; m.Service != "SQS" || !m.Mapping || m.State != "Enabled" || m.BatchSize != 10 || m.LastResult != "OK" {
+		t.Errorf("sqs mapping = %+v", m)
+	}
+	if m := mappings[1]; m.Service != "Kafka" || m.Source != "b1:9092,b2:9092" {
+		t.Errorf("kafka mapping = %+v", m)
+	}
+
+	state, err := r.SetEventSourceMappingEnabled(context.Background(), client, "m-1", false)
+	if err != nil || state != "Disabling" || !strings.Contains(stub.update, `"Enabled":false`) {
+		t.Fatalf("SetEventSourceMappingEnabled() = %q, %v (request %s)", state, err, stub.update)
+	}
+	if err := r.WaitForMappingState(context.Background(), client, "m-1", "Disabled", time.Millisecond); err != nil || stub.polls != 3 {
+		t.Errorf("WaitForMappingState() error = %v after %d polls", err, stub.polls)
+	}
+}
+
+func TestLambdaRepository_GetFunctionTriggerLookupFails(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/2015-03-31/functions/fn":
+			fmt.Fprint(w, `{"Configuration":{"FunctionName":"fn","MemorySize":256},"Concurrency":{"ReservedConcurrentExecutions":5}}`)
+		case "/2015-03-31/event-source-mappings":
+			fmt.Fprint(w, `{"EventSourceMappings":[{"UUID":"m-1","EventSourceArn":"arn:aws:sqs:us-east-1:123456789012:orders"}]}`)
+		case "/2015-03-31/functions/fn/policy":
+			w.Header().Set("X-Amzn-ErrorType", "AccessDeniedException")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"Message":"not authorized to perform lambda:GetPolicy"}`)
+		default:
+			http.Error(w, "unexpected "+req.URL.Path, http.StatusNotImplemented)
+		}
+	}))
+	defer srv.Close()
+	client := newStubLambdaClient(srv.URL)
+	r := repo.NewLambdaRepository()
+
+	// 沒有 GetPolicy 權限時仍回傳函式設定與其他觸發來源，錯誤記錄於 Errors
+	fn, err := r.GetFunction(context.Background(), client, "fn")
+	if err != nil {
+		t.Fatalf("GetFunction() error = %v", err)
+	}
+	if fn.MemoryMB != 256 || len(fn.Triggers) != 1 {
+		t.Errorf("function = %+v", fn)
+	}
+	if msg := fn.Errors["GetPolicy"]; !strings.Contains(msg, "AccessDenied") {
+		t.Errorf("Errors = %v, want GetPolicy AccessDenied", fn.Errors)
+	}
+
+	// 設定編輯只需要 GetFunction，不查詢觸發來源
+	paths = nil
+	if _, err := r.GetFunctionConfig(context.Background(), client, "fn"); err != nil {
+		t.Fatalf("GetFunctionConfig() error = %v", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("GetFunctionConfig() requests = %v, want only GetFunction", paths)
+	}
+}
@@ -0,0 +1,81 @@
+package aws_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// lambdaAliasStub 模擬 ListAliases 與 UpdateAlias；RevisionId 不是 rev-1 時回傳 PreconditionFailedException。
+type lambdaAliasStub struct {
+	update string
+}
+
+func (s *lambdaAliasStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == "/2015-03-31/functions/fn/aliases":
+		fmt.Fprint(w, `{"Aliases":[
+{"Name":"live","FunctionVersion":"4","RevisionId":"rev-1","RoutingConfig":{"AdditionalVersionWeights":{"5":0.1}}},
+{"Name":"dev","FunctionVersion":"$LATEST"}]}`)
+	case req.Method == http.MethodPut && req.URL.Path == "/2015-03-31/functions/fn/aliases/live":
+		body, _ := io.ReadAll(req.Body)
+		if !strings.Contains(string(body), `"RevisionId":"rev-1"`) {
+			w.Header().Set("X-Amzn-ErrorType", "PreconditionFailedException")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `{"Type":"User","message":"revision mismatch"}`)
+			return
+		}
+		s.update = string(body)
+		fmt.Fprint(w, `{"Name":"live","FunctionVersion":"4","RevisionId":"rev-2","RoutingConfig":{"AdditionalVersionWeights":{}}}`)
+	default:
+		http.Error(w, "unexpected "+req.Method+" "+req.URL.Path, http.StatusNotImplemented)
+	}
+}
+
+func TestLambdaRepository_AliasRouting(t *testing.T) {
+	stub := &lambdaAliasStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	client := newStubLambdaClient(srv.URL)
+	r := repo.NewLambdaRepository()
+
+	aliases, err := r.ListAliases(context.Background(), client, "fn")
+	if err != nil {
+		t.Fatalf("ListAliases() error = %v", err)
+	}
+	if len(aliases) != 2 || aliases[0].AdditionalVersion != "5" || aliases[0].AdditionalWeight != 0.1 || aliases[1].AdditionalVersion != "" {
+		t.Fatalf("aliases = %+v", aliases)
+	}
+
+	// 回滾：全部流量回到版本 4，送出空的加權以清除 canary
+	alias, err := r.UpdateAlias(context.Background(), client, "fn", models.LambdaAlias{Name: "live", Version: "4"}, "rev-1")
+	if err != nil {
+		t.Fatalf("UpdateAlias() error = %v", err)
+	}
+	if alias.RevisionID != "rev-2" || alias.AdditionalVersion != "" {
+		t.Errorf("alias = %+v", alias)
+	}
+	for _, want := range []string{`"FunctionVersion":"4"`, `"AdditionalVersionWeights":{}`} {
+		if !strings.Contains(stub.update, want) {
+			t.Errorf("request missing %s: %s", want, stub.update)
+		}
+	}
+
+	_, err = r.UpdateAlias(context.Background(), client, "fn", models.LambdaAlias{Name: "live", Version: "4"}, "rev-0")
+	if !errors.Is(err, repo.ErrLambdaConfigChanged) {
+		t.Errorf("stale revision error = %v, want ErrLambdaConfigChanged", err)
+	}
+	_, err = r.UpdateAlias(context.Background(), client, "fn", models.LambdaAlias{Name: "live", Version: "4", AdditionalVersion: "5", AdditionalWeight: 1.5}, "rev-1")
+	if err == nil {
+		t.Error("expected validation error for weight above 100%")
+	}
+}
@@ -0,0 +1,81 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+func newStubRDSClient(url string) *rds.Client {
+	return rds.New(rds.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(url),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+}
+
+func TestRDSRepository_GetInstance(t *testing.T) {
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id := req.Form.Get("DBInstanceIdentifier")
+		requested = append(requested, req.Form.Get("Action")+" "+id)
+		w.Header().Set("Content-Type", "text/xml")
+		if id != "db-1" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>DBInstanceNotFound</Code><Message>DBInstance `+id+` not found.</Message></Error></ErrorResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<DescribeDBInstancesResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/"><DescribeDBInstancesResult><DBInstances><DBInstance>
+<DBInstanceIdentifier>db-1</DBInstanceIdentifier><DBInstanceArn>arn:aws:rds:us-east-1:123456789012:db:db-1</DBInstanceArn>
+<DBInstanceStatus>available</DBInstanceStatus><DBInstanceClass>db.t3.medium</DBInstanceClass>
+<AllocatedStorage>100</AllocatedStorage><StorageType>gp3</StorageType>
+<Engine>postgres</Engine><EngineVersion>16.3</EngineVersion><MultiAZ>true</MultiAZ>
+<Endpoint><Address>db-1.example.rds.amazonaws.com</Address><Port>5432</Port></Endpoint>
+<DBSubnetGroup><DBSubnetGroupName>private</DBSubnetGroupName></DBSubnetGroup>
+<DBParameterGroups><DBParameterGroup><DBParameterGroupName>pg16</DBParameterGroupName></DBParameterGroup></DBParameterGroups>
+<VpcSecurityGroups><VpcSecurityGroupMembership><VpcSecurityGroupId>sg-db</VpcSecurityGroupId></VpcSecurityGroupMembership></VpcSecurityGroups>
+<TagList><Tag><Key>env</Key><Value>prod</Value></Tag></TagList>
+</DBInstance></DBInstances></DescribeDBInstancesResult></DescribeDBInstancesResponse>`)
+	}))
+	defer srv.Close()
+	client := newStubRDSClient(srv.URL)
+	r := repo.NewRDSRepository()
+
+	db, err := r.GetInstance(context.Background(), client, "db-1")
+	if err != nil {
+		t.Fatalf("GetInstance() error = %v", err)
+	}
+	if db.ID != "db-1" || db.Status != "available" || db.InstanceClass != "db.t3.medium" || !db.MultiAZ {
+		t.Errorf("instance = %+v", db)
+	}
+	if db.StorageGiB != 100 || db.StorageType != "gp3" || db.Engine != "postgres" || db.EngineVersion != "16.3" {
+		t.Errorf("storage/engine = %+v", db)
+	}
+	if db.Endpoint != "db-1.example.rds.amazonaws.com:5432" || db.SubnetGroup != "private" {
+		t.Errorf("endpoint = %q, subnet group = %q", db.Endpoint, db.SubnetGroup)
+	}
+	if len(db.ParameterGroup) != 1 || db.ParameterGroup[0] != "pg16" || len(db.SecurityGroups) != 1 || db.Tags["env"] != "prod" {
+		t.Errorf("parameter groups = %v, security groups = %v, tags = %v", db.ParameterGroup, db.SecurityGroups, db.Tags)
+	}
+
+	if _, err := r.GetInstance(context.Background(), client, "db-missing"); err == nil || !strings.Contains(err.Error(), "DBInstanceNotFound") {
+		t.Errorf("GetInstance(db-missing) error = %v, want DBInstanceNotFound", err)
+	}
+	want := "DescribeDBInstances db-1\nDescribeDBInstances db-missing"
+	if got := strings.Join(requested, "\n"); got != want {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, want)
+	}
+}
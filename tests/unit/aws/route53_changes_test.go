@@ -0,0 +1,192 @@
+package aws_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// route53ChangeStub 以 httptest 模擬 ChangeResourceRecordSets 與 GetChange；
+// GetChange 前 pending 次回傳 PENDING，之後回傳 INSYNC。
+type route53ChangeStub struct {
+	mu      sync.Mutex
+	pending int
+	polls   int
+	batch   string
+}
+
+func (s *route53ChangeStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case req.Method == http.MethodPost && strings.TrimSuffix(req.URL.Path, "/") == "/2013-04-01/hostedzone/Z123/rrset":
+		body, _ := io.ReadAll(req.Body)
+		s.batch = string(body)
+		writeXML(w, http.StatusOK, `<ChangeResourceRecordSetsResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+<ChangeInfo><Id>/change/C1</Id><Status>PENDING</Status><SubmittedAt>2024-01-02T03:04:05Z</SubmittedAt></ChangeInfo>
+</ChangeResourceRecordSetsResponse>`)
+	case req.Method == http.MethodGet && req.URL.Path == "/2013-04-01/change/C1":
+		s.polls++
+		status := "INSYNC"
+		if s.polls <= s.pending {
+			status = "PENDING"
+		}
+		writeXML(w, http.StatusOK, `<GetChangeResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+<ChangeInfo><Id>/change/C1</Id><Status>`+status+`</Status><SubmittedAt>2024-01-02T03:04:05Z</SubmittedAt></ChangeInfo>
+</GetChangeResponse>`)
+	default:
+		http.Error(w, "unexpected "+req.Method+" "+req.URL.Path, http.StatusNotImplemented)
+	}
+}
+
+func newStubRoute53Client(url string) *route53.Client {
+	return route53.New(route53.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(url),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+}
+
+func TestRoute53Repository_ChangeRecordsAndWait(t *testing.T) {
+	stub := &route53ChangeStub{pending: 2}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	client := newStubRoute53Client(srv.URL)
+	r := repo.NewRoute53Repository()
+
+	changes := []models.Route53Change{
+		{Action: "DELETE", Record: models.Route53Record{Name: "old.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}}},
+		{Action: "UPSERT", Record: models.Route53Record{Name: "cdn.example.com.", Type: "A", AliasTarget: "d1.cloudfront.net.", AliasZoneID: "Z2FDTNDATAQYW2"}},
+		{Action: "CREATE", Record: models.Route53Record{Name: "w.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.7"}, SetIdentifier: "blue", Weight: aws.Int64(0), HealthCheckID: "hc-1"}},
+	}
+	info, err := r.ChangeRecords(context.Background(), client, "Z123", "test", changes)
+	if err != nil {
+		t.Fatalf("ChangeRecords() error = %v", err)
+	}
+	if info.ID != "C1" || info.Status != "PENDING" {
+		t.Errorf("change info = %+v, want C1 PENDING", info)
+	}
+	for _, want := range []string{"<Action>DELETE</Action>", "<Value>192.0.2.1</Value>", "<DNSName>d1.cloudfront.net.</DNSName>", "<Comment>test</Comment>", "<SetIdentifier>blue</SetIdentifier>", "<Weight>0</Weight>", "<HealthCheckId>hc-1</HealthCheckId>"} {
+		if !strings.Contains(stub.batch, want) {
+			t.Errorf("batch missing %s: %s", want, stub.batch)
+		}
+	}
+	if strings.Count(stub.batch, "<TTL>") != 2 {
+		t.Errorf("alias record must not send TTL: %s", stub.batch)
+	}
+
+	var statuses []string
+	info, err = r.WaitForChange(context.Background(), client, info.ID, time.Millisecond, func(info models.Route53ChangeInfo) {
+		statuses = append(statuses, info.Status)
+	})
+	if err != nil {
+		t.Fatalf("WaitForChange() error = %v", err)
+	}
+	if info.Status != "INSYNC" || strings.Join(statuses, ",") != "PENDING,PENDING,INSYNC" {
+		t.Errorf("status = %s, polled %v", info.Status, statuses)
+	}
+}
+
+func TestRoute53Repository_WaitForChangeCanceled(t *testing.T) {
+	srv := httptest.NewServer(&route53ChangeStub{pending: 1000})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := repo.NewRoute53Repository().WaitForChange(ctx, newStubRoute53Client(srv.URL), "C1", 5*time.Millisecond, nil)
+	if err == nil {
+		t.Fatal("expected error when context ends before INSYNC")
+	}
+}
+
+func TestRoute53Repository_ChangeRecordsRejectsInvalid(t *testing.T) {
+	srv := httptest.NewServer(&route53ChangeStub{})
+	defer srv.Close()
+
+	changes := []models.Route53Change{{Action: "CREATE", Record: models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"not-an-ip"}}}}
+	if _, err := repo.NewRoute53Repository().ChangeRecords(context.Background(), newStubRoute53Client(srv.URL), "Z123", "", changes); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestValidateRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  models.Route53Record
+		wantErr bool
+	}{
+		{"A record", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1", "192.0.2.2"}}, false},
+		{"AAAA record", models.Route53Record{Name: "a.example.com.", Type: "AAAA", TTL: 300, Values: []string{"2001:db8::1"}}, false},
+		{"A with IPv6", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 300, Values: []string{"2001:db8::1"}}, true},
+		{"missing name", models.Route53Record{Type: "A", TTL: 300, Values: []string{"192.0.2.1"}}, true},
+		{"unknown type", models.Route53Record{Name: "a.example.com.", Type: "ALIAS", TTL: 300, Values: []string{"x"}}, true},
+		{"no values", models.Route53Record{Name: "a.example.com.", Type: "TXT", TTL: 300}, true},
+		{"negative TTL", models.Route53Record{Name: "a.example.com.", Type: "TXT", TTL: -1, Values: []string{`"x"`}}, true},
+		{"two CNAME values", models.Route53Record{Name: "a.example.com.", Type: "CNAME", TTL: 300, Values: []string{"b.", "c."}}, true},
+		{"MX", models.Route53Record{Name: "example.com.", Type: "MX", TTL: 300, Values: []string{"10 mail.example.com."}}, false},
+		{"MX without priority", models.Route53Record{Name: "example.com.", Type: "MX", TTL: 300, Values: []string{"mail.example.com."}}, true},
+		{"SRV", models.Route53Record{Name: "_sip._tcp.example.com.", Type: "SRV", TTL: 300, Values: []string{"1 10 5060 sip.example.com."}}, false},
+		{"SRV missing port", models.Route53Record{Name: "_sip._tcp.example.com.", Type: "SRV", TTL: 300, Values: []string{"1 10 sip.example.com."}}, true},
+		{"alias", models.Route53Record{Name: "cdn.example.com.", Type: "A", AliasTarget: "d1.cloudfront.net.", AliasZoneID: "Z2FDTNDATAQYW2"}, false},
+		{"alias without zone", models.Route53Record{Name: "cdn.example.com.", Type: "A", AliasTarget: "d1.cloudfront.net."}, true},
+		{"alias with values", models.Route53Record{Name: "cdn.example.com.", Type: "A", AliasTarget: "d1.cloudfront.net.", AliasZoneID: "Z2", Values: []string{"192.0.2.1"}}, true},
+		{"weighted", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "blue", Weight: aws.Int64(10), HealthCheckID: "hc-1"}, false},
+		{"weight out of range", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "blue", Weight: aws.Int64(256)}, true},
+		{"policy without set identifier", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, Region: "us-east-1"}, true},
+		{"set identifier without policy", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "blue"}, true},
+		{"two policies", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "blue", Weight: aws.Int64(1), Region: "us-east-1"}, true},
+		{"failover", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "primary", Failover: "PRIMARY"}, false},
+		{"invalid failover", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "primary", Failover: "FIRST"}, true},
+		{"geolocation", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "jp", GeoLocation: &models.Route53GeoLocation{CountryCode: "JP"}}, false},
+		{"empty geolocation", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "jp", GeoLocation: &models.Route53GeoLocation{}}, true},
+		{"multivalue alias", models.Route53Record{Name: "a.example.com.", Type: "A", AliasTarget: "d1.cloudfront.net.", AliasZoneID: "Z2", SetIdentifier: "m", MultiValueAnswer: true}, true},
+		{"ip-based", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "office", CidrRouting: &models.Route53CidrRouting{CollectionID: "c-1", LocationName: "office"}}, false},
+		{"ip-based without location", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "office", CidrRouting: &models.Route53CidrRouting{CollectionID: "c-1"}}, true},
+		{"geoproximity", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "east", GeoProximity: &models.Route53GeoProximity{AWSRegion: "us-east-1", Bias: aws.Int32(10)}}, false},
+		{"empty geoproximity", models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "east", GeoProximity: &models.Route53GeoProximity{Latitude: "45.50"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.ValidateRecord(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoute53Repository_ChangeRecordsRoutingLocations(t *testing.T) {
+	stub := &route53ChangeStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	client := newStubRoute53Client(srv.URL)
+	r := repo.NewRoute53Repository()
+
+	// DELETE 需帶入 CIDR 與 geoproximity 位置，Route53 才會視為同一個 record set
+	changes := []models.Route53Change{
+		{Action: "DELETE", Record: models.Route53Record{Name: "ip.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "office",
+			CidrRouting: &models.Route53CidrRouting{CollectionID: "c-1", LocationName: "office"}}},
+		{Action: "DELETE", Record: models.Route53Record{Name: "geo.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.2"}, SetIdentifier: "west",
+			GeoProximity: &models.Route53GeoProximity{Latitude: "45.50", Longitude: "-122.68", Bias: aws.Int32(-20)}}},
+	}
+	if _, err := r.ChangeRecords(context.Background(), client, "Z123", "", changes); err != nil {
+		t.Fatalf("ChangeRecords() error = %v", err)
+	}
+	for _, want := range []string{"<CollectionId>c-1</CollectionId>", "<LocationName>office</LocationName>", "<Latitude>45.50</Latitude>", "<Longitude>-122.68</Longitude>", "<Bias>-20</Bias>"} {
+		if !strings.Contains(stub.batch, want) {
+			t.Errorf("batch missing %s: %s", want, stub.batch)
+		}
+	}
+}
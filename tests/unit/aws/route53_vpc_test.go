@@ -0,0 +1,63 @@
+package aws_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+func TestRoute53Repository_GetHostedZoneAndAssociateVPC(t *testing.T) {
+	var associate string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch path := strings.TrimSuffix(req.URL.Path, "/"); {
+		case req.Method == http.MethodGet && path == "/2013-04-01/hostedzone/Z123":
+			writeXML(w, http.StatusOK, `<GetHostedZoneResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+<HostedZone><Id>/hostedzone/Z123</Id><Name>internal.example.com.</Name><CallerReference>r</CallerReference>
+<Config><Comment>private</Comment><PrivateZone>true</PrivateZone></Config><ResourceRecordSetCount>4</ResourceRecordSetCount></HostedZone>
+<VPCs><VPC><VPCRegion>ap-northeast-1</VPCRegion><VPCId>vpc-1</VPCId></VPC><VPC><VPCRegion>us-east-1</VPCRegion><VPCId>vpc-2</VPCId></VPC></VPCs>
+</GetHostedZoneResponse>`)
+		case req.Method == http.MethodPost && path == "/2013-04-01/hostedzone/Z123/associatevpc":
+			body, _ := io.ReadAll(req.Body)
+			associate = string(body)
+			writeXML(w, http.StatusOK, `<AssociateVPCWithHostedZoneResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+<ChangeInfo><Id>/change/C2</Id><Status>PENDING</Status><SubmittedAt>2024-01-02T03:04:05Z</SubmittedAt></ChangeInfo>
+</AssociateVPCWithHostedZoneResponse>`)
+		default:
+			http.Error(w, "unexpected "+req.Method+" "+req.URL.Path, http.StatusNotImplemented)
+		}
+	}))
+	defer srv.Close()
+	client := newStubRoute53Client(srv.URL)
+	r := repo.NewRoute53Repository()
+
+	zone, err := r.GetHostedZone(context.Background(), client, "Z123")
+	if err != nil {
+		t.Fatalf("GetHostedZone() error = %v", err)
+	}
+	want := models.Route53HostedZone{
+		ID: "Z123", Name: "internal.example.com.", RecordCount: 4, IsPrivate: true, Comment: "private",
+		VPCs: []models.Route53VPC{{ID: "vpc-1", Region: "ap-northeast-1"}, {ID: "vpc-2", Region: "us-east-1"}},
+	}
+	if !reflect.DeepEqual(zone, want) {
+		t.Errorf("zone = %+v, want %+v", zone, want)
+	}
+
+	info, err := r.AssociateVPC(context.Background(), client, "Z123", models.Route53VPC{ID: "vpc-3", Region: "eu-west-1"})
+	if err != nil {
+		t.Fatalf("AssociateVPC() error = %v", err)
+	}
+	if info.ID != "C2" || !strings.Contains(associate, "<VPCId>vpc-3</VPCId>") || !strings.Contains(associate, "<VPCRegion>eu-west-1</VPCRegion>") {
+		t.Errorf("change = %+v, request = %s", info, associate)
+	}
+
+	if _, err := r.AssociateVPC(context.Background(), client, "Z123", models.Route53VPC{ID: "subnet-1", Region: "eu-west-1"}); err == nil {
+		t.Error("expected error for invalid VPC ID")
+	}
+}
@@ -0,0 +1,211 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// s3Stub 以 httptest 模擬 S3 bucket 相關 API，並記錄同時處理中的 bucket 查詢數量。
+type s3Stub struct {
+	buckets  []string
+	denied   string // GetBucketPolicy 回傳 AccessDenied 的 bucket
+	public   string // ACL 與 policy 皆公開、未設定 Block Public Access 的 bucket
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (s *s3Stub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	bucket := strings.Trim(req.URL.Path, "/")
+	if bucket == "" {
+		var b strings.Builder
+		b.WriteString("<ListAllMyBucketsResult><Buckets>")
+		for _, name := range s.buckets {
+			fmt.Fprintf(&b, "<Bucket><Name>%s</Name></Bucket>", name)
+		}
+		b.WriteString("</Buckets></ListAllMyBucketsResult>")
+		writeXML(w, http.StatusOK, b.String())
+		return
+	}
+
+	current := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if current <= peak || s.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	// 讓先送出的 bucket 較晚完成，確認輸出順序不受完成順序影響
+	time.Sleep(time.Duration(len(s.buckets)-bucketIndex(s.buckets, bucket)) * time.Millisecond)
+
+	query := req.URL.Query()
+	switch {
+	case req.Method == http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case query.Has("location"):
+		writeXML(w, http.StatusOK, "<LocationConstraint>eu-west-1</LocationConstraint>")
+	case query.Has("versioning"):
+		writeXML(w, http.StatusOK, "<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>")
+	case query.Has("encryption"):
+		writeS3Error(w, http.StatusNotFound, "ServerSideEncryptionConfigurationNotFoundError")
+	case query.Has("lifecycle"):
+		writeS3Error(w, http.StatusNotFound, "NoSuchLifecycleConfiguration")
+	case query.Has("policy"):
+		if bucket == s.denied {
+			writeS3Error(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		if bucket == s.public {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"Version":"2012-10-17","Statement":[{"Sid":"PublicRead","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::`+bucket+`/*"}]}`)
+			return
+		}
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucketPolicy")
+	case query.Has("policyStatus"):
+		writeXML(w, http.StatusOK, "<PolicyStatus><IsPublic>true</IsPublic></PolicyStatus>")
+	case query.Has("publicAccessBlock"):
+		if bucket == s.public {
+			writeS3Error(w, http.StatusNotFound, "NoSuchPublicAccessBlockConfiguration")
+			return
+		}
+		writeXML(w, http.StatusOK, "<PublicAccessBlockConfiguration><BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>true</IgnorePublicAcls><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>true</RestrictPublicBuckets></PublicAccessBlockConfiguration>")
+	case query.Has("ownershipControls"):
+		if bucket == s.public {
+			writeXML(w, http.StatusOK, "<OwnershipControls><Rule><ObjectOwnership>ObjectWriter</ObjectOwnership></Rule></OwnershipControls>")
+			return
+		}
+		writeXML(w, http.StatusOK, "<OwnershipControls><Rule><ObjectOwnership>BucketOwnerEnforced</ObjectOwnership></Rule></OwnershipControls>")
+	case query.Has("acl"):
+		grants := `<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner-id</ID><DisplayName>owner</DisplayName></Grantee><Permission>FULL_CONTROL</Permission></Grant>`
+		if bucket == s.public {
+			grants += `<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://acs.amazonaws.com/groups/global/AllUsers</URI></Grantee><Permission>READ</Permission></Grant>`
+		}
+		writeXML(w, http.StatusOK, "<AccessControlPolicy><Owner><ID>owner-id</ID><DisplayName>owner</DisplayName></Owner><AccessControlList>"+grants+"</AccessControlList></AccessControlPolicy>")
+	case query.Has("logging"):
+		writeXML(w, http.StatusOK, "<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>"+bucket+"/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>")
+	case query.Has("replication"):
+		writeS3Error(w, http.StatusNotFound, "ReplicationConfigurationNotFoundError")
+	case query.Has("cors"):
+		writeS3Error(w, http.StatusNotFound, "NoSuchCORSConfiguration")
+	case query.Has("object-lock"):
+		writeXML(w, http.StatusOK, "<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>30</Days></DefaultRetention></Rule></ObjectLockConfiguration>")
+	case query.Has("tagging"):
+		writeXML(w, http.StatusOK, "<Tagging><TagSet><Tag><Key>owner</Key><Value>"+bucket+"</Value></Tag></TagSet></Tagging>")
+	default:
+		writeS3Error(w, http.StatusBadRequest, "NotImplemented")
+	}
+}
+
+func bucketIndex(buckets []string, name string) int {
+	for i, b := range buckets {
+		if b == name {
+			return i
+		}
+	}
+	return 0
+}
+
+func writeXML(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>`+body)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	writeXML(w, status, "<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+}
+
+func newStubS3Client(url string) *s3.Client {
+	return newRegionalStubS3Client(url, "us-east-1")
+}
+
+func newRegionalStubS3Client(url, region string) *s3.Client {
+	return s3.New(s3.Options{
+		Region:           region,
+		BaseEndpoint:     aws.String(url),
+		UsePathStyle:     true,
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+}
+
+func TestS3Repository_ListBuckets_Enrichment(t *testing.T) {
+	stub := &s3Stub{denied: "bucket-03"}
+	for i := range 12 {
+		stub.buckets = append(stub.buckets, fmt.Sprintf("bucket-%02d", i))
+	}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	r.SetConcurrency(4)
+	buckets, err := r.ListBuckets(context.Background(), newStubS3Client(srv.URL), nil)
+	if err != nil {
+		t.Fatalf("ListBuckets() error = %v", err)
+	}
+
+	if len(buckets) != len(stub.buckets) {
+		t.Fatalf("ListBuckets() returned %d buckets, want %d", len(buckets), len(stub.buckets))
+	}
+	for i, bucket := range buckets {
+		if bucket.Name != stub.buckets[i] {
+			t.Errorf("buckets[%d] = %s, want %s (order must follow ListBuckets)", i, bucket.Name, stub.buckets[i])
+		}
+		if !bucket.Enriched || bucket.Region != "eu-west-1" || bucket.Versioning != "Enabled" {
+			t.Errorf("%s not enriched: %+v", bucket.Name, bucket)
+		}
+		if bucket.Tags["owner"] != bucket.Name {
+			t.Errorf("%s tags = %v", bucket.Name, bucket.Tags)
+		}
+	}
+
+	if peak := stub.peak.Load(); peak > 4 {
+		t.Errorf("peak concurrent bucket lookups = %d, want <= 4", peak)
+	}
+
+	// 未設定的 encryption/lifecycle/policy 不算錯誤，AccessDenied 要保留在模型中
+	for _, bucket := range buckets {
+		if bucket.Name == stub.denied {
+			if _, ok := bucket.Errors["GetBucketPolicy"]; !ok || len(bucket.Errors) != 1 {
+				t.Errorf("%s errors = %v, want only GetBucketPolicy", bucket.Name, bucket.Errors)
+			}
+			continue
+		}
+		if len(bucket.Errors) != 0 {
+			t.Errorf("%s errors = %v, want none", bucket.Name, bucket.Errors)
+		}
+	}
+}
+
+func TestS3Repository_EnrichBuckets_OnDone(t *testing.T) {
+	stub := &s3Stub{buckets: []string{"a", "b", "c"}}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	buckets := []models.S3Bucket{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	seen := make(map[int]string)
+	repo.NewS3Repository().EnrichBuckets(context.Background(), newStubS3Client(srv.URL), nil, buckets, func(i int, bucket models.S3Bucket) {
+		seen[i] = bucket.Name
+	})
+
+	if len(seen) != len(buckets) {
+		t.Fatalf("onDone called for %d buckets, want %d", len(seen), len(buckets))
+	}
+	for i, bucket := range buckets {
+		if seen[i] != bucket.Name {
+			t.Errorf("onDone index %d reported %s, want %s", i, seen[i], bucket.Name)
+		}
+	}
+}
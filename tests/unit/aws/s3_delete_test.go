@@ -0,0 +1,124 @@
+package aws_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+// deleteStub 模擬 DeleteObjects：記錄每批 key 數，名稱含 "locked" 的 key 回傳 AccessDenied；
+// failBatch 大於 0 時第 failBatch 批整批失敗。
+type deleteStub struct {
+	batches   []int
+	quiet     []bool
+	failBatch int
+}
+
+func (d *deleteStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost || !req.URL.Query().Has("delete") {
+		writeS3Error(w, http.StatusBadRequest, "NotImplemented")
+		return
+	}
+	var body struct {
+		Quiet   bool `xml:"Quiet"`
+		Objects []struct {
+			Key string `xml:"Key"`
+		} `xml:"Object"`
+	}
+	if err := xml.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+	d.batches = append(d.batches, len(body.Objects))
+	d.quiet = append(d.quiet, body.Quiet)
+	if len(d.batches) == d.failBatch {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("<DeleteResult>")
+	for _, obj := range body.Objects {
+		if strings.Contains(obj.Key, "locked") {
+			fmt.Fprintf(&b, "<Error><Key>%s</Key><Code>AccessDenied</Code><Message>Access Denied</Message></Error>", obj.Key)
+		}
+	}
+	b.WriteString("</DeleteResult>")
+	writeXML(w, http.StatusOK, b.String())
+}
+
+func TestS3Repository_DeleteObjects_Batches(t *testing.T) {
+	stub := &deleteStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	keys := make([]string, 2500)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("logs/%04d.gz", i)
+	}
+	keys[1200] = "logs/locked-1.gz"
+	keys[2499] = "logs/locked-2.gz"
+
+	var progress []int
+	processed, failed, err := repo.NewS3Repository().DeleteObjects(context.Background(), newStubS3Client(srv.URL), "bucket", keys, func(done int) {
+		progress = append(progress, done)
+	})
+	if err != nil {
+		t.Fatalf("DeleteObjects() error = %v", err)
+	}
+	if processed != len(keys) {
+		t.Errorf("processed = %d, want %d", processed, len(keys))
+	}
+
+	if want := []int{1000, 1000, 500}; fmt.Sprint(stub.batches) != fmt.Sprint(want) {
+		t.Errorf("batch sizes = %v, want %v", stub.batches, want)
+	}
+	for i, quiet := range stub.quiet {
+		if !quiet {
+			t.Errorf("batch %d was not sent in quiet mode", i)
+		}
+	}
+	if want := []int{1000, 2000, 2500}; fmt.Sprint(progress) != fmt.Sprint(want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+
+	if len(failed) != 2 {
+		t.Fatalf("failed = %+v, want 2 entries", failed)
+	}
+	if failed[0].Key != "logs/locked-1.gz" || failed[0].Code != "AccessDenied" || failed[0].Message != "Access Denied" {
+		t.Errorf("failed[0] = %+v", failed[0])
+	}
+	if failed[1].Key != "logs/locked-2.gz" {
+		t.Errorf("failed[1] = %+v", failed[1])
+	}
+}
+
+func TestS3Repository_DeleteObjects_BatchFails(t *testing.T) {
+	stub := &deleteStub{failBatch: 2}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	keys := make([]string, 2500)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("logs/%04d.gz", i)
+	}
+	keys[10] = "logs/locked-1.gz"
+
+	processed, failed, err := repo.NewS3Repository().DeleteObjects(context.Background(), newStubS3Client(srv.URL), "bucket", keys, nil)
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("DeleteObjects() error = %v, want AccessDenied", err)
+	}
+	// 第一批已送出並刪除，只有其中 locked 的 key 失敗；第三批不應送出
+	if processed != 1000 || len(failed) != 1 || failed[0].Key != "logs/locked-1.gz" {
+		t.Errorf("processed = %d, failed = %+v; want 1000 processed with 1 failure", processed, failed)
+	}
+	if want := []int{1000, 1000}; fmt.Sprint(stub.batches) != fmt.Sprint(want) {
+		t.Errorf("batch sizes = %v, want %v", stub.batches, want)
+	}
+}
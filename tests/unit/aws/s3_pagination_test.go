@@ -0,0 +1,84 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+// pagedObjects 以 httptest 模擬 ListObjectsV2 分頁，token 為下一個物件的索引。
+func pagedObjects(total int) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		start, _ := strconv.Atoi(query.Get("continuation-token"))
+		size, _ := strconv.Atoi(query.Get("max-keys"))
+		end := min(start+size, total)
+
+		var b strings.Builder
+		b.WriteString("<ListBucketResult>")
+		for i := start; i < end; i++ {
+			fmt.Fprintf(&b, "<Contents><Key>key-%04d</Key><Size>1</Size><LastModified>2024-01-01T00:00:00Z</LastModified></Contents>", i)
+		}
+		if end < total {
+			fmt.Fprintf(&b, "<IsTruncated>true</IsTruncated><NextContinuationToken>%d</NextContinuationToken>", end)
+		} else {
+			b.WriteString("<IsTruncated>false</IsTruncated>")
+		}
+		b.WriteString("</ListBucketResult>")
+		writeXML(w, http.StatusOK, b.String())
+	}
+}
+
+func TestS3Repository_ListObjectsPage(t *testing.T) {
+	srv := httptest.NewServer(pagedObjects(120))
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	client := newStubS3Client(srv.URL)
+
+	var (
+		token string
+		keys  []string
+		pages int
+	)
+	for {
+		page, err := r.ListObjectsPage(context.Background(), client, "bucket", "", token, 50)
+		if err != nil {
+			t.Fatalf("ListObjectsPage() error = %v", err)
+		}
+		pages++
+		for _, obj := range page.Objects {
+			keys = append(keys, obj.Key)
+		}
+		if page.NextToken == "" {
+			break
+		}
+		token = page.NextToken
+	}
+
+	if pages != 3 {
+		t.Errorf("pages = %d, want 3", pages)
+	}
+	if len(keys) != 120 || keys[0] != "key-0000" || keys[119] != "key-0119" {
+		t.Errorf("keys = %d (first %v), want key-0000..key-0119", len(keys), keys[:1])
+	}
+}
+
+func TestS3Repository_ListObjects_FollowsContinuation(t *testing.T) {
+	srv := httptest.NewServer(pagedObjects(2500))
+	defer srv.Close()
+
+	objects, err := repo.NewS3Repository().ListObjects(context.Background(), newStubS3Client(srv.URL), "bucket", "")
+	if err != nil {
+		t.Fatalf("ListObjects() error = %v", err)
+	}
+	if len(objects) != 2500 {
+		t.Errorf("ListObjects() returned %d objects, want 2500", len(objects))
+	}
+}
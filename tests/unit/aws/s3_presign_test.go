@@ -0,0 +1,93 @@
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+func newSigningS3Client(expires time.Time) *s3.Client {
+	return s3.New(s3.Options{
+		Region:       "ap-northeast-1",
+		BaseEndpoint: aws.String("https://s3.example.test"),
+		UsePathStyle: true,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+				CanExpire:       !expires.IsZero(),
+				Expires:         expires,
+			}, nil
+		}),
+	})
+}
+
+func TestS3Repository_PresignObject(t *testing.T) {
+	credsExpire := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	client := newSigningS3Client(credsExpire)
+	r := repo.NewS3Repository()
+
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		presigned, err := r.PresignObject(context.Background(), client, "bucket", "dir/report 1.csv", method, time.Hour)
+		if err != nil {
+			t.Fatalf("PresignObject(%s) error = %v", method, err)
+		}
+		u, err := url.Parse(presigned.URL)
+		if err != nil {
+			t.Fatalf("invalid URL %q: %v", presigned.URL, err)
+		}
+		if u.Path != "/bucket/dir/report 1.csv" {
+			t.Errorf("%s path = %q", method, u.Path)
+		}
+		query := u.Query()
+		if query.Get("X-Amz-Expires") != "3600" || query.Get("X-Amz-Signature") == "" {
+			t.Errorf("%s query missing expiry or signature: %v", method, query)
+		}
+		if presigned.Method != method || presigned.Key != "dir/report 1.csv" {
+			t.Errorf("presigned = %+v", presigned)
+		}
+		if !presigned.CredentialsExpireAt.Equal(credsExpire) {
+			t.Errorf("CredentialsExpireAt = %v, want %v", presigned.CredentialsExpireAt, credsExpire)
+		}
+		if d := time.Until(presigned.ExpiresAt); d < 59*time.Minute || d > time.Hour {
+			t.Errorf("ExpiresAt is %v from now, want about 1h", d)
+		}
+	}
+}
+
+func TestS3Repository_PresignObject_Invalid(t *testing.T) {
+	client := newSigningS3Client(time.Time{})
+	r := repo.NewS3Repository()
+
+	tests := []struct {
+		name    string
+		key     string
+		method  string
+		expires time.Duration
+	}{
+		{"too long", "a.txt", http.MethodGet, 8 * 24 * time.Hour},
+		{"zero expiry", "a.txt", http.MethodGet, 0},
+		{"empty key", "", http.MethodGet, time.Hour},
+		{"unsupported method", "a.txt", http.MethodDelete, time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := r.PresignObject(context.Background(), client, "bucket", tt.key, tt.method, tt.expires); err == nil {
+				t.Error("PresignObject() should fail")
+			}
+		})
+	}
+
+	presigned, err := r.PresignObject(context.Background(), client, "bucket", "a.txt", http.MethodGet, repo.MaxPresignExpiry)
+	if err != nil || !presigned.CredentialsExpireAt.IsZero() {
+		t.Errorf("long-lived credentials: %+v, %v", presigned, err)
+	}
+}
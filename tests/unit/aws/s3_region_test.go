@@ -0,0 +1,107 @@
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+// redirectingS3 模擬 us-east-1 endpoint：bucket 位於其他 region 時，
+// 除 GetBucketLocation 外一律回傳 301 PermanentRedirect 並帶 x-amz-bucket-region。
+func redirectingS3(bucketRegion string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Has("location") {
+			writeXML(w, http.StatusOK, "<LocationConstraint>"+bucketRegion+"</LocationConstraint>")
+			return
+		}
+		w.Header().Set("X-Amz-Bucket-Region", bucketRegion)
+		if req.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+		writeXML(w, http.StatusMovedPermanently,
+			"<Error><Code>PermanentRedirect</Code><Message>The bucket you are attempting to access must be addressed using the specified endpoint.</Message></Error>")
+	}
+}
+
+func TestS3Repository_BucketRegion_FromRedirect(t *testing.T) {
+	srv := httptest.NewServer(redirectingS3("eu-west-1"))
+	defer srv.Close()
+
+	region, err := repo.NewS3Repository().BucketRegion(context.Background(), newStubS3Client(srv.URL), "eu-bucket")
+	if err != nil {
+		t.Fatalf("BucketRegion() error = %v", err)
+	}
+	if region != "eu-west-1" {
+		t.Errorf("BucketRegion() = %q, want eu-west-1", region)
+	}
+}
+
+func TestRedirectRegion(t *testing.T) {
+	srv := httptest.NewServer(redirectingS3("ap-northeast-1"))
+	defer srv.Close()
+
+	_, err := repo.NewS3Repository().ListObjects(context.Background(), newStubS3Client(srv.URL), "tokyo-bucket", "")
+	if err == nil {
+		t.Fatal("ListObjects() against the wrong region should fail")
+	}
+	if !strings.Contains(err.Error(), "PermanentRedirect") {
+		t.Errorf("ListObjects() error = %v, want PermanentRedirect", err)
+	}
+
+	region, ok := repo.RedirectRegion(err)
+	if !ok || region != "ap-northeast-1" {
+		t.Errorf("RedirectRegion() = %q, %v; want ap-northeast-1, true", region, ok)
+	}
+
+	if _, ok := repo.RedirectRegion(nil); ok {
+		t.Error("RedirectRegion(nil) should report no redirect")
+	}
+}
+
+func TestS3Repository_ListBuckets_UsesBucketRegionClient(t *testing.T) {
+	home := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.Trim(req.URL.Path, "/") == "" {
+			writeXML(w, http.StatusOK, "<ListAllMyBucketsResult><Buckets><Bucket><Name>eu-bucket</Name></Bucket></Buckets></ListAllMyBucketsResult>")
+			return
+		}
+		redirectingS3("eu-west-1")(w, req)
+	}))
+	defer home.Close()
+	eu := httptest.NewServer(&s3Stub{buckets: []string{"eu-bucket"}})
+	defer eu.Close()
+
+	var requested []string
+	regional := func(ctx context.Context, region string) (*s3.Client, error) {
+		requested = append(requested, region)
+		return newRegionalStubS3Client(eu.URL, region), nil
+	}
+
+	buckets, err := repo.NewS3Repository().ListBuckets(context.Background(), newStubS3Client(home.URL), regional)
+	if err != nil {
+		t.Fatalf("ListBuckets() error = %v", err)
+	}
+	if len(buckets) != 1 {
+		t.Fatalf("ListBuckets() returned %d buckets, want 1", len(buckets))
+	}
+
+	bucket := buckets[0]
+	if bucket.Region != "eu-west-1" {
+		t.Errorf("Region = %q, want eu-west-1", bucket.Region)
+	}
+	if len(requested) != 1 || requested[0] != "eu-west-1" {
+		t.Errorf("regional clients requested = %v, want [eu-west-1]", requested)
+	}
+	if len(bucket.Errors) != 0 {
+		t.Errorf("Errors = %v, want none once the eu-west-1 client is used", bucket.Errors)
+	}
+	if bucket.Versioning != "Enabled" || bucket.Tags["owner"] != "eu-bucket" {
+		t.Errorf("bucket not enriched through regional client: %+v", bucket)
+	}
+}
@@ -0,0 +1,221 @@
+package aws_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+// rulesStub 模擬 lifecycle 與 CORS API：GET lifecycle 回傳固定規則，CORS 未設定，PUT/DELETE 記錄請求。
+type rulesStub struct {
+	puts    map[string]string // query 名稱 → request body
+	deletes []string
+}
+
+func (s *rulesStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	name := "lifecycle"
+	if query.Has("cors") {
+		name = "cors"
+	}
+	switch req.Method {
+	case http.MethodGet:
+		if name == "cors" {
+			writeS3Error(w, http.StatusNotFound, "NoSuchCORSConfiguration")
+			return
+		}
+		w.Header().Set("x-amz-transition-default-minimum-object-size", "varies_by_storage_class")
+		writeXML(w, http.StatusOK, `<LifecycleConfiguration>`+
+			`<Rule><ID>archive</ID><Status>Enabled</Status>`+
+			`<Filter><And><Prefix>logs/</Prefix><Tag><Key>tier</Key><Value>cold</Value></Tag><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></And></Filter>`+
+			`<Transition><Days>30</Days><StorageClass>STANDARD_IA</StorageClass></Transition>`+
+			`<Expiration><Days>365</Days></Expiration>`+
+			`<NoncurrentVersionExpiration><NoncurrentDays>30</NoncurrentDays><NewerNoncurrentVersions>3</NewerNoncurrentVersions></NoncurrentVersionExpiration>`+
+			`</Rule>`+
+			`<Rule><ID>uploads</ID><Status>Disabled</Status><Prefix>tmp/</Prefix>`+
+			`<AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>`+
+			`</Rule></LifecycleConfiguration>`)
+	case http.MethodPut:
+		body, _ := io.ReadAll(req.Body)
+		if s.puts == nil {
+			s.puts = make(map[string]string)
+		}
+		s.puts[name] = string(body) + "|" + req.Header.Get("x-amz-transition-default-minimum-object-size")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		s.deletes = append(s.deletes, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusBadRequest, "NotImplemented")
+	}
+}
+
+func TestS3Repository_GetLifecycleRules(t *testing.T) {
+	srv := httptest.NewServer(&rulesStub{})
+	defer srv.Close()
+	r := repo.NewS3Repository()
+
+	rules, err := r.GetLifecycleRules(context.Background(), newStubS3Client(srv.URL), "bucket")
+	if err != nil {
+		t.Fatalf("GetLifecycleRules() error = %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(rules))
+	}
+	archive := rules[0]
+	if archive.Prefix != "logs/" || archive.Tags["tier"] != "cold" || archive.ObjectSizeGreaterThan != 1024 {
+		t.Errorf("filter not parsed: %+v", archive)
+	}
+	if len(archive.Transitions) != 1 || archive.Transitions[0] != (models.S3LifecycleTransition{Days: 30, StorageClass: "STANDARD_IA"}) {
+		t.Errorf("transitions = %+v", archive.Transitions)
+	}
+	if archive.ExpirationDays != 365 || archive.NoncurrentExpirationDays != 30 || archive.NewerNoncurrentVersions != 3 {
+		t.Errorf("expiration not parsed: %+v", archive)
+	}
+	// 舊式規則層 Prefix
+	if rules[1].Prefix != "tmp/" || rules[1].Status != "Disabled" || rules[1].AbortIncompleteUploadDays != 7 {
+		t.Errorf("legacy rule = %+v", rules[1])
+	}
+}
+
+func TestS3Repository_PutLifecycleRules(t *testing.T) {
+	stub := &rulesStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	r := repo.NewS3Repository()
+	client := newStubS3Client(srv.URL)
+
+	rules := []models.S3LifecycleRule{
+		{ID: "whole", Status: "Enabled", ExpirationDays: 90},
+		{ID: "tagged", Status: "Enabled", Tags: map[string]string{"b": "2", "a": "1"}, Transitions: []models.S3LifecycleTransition{{Days: 0, StorageClass: "GLACIER_IR"}}},
+	}
+	if err := r.PutLifecycleRules(context.Background(), client, "bucket", rules); err != nil {
+		t.Fatalf("PutLifecycleRules() error = %v", err)
+	}
+	body := stub.puts["lifecycle"]
+	for _, want := range []string{
+		"<Filter><Prefix></Prefix></Filter>",
+		"<And><Tag><Key>a</Key><Value>1</Value></Tag><Tag><Key>b</Key><Value>2</Value></Tag></And>",
+		"<Days>0</Days><StorageClass>GLACIER_IR</StorageClass>",
+		"|varies_by_storage_class",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("request does not contain %q:\n%s", want, body)
+		}
+	}
+
+	if err := r.PutLifecycleRules(context.Background(), client, "bucket", nil); err != nil {
+		t.Fatalf("PutLifecycleRules(nil) error = %v", err)
+	}
+	if len(stub.deletes) != 1 || stub.deletes[0] != "lifecycle" {
+		t.Errorf("deletes = %v, want [lifecycle]", stub.deletes)
+	}
+
+	invalid := []models.S3LifecycleRule{{ID: "x", Status: "On", ExpirationDays: 1}}
+	if err := r.PutLifecycleRules(context.Background(), client, "bucket", invalid); err == nil {
+		t.Error("invalid rules should not be sent")
+	}
+}
+
+func TestS3Repository_CORSRules(t *testing.T) {
+	stub := &rulesStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	r := repo.NewS3Repository()
+	client := newStubS3Client(srv.URL)
+
+	rules, err := r.GetCORSRules(context.Background(), client, "bucket")
+	if err != nil || len(rules) != 0 {
+		t.Fatalf("unconfigured CORS: %v, %v", rules, err)
+	}
+
+	put := []models.S3CORSRule{{ID: "web", AllowedOrigins: []string{"https://example.com"}, AllowedMethods: []string{"GET", "HEAD"}, MaxAgeSeconds: 600}}
+	if err := r.PutCORSRules(context.Background(), client, "bucket", put); err != nil {
+		t.Fatalf("PutCORSRules() error = %v", err)
+	}
+	if body := stub.puts["cors"]; !strings.Contains(body, "<AllowedOrigin>https://example.com</AllowedOrigin>") || !strings.Contains(body, "<MaxAgeSeconds>600</MaxAgeSeconds>") {
+		t.Errorf("unexpected request: %s", body)
+	}
+}
+
+func TestValidateLifecycleRules(t *testing.T) {
+	valid := models.S3LifecycleRule{ID: "r", Status: "Enabled", ExpirationDays: 30}
+	tests := []struct {
+		name   string
+		modify func(*models.S3LifecycleRule)
+		ok     bool
+	}{
+		{"valid", func(*models.S3LifecycleRule) {}, true},
+		{"missing id", func(r *models.S3LifecycleRule) { r.ID = "" }, false},
+		{"bad status", func(r *models.S3LifecycleRule) { r.Status = "enabled" }, false},
+		{"no action", func(r *models.S3LifecycleRule) { r.ExpirationDays = 0 }, false},
+		{"days and date", func(r *models.S3LifecycleRule) { r.ExpirationDate = "2030-01-01" }, false},
+		{"bad date", func(r *models.S3LifecycleRule) { r.ExpirationDays, r.ExpirationDate = 0, "2030/01/01" }, false},
+		{"unknown class", func(r *models.S3LifecycleRule) {
+			r.ExpirationDays = 0
+			r.Transitions = []models.S3LifecycleTransition{{Days: 10, StorageClass: "COLD"}}
+		}, false},
+		{"IA too early", func(r *models.S3LifecycleRule) {
+			r.ExpirationDays = 0
+			r.Transitions = []models.S3LifecycleTransition{{Days: 10, StorageClass: "STANDARD_IA"}}
+		}, false},
+		{"transition after expiration", func(r *models.S3LifecycleRule) {
+			r.Transitions = []models.S3LifecycleTransition{{Days: 60, StorageClass: "GLACIER"}}
+		}, false},
+		{"transition before expiration", func(r *models.S3LifecycleRule) {
+			r.Transitions = []models.S3LifecycleTransition{{Days: 0, StorageClass: "GLACIER"}}
+		}, true},
+		{"delete marker with tag", func(r *models.S3LifecycleRule) {
+			r.ExpirationDays, r.ExpiredObjectDeleteMarker = 0, true
+			r.Tags = map[string]string{"k": "v"}
+		}, false},
+		{"noncurrent date", func(r *models.S3LifecycleRule) {
+			r.NoncurrentTransitions = []models.S3LifecycleTransition{{Date: "2030-01-01", StorageClass: "GLACIER"}}
+		}, false},
+		{"newer versions without expiration", func(r *models.S3LifecycleRule) { r.NewerNoncurrentVersions = 2 }, false},
+		{"size range inverted", func(r *models.S3LifecycleRule) { r.ObjectSizeGreaterThan, r.ObjectSizeLessThan = 100, 10 }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := valid
+			tt.modify(&rule)
+			err := repo.ValidateLifecycleRules([]models.S3LifecycleRule{rule})
+			if (err == nil) != tt.ok {
+				t.Errorf("ValidateLifecycleRules() error = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+
+	if err := repo.ValidateLifecycleRules([]models.S3LifecycleRule{valid, valid}); err == nil {
+		t.Error("duplicate IDs should be rejected")
+	}
+}
+
+func TestValidateCORSRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule models.S3CORSRule
+		ok   bool
+	}{
+		{"valid", models.S3CORSRule{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}}, true},
+		{"no origins", models.S3CORSRule{AllowedMethods: []string{"GET"}}, false},
+		{"no methods", models.S3CORSRule{AllowedOrigins: []string{"*"}}, false},
+		{"bad method", models.S3CORSRule{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"PATCH"}}, false},
+		{"two wildcards", models.S3CORSRule{AllowedOrigins: []string{"https://*.*.com"}, AllowedMethods: []string{"GET"}}, false},
+		{"negative max age", models.S3CORSRule{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}, MaxAgeSeconds: -1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.ValidateCORSRules([]models.S3CORSRule{tt.rule})
+			if (err == nil) != tt.ok {
+				t.Errorf("ValidateCORSRules() error = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+	"github.com/vincent119/awsGUITools/internal/models"
+)
+
+func TestS3Repository_ListBuckets_Security(t *testing.T) {
+	stub := &s3Stub{buckets: []string{"private", "website"}, public: "website"}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	buckets, err := repo.NewS3Repository().ListBuckets(context.Background(), newStubS3Client(srv.URL), nil)
+	if err != nil {
+		t.Fatalf("ListBuckets() error = %v", err)
+	}
+
+	private := buckets[0].Security
+	if private.Public || !private.PublicAccessBlock.Configured || !private.PublicAccessBlock.RestrictPublicBuckets {
+		t.Errorf("private bucket security = %+v", private)
+	}
+	if private.ObjectOwnership != "BucketOwnerEnforced" || private.Owner != "owner" || len(private.Grants) != 1 {
+		t.Errorf("private bucket ownership/ACL = %+v", private)
+	}
+
+	website := buckets[1].Security
+	if !website.Public || len(website.PublicReasons) != 2 {
+		t.Errorf("website bucket should be public via ACL and policy: %+v", website)
+	}
+	if len(website.Grants) != 2 || !website.Grants[0].Public || website.Grants[0].Grantee != "AllUsers" || website.Grants[0].Permission != "READ" {
+		t.Errorf("website grants = %+v, want AllUsers READ first", website.Grants)
+	}
+	if len(website.PolicyFindings) != 1 || website.PolicyFindings[0].Sid != "PublicRead" {
+		t.Errorf("website policy findings = %+v", website.PolicyFindings)
+	}
+	if website.PolicyIsPublic == nil || !*website.PolicyIsPublic {
+		t.Errorf("website PolicyIsPublic = %v", website.PolicyIsPublic)
+	}
+	if len(buckets[1].Errors) != 0 {
+		t.Errorf("website errors = %v", buckets[1].Errors)
+	}
+	// 詳情專用的設定不在清單補齊時查詢
+	if website.Logging != "" || website.ObjectLock != "" {
+		t.Errorf("list enrichment should not fetch logging/object lock: %+v", website)
+	}
+}
+
+func TestS3Repository_GetBucket_Security(t *testing.T) {
+	srv := httptest.NewServer(&s3Stub{buckets: []string{"audit"}})
+	defer srv.Close()
+
+	bucket, err := repo.NewS3Repository().GetBucket(context.Background(), newStubS3Client(srv.URL), "audit")
+	if err != nil {
+		t.Fatalf("GetBucket() error = %v", err)
+	}
+	sec := bucket.Security
+	if sec.Logging != "s3://logs/audit/" {
+		t.Errorf("Logging = %q", sec.Logging)
+	}
+	if sec.ObjectLock != "Enabled (GOVERNANCE, 30 days)" {
+		t.Errorf("ObjectLock = %q", sec.ObjectLock)
+	}
+	if sec.Replication != "" || len(bucket.Errors) != 0 {
+		t.Errorf("Replication = %q, errors = %v; want not configured without errors", sec.Replication, bucket.Errors)
+	}
+}
+
+func TestAnalyzePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		wantSids    []string
+		conditional []bool
+		wantErr     bool
+	}{
+		{
+			name:     "single statement object",
+			policy:   `{"Statement":{"Sid":"All","Effect":"Allow","Principal":"*","Action":["s3:GetObject","s3:ListBucket"]}}`,
+			wantSids: []string{"All"}, conditional: []bool{false},
+		},
+		{
+			name: "AWS wildcard in list, deny and account principals ignored",
+			policy: `{"Statement":[
+				{"Sid":"Acct","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"s3:*"},
+				{"Sid":"Deny","Effect":"Deny","Principal":"*","Action":"s3:*"},
+				{"Sid":"Mixed","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","*"]},"Action":"s3:GetObject"}]}`,
+			wantSids: []string{"Mixed"}, conditional: []bool{false},
+		},
+		{
+			name:     "condition marks finding as conditional",
+			policy:   `{"Statement":[{"Sid":"Vpc","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Condition":{"StringEquals":{"aws:SourceVpce":"vpce-1"}}}]}`,
+			wantSids: []string{"Vpc"}, conditional: []bool{true},
+		},
+		{
+			name:     "NotPrincipal allow",
+			policy:   `{"Statement":[{"Sid":"Not","Effect":"Allow","NotPrincipal":{"AWS":"arn:aws:iam::1:root"},"Action":"s3:GetObject"}]}`,
+			wantSids: []string{"Not"}, conditional: []bool{false},
+		},
+		{name: "invalid JSON", policy: `{"Statement":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := repo.AnalyzePolicy(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AnalyzePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(findings) != len(tt.wantSids) {
+				t.Fatalf("findings = %+v, want sids %v", findings, tt.wantSids)
+			}
+			for i, f := range findings {
+				if f.Sid != tt.wantSids[i] || f.Conditional != tt.conditional[i] {
+					t.Errorf("findings[%d] = %+v", i, f)
+				}
+				if f.NotPrincipal != (f.Sid == "Not") {
+					t.Errorf("findings[%d].NotPrincipal = %v", i, f.NotPrincipal)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluatePublic(t *testing.T) {
+	publicGrant := []models.S3Grant{{Grantee: "AllUsers", Permission: "READ", Public: true}}
+	openPolicy := []models.S3PolicyFinding{{Sid: "All", Effect: "Allow"}}
+
+	tests := []struct {
+		name string
+		sec  models.S3BucketSecurity
+		want bool
+	}{
+		{"public ACL", models.S3BucketSecurity{Grants: publicGrant}, true},
+		{"public ACL ignored", models.S3BucketSecurity{Grants: publicGrant, PublicAccessBlock: models.S3PublicAccessBlock{Configured: true, IgnorePublicAcls: true}}, false},
+		{"ACLs disabled", models.S3BucketSecurity{Grants: publicGrant, ObjectOwnership: "BucketOwnerEnforced"}, false},
+		{"public policy", models.S3BucketSecurity{PolicyFindings: openPolicy}, true},
+		{"public policy restricted", models.S3BucketSecurity{PolicyFindings: openPolicy, PublicAccessBlock: models.S3PublicAccessBlock{Configured: true, RestrictPublicBuckets: true}}, false},
+		{"conditional policy", models.S3BucketSecurity{PolicyFindings: []models.S3PolicyFinding{{Sid: "Vpc", Conditional: true}}}, false},
+		{"policy status overrides parsing", models.S3BucketSecurity{PolicyFindings: openPolicy, PolicyIsPublic: aws.Bool(false)}, false},
+		{"public ACL ignored by account", models.S3BucketSecurity{Grants: publicGrant, AccountPublicAccessBlock: models.S3PublicAccessBlock{Configured: true, IgnorePublicAcls: true}}, false},
+		{"public policy restricted by account", models.S3BucketSecurity{PolicyFindings: openPolicy, AccountPublicAccessBlock: models.S3PublicAccessBlock{Configured: true, RestrictPublicBuckets: true}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sec := tt.sec
+			repo.EvaluatePublic(&sec)
+			if sec.Public != tt.want {
+				t.Errorf("Public = %v, want %v (reasons %v)", sec.Public, tt.want, sec.PublicReasons)
+			}
+			if sec.Public != (len(sec.PublicReasons) > 0) {
+				t.Errorf("PublicReasons = %v inconsistent with Public", sec.PublicReasons)
+			}
+		})
+	}
+}
+
+func TestEffectivePublicAccessBlock(t *testing.T) {
+	bucket := models.S3PublicAccessBlock{Configured: true, BlockPublicAcls: true}
+	account := models.S3PublicAccessBlock{Configured: true, IgnorePublicAcls: true, RestrictPublicBuckets: true}
+	got := repo.EffectivePublicAccessBlock(bucket, account)
+	want := models.S3PublicAccessBlock{Configured: true, BlockPublicAcls: true, IgnorePublicAcls: true, RestrictPublicBuckets: true}
+	if got != want {
+		t.Errorf("EffectivePublicAccessBlock() = %+v, want %+v", got, want)
+	}
+	if got := repo.EffectivePublicAccessBlock(models.S3PublicAccessBlock{}, models.S3PublicAccessBlock{}); got.Configured {
+		t.Errorf("EffectivePublicAccessBlock() of unset levels = %+v, want not configured", got)
+	}
+}
+
+func TestAnalyzePolicy_NotPrincipal(t *testing.T) {
+	findings, err := repo.AnalyzePolicy(`{"Statement":[{"Sid":"Not","Effect":"Allow",
+		"NotPrincipal":{"Service":"logging.s3.amazonaws.com","AWS":["arn:aws:iam::1:root","arn:aws:iam::2:root"]},"Action":"s3:GetObject"}]}`)
+	if err != nil {
+		t.Fatalf("AnalyzePolicy() error = %v", err)
+	}
+	want := []string{"arn:aws:iam::1:root", "arn:aws:iam::2:root", "logging.s3.amazonaws.com"}
+	if len(findings) != 1 || !findings[0].NotPrincipal || fmt.Sprint(findings[0].Excluded) != fmt.Sprint(want) {
+		t.Errorf("findings = %+v, want NotPrincipal excluding %v", findings, want)
+	}
+}
@@ -0,0 +1,233 @@
+package aws_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+// memS3 為記憶體中的 S3 相容 stub，支援 PutObject、分段上傳、Range GET、HeadObject 與 ListObjectsV2。
+type memS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+	aborted atomic.Int32
+	ranged  atomic.Int32
+	failAt  int // UploadPart 於此段編號回傳錯誤；0 表示不失敗
+}
+
+func newMemS3() *memS3 {
+	return &memS3{objects: make(map[string][]byte), uploads: make(map[string]map[int][]byte)}
+}
+
+func (m *memS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// path-style：/bucket/key
+	_, key, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	query := req.URL.Query()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch {
+	case req.Method == http.MethodGet && key == "":
+		m.list(w, query.Get("prefix"))
+	case req.Method == http.MethodPost && query.Has("uploads"):
+		id := fmt.Sprintf("upload-%d", len(m.uploads)+1)
+		m.uploads[id] = make(map[int][]byte)
+		writeXML(w, http.StatusOK, "<InitiateMultipartUploadResult><UploadId>"+id+"</UploadId></InitiateMultipartUploadResult>")
+	case req.Method == http.MethodPut && query.Has("uploadId"):
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		if number == m.failAt {
+			writeS3Error(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		m.uploads[query.Get("uploadId")][number] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+		w.WriteHeader(http.StatusOK)
+	case req.Method == http.MethodPost && query.Has("uploadId"):
+		parts := m.uploads[query.Get("uploadId")]
+		numbers := make([]int, 0, len(parts))
+		for n := range parts {
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+		var data []byte
+		for _, n := range numbers {
+			data = append(data, parts[n]...)
+		}
+		m.objects[key] = data
+		delete(m.uploads, query.Get("uploadId"))
+		writeXML(w, http.StatusOK, "<CompleteMultipartUploadResult><Key>"+key+"</Key></CompleteMultipartUploadResult>")
+	case req.Method == http.MethodDelete && query.Has("uploadId"):
+		m.aborted.Add(1)
+		delete(m.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodPut:
+		body, _ := io.ReadAll(req.Body)
+		m.objects[key] = body
+		w.WriteHeader(http.StatusOK)
+	case req.Method == http.MethodHead:
+		data, ok := m.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+	case req.Method == http.MethodGet:
+		data, ok := m.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		if rng := req.Header.Get("Range"); rng != "" {
+			m.ranged.Add(1)
+			var start, end int
+			fmt.Sscanf(rng, "bytes=%d-%d", &start, &end)
+			end = min(end, len(data)-1)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(data[start : end+1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	default:
+		writeS3Error(w, http.StatusBadRequest, "NotImplemented")
+	}
+}
+
+func (m *memS3) list(w http.ResponseWriter, prefix string) {
+	keys := make([]string, 0, len(m.objects))
+	for k := range m.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString("<ListBucketResult><IsTruncated>false</IsTruncated>")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "<Contents><Key>%s</Key><Size>%d</Size></Contents>", k, len(m.objects[k]))
+	}
+	b.WriteString("</ListBucketResult>")
+	writeXML(w, http.StatusOK, b.String())
+}
+
+func writeTempFile(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+	data := bytes.Repeat([]byte("0123456789abcdef"), size/16+1)[:size]
+	path := filepath.Join(t.TempDir(), "payload.bin")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path, data
+}
+
+func TestS3Repository_UploadDownload_Multipart(t *testing.T) {
+	stub := newMemS3()
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	r.SetPartSize(1024)
+	client := newStubS3Client(srv.URL)
+	src, data := writeTempFile(t, 5000)
+
+	var uploaded atomic.Int64
+	if err := r.UploadFile(context.Background(), client, "bucket", "dir/payload.bin", src, func(n int64) { uploaded.Add(n) }); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if !bytes.Equal(stub.objects["dir/payload.bin"], data) {
+		t.Fatalf("uploaded object has %d bytes, want %d matching bytes", len(stub.objects["dir/payload.bin"]), len(data))
+	}
+	if got := uploaded.Load(); got != int64(len(data)) {
+		t.Errorf("upload progress = %d, want %d", got, len(data))
+	}
+
+	objects, err := r.ListKeys(context.Background(), client, "bucket", "dir/")
+	if err != nil || len(objects) != 1 || objects[0].Size != int64(len(data)) {
+		t.Fatalf("ListKeys() = %+v, %v", objects, err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "nested", "payload.bin")
+	var downloaded atomic.Int64
+	if err := r.DownloadObject(context.Background(), client, "bucket", "dir/payload.bin", "", objects[0].Size, dest, func(n int64) { downloaded.Add(n) }); err != nil {
+		t.Fatalf("DownloadObject() error = %v", err)
+	}
+	got, err := os.ReadFile(dest)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("downloaded file = %d bytes (err %v), want %d matching bytes", len(got), err, len(data))
+	}
+	if info, err := os.Stat(dest); err != nil {
+		t.Errorf("stat downloaded file: %v", err)
+	} else if info.Mode().Perm() != 0o644 {
+		t.Errorf("downloaded file mode = %v, want 0644", info.Mode().Perm())
+	}
+	if n := stub.ranged.Load(); n != 5 {
+		t.Errorf("ranged GETs = %d, want 5", n)
+	}
+	if got := downloaded.Load(); got != int64(len(data)) {
+		t.Errorf("download progress = %d, want %d", got, len(data))
+	}
+}
+
+func TestS3Repository_UploadFile_AbortsOnFailure(t *testing.T) {
+	stub := newMemS3()
+	stub.failAt = 3
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	r.SetPartSize(1024)
+	src, _ := writeTempFile(t, 8000)
+
+	err := r.UploadFile(context.Background(), newStubS3Client(srv.URL), "bucket", "payload.bin", src, nil)
+	if err == nil {
+		t.Fatal("UploadFile() should fail when a part fails")
+	}
+	if stub.aborted.Load() != 1 {
+		t.Errorf("AbortMultipartUpload called %d times, want 1", stub.aborted.Load())
+	}
+	if _, ok := stub.objects["payload.bin"]; ok {
+		t.Error("failed upload should not create the object")
+	}
+}
+
+func TestS3Repository_DownloadObject_Canceled(t *testing.T) {
+	stub := newMemS3()
+	stub.objects["payload.bin"] = bytes.Repeat([]byte("x"), 4096)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	r.SetPartSize(1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "payload.bin")
+	err := r.DownloadObject(ctx, newStubS3Client(srv.URL), "bucket", "payload.bin", "", 4096, dest, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("DownloadObject() error = %v, want context.Canceled", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("canceled download left %d files behind", len(entries))
+	}
+}
@@ -0,0 +1,191 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+type usageObject struct {
+	size  int64
+	class string
+}
+
+// usageStub 以 Delimiter=/ 回傳單層清單（CommonPrefixes 與直接位於 prefix 的物件）。
+type usageStub struct {
+	objects map[string]usageObject
+	calls   atomic.Int32
+	failOn  string // 列出此 prefix 時回傳錯誤
+	// inFlight 與 maxInFlight 記錄同時進行的查詢數
+	inFlight, maxInFlight atomic.Int32
+}
+
+func (u *usageStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	u.calls.Add(1)
+	n := u.inFlight.Add(1)
+	defer u.inFlight.Add(-1)
+	for {
+		cur := u.maxInFlight.Load()
+		if n <= cur || u.maxInFlight.CompareAndSwap(cur, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	prefix := req.URL.Query().Get("prefix")
+	if u.failOn != "" && prefix == u.failOn {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	prefixes := make(map[string]bool)
+	var keys []string
+	for key := range u.objects {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if dir, _, found := strings.Cut(rest, "/"); found {
+			prefixes[prefix+dir+"/"] = true
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("<ListBucketResult><IsTruncated>false</IsTruncated>")
+	for _, key := range keys {
+		obj := u.objects[key]
+		fmt.Fprintf(&b, "<Contents><Key>%s</Key><Size>%d</Size><StorageClass>%s</StorageClass><LastModified>2024-01-01T00:00:00Z</LastModified></Contents>", key, obj.size, obj.class)
+	}
+	for p := range prefixes {
+		fmt.Fprintf(&b, "<CommonPrefixes><Prefix>%s</Prefix></CommonPrefixes>", p)
+	}
+	b.WriteString("</ListBucketResult>")
+	writeXML(w, http.StatusOK, b.String())
+}
+
+func newUsageStub() *usageStub {
+	return &usageStub{objects: map[string]usageObject{
+		"root.txt":                {10, "STANDARD"},
+		"logs/2024/01/a.gz":       {100, "STANDARD"},
+		"logs/2024/01/b.gz":       {200, "GLACIER"},
+		"logs/2024/02/c.gz":       {300, "GLACIER"},
+		"logs/index.json":         {5, ""},
+		"media/video.mp4":         {1000, "STANDARD_IA"},
+		"media/thumbs/1.png":      {50, "STANDARD"},
+		"media/thumbs/2.png":      {50, "STANDARD"},
+		"empty/":                  {0, "STANDARD"},
+		"empty/nested/marker.txt": {1, "STANDARD"},
+	}}
+}
+
+func TestS3Repository_AnalyzePrefix(t *testing.T) {
+	stub := newUsageStub()
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	r.SetConcurrency(2)
+	var last atomic.Int64
+	root, err := r.AnalyzePrefix(context.Background(), newStubS3Client(srv.URL), "bucket", "", func(n int64) {
+		for {
+			cur := last.Load()
+			if n <= cur || last.CompareAndSwap(cur, n) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("AnalyzePrefix() error = %v", err)
+	}
+
+	if root.Size != 1716 || root.Objects != 9 {
+		t.Errorf("root = %d bytes / %d objects, want 1716 / 9", root.Size, root.Objects)
+	}
+	if root.Direct.Size != 10 || root.Direct.Objects != 1 {
+		t.Errorf("root direct = %+v", root.Direct)
+	}
+	if got := last.Load(); got != 9 {
+		t.Errorf("progress reported %d objects, want 9", got)
+	}
+
+	// 子 prefix 依大小由大到小排列
+	var order []string
+	for _, child := range root.Children {
+		order = append(order, child.Prefix)
+	}
+	if want := "media/ logs/ empty/"; strings.Join(order, " ") != want {
+		t.Errorf("children = %v, want %s", order, want)
+	}
+
+	logs := root.Children[1]
+	if logs.Size != 605 || logs.Objects != 4 {
+		t.Errorf("logs/ = %+v", logs.S3StorageUsage)
+	}
+	if logs.ByClass["GLACIER"].Size != 500 || logs.ByClass["STANDARD"].Objects != 2 {
+		t.Errorf("logs/ by class = %+v (missing class should count as STANDARD)", logs.ByClass)
+	}
+	if logs.DirectByClass["STANDARD"].Size != 5 || len(logs.DirectByClass) != 1 {
+		t.Errorf("logs/ direct by class = %+v", logs.DirectByClass)
+	}
+	if len(logs.Children) != 1 || len(logs.Children[0].Children) != 2 {
+		t.Fatalf("logs/ tree = %+v", logs.Children)
+	}
+	if jan := logs.Children[0].Children[0]; jan.Prefix != "logs/2024/01/" || jan.Size != 300 {
+		t.Errorf("largest month = %s %d, want logs/2024/01/ 300", jan.Prefix, jan.Size)
+	}
+	if root.ByClass["STANDARD_IA"].Size != 1000 {
+		t.Errorf("root by class = %+v", root.ByClass)
+	}
+}
+
+func TestS3Repository_AnalyzePrefix_Error(t *testing.T) {
+	stub := newUsageStub()
+	stub.failOn = "logs/2024/"
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	_, err := repo.NewS3Repository().AnalyzePrefix(context.Background(), newStubS3Client(srv.URL), "bucket", "logs", nil)
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("AnalyzePrefix() error = %v, want AccessDenied", err)
+	}
+}
+
+func TestS3Repository_AnalyzePrefix_WideTree(t *testing.T) {
+	stub := &usageStub{objects: make(map[string]usageObject)}
+	for i := range 200 {
+		stub.objects[fmt.Sprintf("data/%03d/part-0.bin", i)] = usageObject{10, "STANDARD"}
+		stub.objects[fmt.Sprintf("data/%03d/sub/part-1.bin", i)] = usageObject{5, "GLACIER"}
+	}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	r.SetConcurrency(3)
+	root, err := r.AnalyzePrefix(context.Background(), newStubS3Client(srv.URL), "bucket", "data", nil)
+	if err != nil {
+		t.Fatalf("AnalyzePrefix() error = %v", err)
+	}
+	if root.Size != 3000 || root.Objects != 400 || len(root.Children) != 200 {
+		t.Errorf("root = %d bytes / %d objects / %d children, want 3000 / 400 / 200", root.Size, root.Objects, len(root.Children))
+	}
+	if root.ByClass["GLACIER"].Objects != 200 {
+		t.Errorf("root by class = %+v", root.ByClass)
+	}
+	// data/、200 個子 prefix 與各自的 sub/ 各查詢一次，同時最多 3 個
+	if calls := stub.calls.Load(); calls != 401 {
+		t.Errorf("list calls = %d, want 401", calls)
+	}
+	if peak := stub.maxInFlight.Load(); peak > 3 {
+		t.Errorf("peak concurrent lists = %d, want at most 3", peak)
+	}
+}
@@ -0,0 +1,93 @@
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/aws/repo"
+)
+
+// versionsStub 模擬 ListObjectVersions、CopyObject 與 DeleteObject，並記錄收到的請求。
+type versionsStub struct {
+	copySource string
+	deleted    string
+}
+
+func (v *versionsStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	switch {
+	case query.Has("versions"):
+		writeXML(w, http.StatusOK, `<ListVersionsResult><IsTruncated>false</IsTruncated>
+<Version><Key>app.yaml</Key><VersionId>v1</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-01T00:00:00Z</LastModified><Size>10</Size></Version>
+<Version><Key>app.yaml</Key><VersionId>v2</VersionId><IsLatest>false</IsLatest><LastModified>2024-02-01T00:00:00Z</LastModified><Size>12</Size></Version>
+<Version><Key>app.yaml.bak</Key><VersionId>other</VersionId><IsLatest>true</IsLatest><LastModified>2024-03-01T00:00:00Z</LastModified><Size>1</Size></Version>
+<DeleteMarker><Key>app.yaml</Key><VersionId>dm</VersionId><IsLatest>true</IsLatest><LastModified>2024-03-01T00:00:00Z</LastModified></DeleteMarker>
+</ListVersionsResult>`)
+	case req.Method == http.MethodPut && req.Header.Get("X-Amz-Copy-Source") != "":
+		v.copySource = req.Header.Get("X-Amz-Copy-Source")
+		w.Header().Set("X-Amz-Version-Id", "v3")
+		writeXML(w, http.StatusOK, "<CopyObjectResult><ETag>\"e\"</ETag></CopyObjectResult>")
+	case req.Method == http.MethodDelete:
+		v.deleted = query.Get("versionId")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusBadRequest, "NotImplemented")
+	}
+}
+
+func TestS3Repository_ListObjectVersions(t *testing.T) {
+	srv := httptest.NewServer(&versionsStub{})
+	defer srv.Close()
+
+	versions, err := repo.NewS3Repository().ListObjectVersions(context.Background(), newStubS3Client(srv.URL), "bucket", "app.yaml")
+	if err != nil {
+		t.Fatalf("ListObjectVersions() error = %v", err)
+	}
+
+	// 其他相同前綴的 key 不列入；最新的刪除標記排第一，其餘由新到舊
+	want := []string{"dm", "v2", "v1"}
+	if len(versions) != len(want) {
+		t.Fatalf("got %d versions, want %d: %+v", len(versions), len(want), versions)
+	}
+	for i, id := range want {
+		if versions[i].VersionID != id {
+			t.Errorf("versions[%d] = %s, want %s", i, versions[i].VersionID, id)
+		}
+	}
+	if !versions[0].IsDeleteMarker || !versions[0].IsLatest {
+		t.Errorf("first entry should be the latest delete marker: %+v", versions[0])
+	}
+	if versions[1].Size != 12 {
+		t.Errorf("v2 size = %d, want 12", versions[1].Size)
+	}
+}
+
+func TestS3Repository_RestoreAndDeleteVersion(t *testing.T) {
+	stub := &versionsStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	r := repo.NewS3Repository()
+	client := newStubS3Client(srv.URL)
+
+	newVersion, err := r.RestoreVersion(context.Background(), client, "bucket", "conf/app config.yaml", "v1")
+	if err != nil {
+		t.Fatalf("RestoreVersion() error = %v", err)
+	}
+	if newVersion != "v3" {
+		t.Errorf("new version = %q, want v3", newVersion)
+	}
+	if !strings.HasPrefix(stub.copySource, "bucket/conf/app%20config.yaml?versionId=v1") {
+		t.Errorf("copy source = %q", stub.copySource)
+	}
+
+	if err := r.DeleteVersion(context.Background(), client, "bucket", "conf/app.yaml", "v2"); err != nil {
+		t.Fatalf("DeleteVersion() error = %v", err)
+	}
+	if stub.deleted != "v2" {
+		t.Errorf("deleted versionId = %q, want v2", stub.deleted)
+	}
+}
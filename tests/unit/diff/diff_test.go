@@ -0,0 +1,67 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/diff"
+)
+
+// render 以 " "、"-"、"+" 前綴輸出差異，方便比對。
+func render(lines []diff.Line) string {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(map[diff.Op]string{diff.Equal: " ", diff.Delete: "-", diff.Insert: "+"}[l.Op])
+		b.WriteString(l.Text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"identical", "a\nb\n", "a\nb", " a\n b\n"},
+		{"empty to text", "", "a\nb", "+a\n+b\n"},
+		{"text to empty", "a\n", "", "-a\n"},
+		{"change middle", "a\nb\nc", "a\nx\nc", " a\n-b\n+x\n c\n"},
+		{"insert and delete", "a\nb\nc\nd", "b\nc\ne\nd", "-a\n b\n c\n+e\n d\n"},
+		{"reorder", "id: x\nstatus: Enabled\nprefix: logs/", "id: x\nprefix: logs/\nstatus: Enabled", " id: x\n-status: Enabled\n prefix: logs/\n+status: Enabled\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := render(diff.Text(tt.a, tt.b)); got != tt.want {
+				t.Errorf("Text() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLines_Minimal(t *testing.T) {
+	a := strings.Split("a b c a b b a", " ")
+	b := strings.Split("c b a b a c", " ")
+	lines := diff.Lines(a, b)
+	if ins, del := diff.Stats(lines); ins+del != 5 {
+		t.Errorf("edit distance = %d, want 5\n%s", ins+del, render(lines))
+	}
+
+	// 重建兩邊內容，確認差異正確
+	var gotA, gotB []string
+	for _, l := range lines {
+		if l.Op != diff.Insert {
+			gotA = append(gotA, l.Text)
+		}
+		if l.Op != diff.Delete {
+			gotB = append(gotB, l.Text)
+		}
+	}
+	if strings.Join(gotA, " ") != strings.Join(a, " ") || strings.Join(gotB, " ") != strings.Join(b, " ") {
+		t.Errorf("diff does not reproduce inputs: %v / %v", gotA, gotB)
+	}
+	if !diff.Changed(lines) || diff.Changed(diff.Lines(a, a)) {
+		t.Error("Changed() is wrong")
+	}
+}
@@ -0,0 +1,118 @@
+// Package preview 提供物件預覽格式判斷的單元測試。
+package preview
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/preview"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRender_JSONPrettyPrinted(t *testing.T) {
+	result := preview.Render(preview.Input{Key: "data/config.json", Data: []byte(`{"a":1,"b":[true,null]}`)})
+	if result.Format != preview.FormatJSON {
+		t.Fatalf("Format = %s, want json", result.Format)
+	}
+	if !strings.Contains(result.Text, "\n  \"a\": 1,") {
+		t.Errorf("JSON not indented:\n%s", result.Text)
+	}
+}
+
+func TestRender_JSONDetectedByContent(t *testing.T) {
+	result := preview.Render(preview.Input{Key: "payload", Data: []byte(`[1,2]`)})
+	if result.Format != preview.FormatJSON {
+		t.Errorf("Format = %s, want json", result.Format)
+	}
+}
+
+func TestRender_TruncatedJSONFallsBackToText(t *testing.T) {
+	result := preview.Render(preview.Input{Key: "big.json", Data: []byte(`{"a":[1,2,`), Truncated: true})
+	if result.Format != preview.FormatText || !result.BrokenJSON || !result.Truncated {
+		t.Errorf("Render() = %+v, want text with BrokenJSON and Truncated", result)
+	}
+}
+
+func TestRender_CSVTable(t *testing.T) {
+	data := []byte("name,region\nweb,us-east-1\napi,ap-northeast-1\npartial,eu")
+	result := preview.Render(preview.Input{Key: "report.csv", Data: data, Truncated: true})
+	if result.Format != preview.FormatCSV {
+		t.Fatalf("Format = %s, want csv", result.Format)
+	}
+	lines := strings.Split(strings.TrimSpace(result.Text), "\n")
+	// 表頭、分隔線與兩列完整資料；截斷的最後一列會被捨棄
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4:\n%s", len(lines), result.Text)
+	}
+	if !strings.HasPrefix(lines[0], "name │ region") || !strings.Contains(lines[1], "─┼─") {
+		t.Errorf("unexpected table:\n%s", result.Text)
+	}
+}
+
+func TestRender_TSVByContentType(t *testing.T) {
+	result := preview.Render(preview.Input{Key: "export", ContentType: "text/tab-separated-values", Data: []byte("a\tb\n1\t2\n")})
+	if result.Format != preview.FormatCSV || !strings.Contains(result.Text, "a │ b") {
+		t.Errorf("Render() = %+v, want tab-separated table", result)
+	}
+}
+
+func TestRender_Gzip(t *testing.T) {
+	data := gzipBytes(t, []byte(`{"compressed":true}`))
+	result := preview.Render(preview.Input{Key: "events.json.gz", ContentType: "application/gzip", Data: data})
+	if !result.Decompressed || result.Format != preview.FormatJSON {
+		t.Fatalf("Render() = %+v, want decompressed json", result)
+	}
+	if !strings.Contains(result.Text, `"compressed": true`) {
+		t.Errorf("Text = %q", result.Text)
+	}
+}
+
+func TestRender_TruncatedGzip(t *testing.T) {
+	plain := bytes.Repeat([]byte("line of log text\n"), 5000)
+	data := gzipBytes(t, plain)
+	result := preview.Render(preview.Input{Key: "app.log.gz", Data: data[:len(data)/2], Truncated: true})
+	if !result.Decompressed || result.Format != preview.FormatText || !result.Truncated {
+		t.Fatalf("Render() = format %s decompressed %v truncated %v", result.Format, result.Decompressed, result.Truncated)
+	}
+	if !strings.HasPrefix(result.Text, "line of log text\n") {
+		t.Errorf("Text starts with %q", result.Text[:20])
+	}
+}
+
+func TestRender_BinaryHexDump(t *testing.T) {
+	result := preview.Render(preview.Input{Key: "image.png", Data: []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00}})
+	if result.Format != preview.FormatHex {
+		t.Fatalf("Format = %s, want hex", result.Format)
+	}
+	if !strings.HasPrefix(result.Text, "00000000  89 50 4e 47") {
+		t.Errorf("Text = %q", result.Text)
+	}
+}
+
+func TestRender_TextWithSplitRune(t *testing.T) {
+	data := []byte("日誌內容")
+	result := preview.Render(preview.Input{Key: "notes.txt", Data: data[:len(data)-1], Truncated: true})
+	if result.Format != preview.FormatText {
+		t.Errorf("Format = %s, want text even when the last rune is cut", result.Format)
+	}
+}
+
+func TestRender_Empty(t *testing.T) {
+	if result := preview.Render(preview.Input{Key: "empty.txt"}); result.Format != preview.FormatEmpty {
+		t.Errorf("Format = %s, want empty", result.Format)
+	}
+}
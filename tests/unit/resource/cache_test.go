@@ -0,0 +1,132 @@
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/app/state"
+	"github.com/vincent119/awsGUITools/internal/aws/clients"
+	"github.com/vincent119/awsGUITools/internal/aws/session"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/search"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+const (
+	cacheTestKind       resource.Kind = "cache-test"
+	cacheTestScopedKind resource.Kind = "cache-test-scoped"
+)
+
+// cacheTestScope 為 cacheTestScopedKind 目前的瀏覽範圍。
+var cacheTestScope string
+
+// cacheTestCalls 記錄 cacheTestKind 的查詢次數，用於確認搜尋不會觸發重新查詢。
+var cacheTestCalls int
+
+func init() {
+	resource.Register(resource.Definition{
+		Kind: cacheTestKind,
+		List: func(ctx context.Context, s *resource.Service) ([]models.ListItem, map[string]models.DetailView, error) {
+			cacheTestCalls++
+			return []models.ListItem{
+				{ID: "alpha", Name: "alpha"},
+				{ID: "beta", Name: "beta"},
+			}, nil, nil
+		},
+	})
+	resource.Register(resource.Definition{
+		Kind: cacheTestScopedKind,
+		List: func(ctx context.Context, s *resource.Service) ([]models.ListItem, map[string]models.DetailView, error) {
+			return []models.ListItem{{ID: cacheTestScope, Name: cacheTestScope}}, nil, nil
+		},
+		Scope: func(s *resource.Service) string { return cacheTestScope },
+	})
+}
+
+func newCacheTestService(st *state.Store) *resource.Service {
+	factory := clients.NewFactory(session.NewLoader())
+	return resource.NewService(factory, nil, time.Second, st)
+}
+
+func TestService_CacheKeyedByProfileAndRegion(t *testing.T) {
+	st := state.New("dev", "us-east-1", "dark", "en")
+	svc := newCacheTestService(st)
+	all := search.NewMatcher("")
+
+	if _, ok := svc.Cached(cacheTestKind, all); ok {
+		t.Fatal("Cached() before any list should miss")
+	}
+	if _, err := svc.ListItems(context.Background(), cacheTestKind, all); err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+
+	snapshot, ok := svc.Cached(cacheTestKind, all)
+	if !ok || len(snapshot.Items) != 2 {
+		t.Fatalf("Cached() = %v items, ok %v; want 2 items", len(snapshot.Items), ok)
+	}
+	if snapshot.Stale {
+		t.Error("fresh snapshot should not be stale")
+	}
+
+	st.SetRegion("ap-northeast-1")
+	if _, ok := svc.Cached(cacheTestKind, all); ok {
+		t.Error("Cached() after region change should miss")
+	}
+
+	st.SetRegion("us-east-1")
+	if _, ok := svc.Cached(cacheTestKind, all); !ok {
+		t.Error("Cached() after switching back should hit the original entry")
+	}
+}
+
+func TestService_CacheFiltersAndExpires(t *testing.T) {
+	st := state.New("dev", "us-east-1", "dark", "en")
+	svc := newCacheTestService(st)
+	svc.SetCacheTTL(time.Hour, map[string]time.Duration{string(cacheTestKind): 0})
+
+	before := cacheTestCalls
+	items, err := svc.ListItems(context.Background(), cacheTestKind, search.NewMatcher("alp"))
+	if err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "alpha" {
+		t.Errorf("ListItems(alp) = %v, want only alpha", items)
+	}
+	if cacheTestCalls != before+1 {
+		t.Errorf("lister called %d times, want 1", cacheTestCalls-before)
+	}
+
+	// 搜尋條件只套用在快取上，不需重新查詢
+	snapshot, ok := svc.Cached(cacheTestKind, search.NewMatcher("bet"))
+	if !ok || len(snapshot.Items) != 1 || snapshot.Items[0].ID != "beta" {
+		t.Errorf("Cached(bet) = %v, ok %v; want only beta", snapshot.Items, ok)
+	}
+	if !snapshot.Stale {
+		t.Error("per-kind TTL of 0 should always report stale")
+	}
+}
+
+func TestService_CacheEvictsOldestScopes(t *testing.T) {
+	st := state.New("dev", "us-east-1", "dark", "en")
+	svc := newCacheTestService(st)
+	all := search.NewMatcher("")
+
+	const scopes = 100
+	for i := range scopes {
+		cacheTestScope = fmt.Sprintf("prefix-%d/", i)
+		if _, err := svc.ListItems(context.Background(), cacheTestScopedKind, all); err != nil {
+			t.Fatalf("ListItems(%s) error = %v", cacheTestScope, err)
+		}
+	}
+
+	cacheTestScope = "prefix-0/"
+	if _, ok := svc.Cached(cacheTestScopedKind, all); ok {
+		t.Error("oldest scope should have been evicted")
+	}
+	cacheTestScope = fmt.Sprintf("prefix-%d/", scopes-1)
+	if snapshot, ok := svc.Cached(cacheTestScopedKind, all); !ok || len(snapshot.Items) != 1 {
+		t.Errorf("latest scope Cached() = %v, ok %v; want 1 item", snapshot.Items, ok)
+	}
+}
@@ -0,0 +1,158 @@
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/vincent119/awsGUITools/internal/app/state"
+	"github.com/vincent119/awsGUITools/internal/aws/clients"
+	"github.com/vincent119/awsGUITools/internal/search"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+// stubLoader 讓 Factory 建立的 client 都連到 httptest server。
+type stubLoader struct {
+	url string
+}
+
+func (l stubLoader) Config(_ context.Context, _, region string) (aws.Config, error) {
+	return aws.Config{
+		Region:           region,
+		BaseEndpoint:     aws.String(l.url),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	}, nil
+}
+
+// detailEC2Stub 模擬 EC2 清單與單筆查詢：i-denied 的單筆查詢會失敗，並記錄每次呼叫。
+type detailEC2Stub struct {
+	calls []string
+}
+
+func (s *detailEC2Stub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := req.Form.Get("Action")
+	w.Header().Set("Content-Type", "text/xml")
+	switch action {
+	case "DescribeInstances":
+		id := req.Form.Get("InstanceId.1")
+		s.calls = append(s.calls, strings.TrimSpace(action+" "+id))
+		if id == "i-denied" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<Response><Errors><Error><Code>UnauthorizedOperation</Code><Message>denied</Message></Error></Errors></Response>`)
+			return
+		}
+		ids := []string{id}
+		if id == "" {
+			ids = []string{"i-1", "i-2", "i-denied"}
+		}
+		var items strings.Builder
+		for _, id := range ids {
+			items.WriteString(`<item><instanceId>` + id + `</instanceId><instanceType>t3.micro</instanceType>
+<instanceState><code>16</code><name>running</name></instanceState>
+<placement><availabilityZone>us-east-1a</availabilityZone></placement>
+<blockDeviceMapping><item><deviceName>/dev/xvda</deviceName><ebs><volumeId>vol-` + id + `</volumeId><status>attached</status></ebs></item></blockDeviceMapping></item>`)
+		}
+		fmt.Fprint(w, `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><reservationSet><item><instancesSet>`+
+			items.String()+`</instancesSet></item></reservationSet></DescribeInstancesResponse>`)
+	case "DescribeVolumes":
+		id := req.Form.Get("VolumeId.1")
+		s.calls = append(s.calls, action+" "+id)
+		fmt.Fprint(w, `<DescribeVolumesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><volumeSet>
+<item><volumeId>`+id+`</volumeId><size>30</size><volumeType>gp3</volumeType><encrypted>true</encrypted></item></volumeSet></DescribeVolumesResponse>`)
+	default:
+		http.Error(w, "unexpected "+action, http.StatusNotImplemented)
+	}
+}
+
+func newDetailTestService(t *testing.T) (*resource.Service, *detailEC2Stub) {
+	t.Helper()
+	stub := &detailEC2Stub{}
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	st := state.New("dev", "us-east-1", "dark", "en")
+	return resource.NewService(clients.NewFactory(stubLoader{url: srv.URL}), nil, time.Second, st), stub
+}
+
+func TestService_DetailDescribesSelectedInstance(t *testing.T) {
+	svc, stub := newDetailTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.ListItems(ctx, resource.KindEC2, search.NewMatcher("")); err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	detail, err := svc.Detail(ctx, resource.KindEC2, "i-1")
+	if err != nil {
+		t.Fatalf("Detail(i-1) error = %v", err)
+	}
+	if vols := detail.Relations["EBS Volumes"]; len(vols) != 1 || vols[0] != "vol-i-1 /dev/xvda 30 GiB gp3 (attached)" {
+		t.Errorf("EBS Volumes = %v, want the described size and type", vols)
+	}
+
+	// 已查詢過的 i-1 直接使用快取；i-2 只有清單層級的詳情，仍需單筆查詢
+	if _, err := svc.Detail(ctx, resource.KindEC2, "i-1"); err != nil {
+		t.Fatalf("cached Detail(i-1) error = %v", err)
+	}
+	detail, err = svc.Detail(ctx, resource.KindEC2, "i-2")
+	if err != nil {
+		t.Fatalf("Detail(i-2) error = %v", err)
+	}
+	if vols := detail.Relations["EBS Volumes"]; len(vols) != 1 || !strings.Contains(vols[0], "30 GiB") {
+		t.Errorf("i-2 EBS Volumes = %v", vols)
+	}
+
+	want := []string{
+		"DescribeInstances",
+		"DescribeInstances i-1", "DescribeVolumes vol-i-1",
+		"DescribeInstances i-2", "DescribeVolumes vol-i-2",
+	}
+	if got := strings.Join(stub.calls, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("calls:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestService_DetailFallsBackToListDetail(t *testing.T) {
+	svc, stub := newDetailTestService(t)
+	ctx := context.Background()
+
+	// 未列出清單時單筆查詢失敗，沒有可退回的詳情
+	if _, err := svc.Detail(ctx, resource.KindEC2, "i-denied"); err == nil {
+		t.Fatal("Detail(i-denied) before listing expected error")
+	}
+	if len(stub.calls) != 1 {
+		t.Errorf("calls = %v, want only the describe call (no list)", stub.calls)
+	}
+
+	if _, err := svc.ListItems(ctx, resource.KindEC2, search.NewMatcher("")); err != nil {
+		t.Fatalf("ListItems() error = %v", err)
+	}
+	detail, err := svc.Detail(ctx, resource.KindEC2, "i-denied")
+	if err != nil {
+		t.Fatalf("Detail(i-denied) after listing error = %v", err)
+	}
+	if detail.Overview["Instance ID"] != "i-denied" || !strings.Contains(detail.Overview["Error"], "UnauthorizedOperation") {
+		t.Errorf("overview = %v, want list-level detail marked with the describe error", detail.Overview)
+	}
+	if vols := detail.Relations["EBS Volumes"]; len(vols) != 1 || vols[0] != "vol-i-denied (attached)" {
+		t.Errorf("EBS Volumes = %v, want list-level volumes", vols)
+	}
+
+	// 失敗的查詢不會寫入快取，下次仍重新查詢
+	before := len(stub.calls)
+	if _, err := svc.Detail(ctx, resource.KindEC2, "i-denied"); err != nil {
+		t.Fatalf("Detail(i-denied) again error = %v", err)
+	}
+	if len(stub.calls) != before+1 {
+		t.Errorf("calls = %v, want the describe retried", stub.calls[before:])
+	}
+}
@@ -0,0 +1,52 @@
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vincent119/awsGUITools/internal/app/state"
+	"github.com/vincent119/awsGUITools/internal/aws/clients"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func TestExecuteAction_EC2RebootDoesNotWait(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		action := req.Form.Get("Action")
+		calls = append(calls, action+" "+req.Form.Get("InstanceId.1"))
+		if action != "RebootInstances" {
+			http.Error(w, "unexpected "+action, http.StatusNotImplemented)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, `<RebootInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><return>true</return></RebootInstancesResponse>`)
+	}))
+	defer srv.Close()
+	st := state.New("dev", "us-east-1", "dark", "en")
+	svc := resource.NewService(clients.NewFactory(stubLoader{url: srv.URL}), nil, time.Second, st)
+
+	waited := false
+	result, err := svc.ExecuteAction(context.Background(), resource.KindEC2, "i-1", resource.ActionReboot, func(string) { waited = true })
+	if err != nil {
+		t.Fatalf("ExecuteAction(reboot) error = %v", err)
+	}
+	// 重新開機期間實例一直是 running，不應回報已達到目標狀態
+	if waited || result.TargetState != "" {
+		t.Errorf("waited = %v, TargetState = %q; want no wait", waited, result.TargetState)
+	}
+	if result.MessageKey != "action.reboot_requested" || result.Message != "" {
+		t.Errorf("MessageKey = %q, Message = %q; want a translatable reboot note", result.MessageKey, result.Message)
+	}
+	if got := strings.Join(calls, "\n"); got != "RebootInstances i-1" {
+		t.Errorf("calls = %q, want only RebootInstances", got)
+	}
+}
@@ -0,0 +1,125 @@
+package resource_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/vincent119/awsGUITools/internal/aws/metrics"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+// series 由每分鐘的數值建立指標序列，t0 為第一點的時間。
+func series(t0 time.Time, values ...float64) []metrics.Point {
+	points := make([]metrics.Point, len(values))
+	for i, v := range values {
+		points[i] = metrics.Point{Timestamp: t0.Add(time.Duration(i) * time.Minute), Value: v}
+	}
+	return points
+}
+
+func TestAnalyzeConcurrency(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		concurrent []float64
+		throttles  []float64
+		reserved   bool
+		want       resource.ThrottleCause
+		atLimit    int
+	}{
+		{"no throttles", []float64{10, 50, 30}, []float64{0, 0, 0}, true, resource.ThrottleNone, 0},
+		{"at reserved", []float64{10, 100, 95}, []float64{0, 12, 3}, true, resource.ThrottleReserved, 2},
+		{"at account", []float64{10, 100, 40}, []float64{0, 12, 0}, false, resource.ThrottleAccount, 1},
+		{"burst below limit", []float64{10, 40, 95}, []float64{0, 8, 0}, true, resource.ThrottleBurst, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := resource.AnalyzeConcurrency(series(t0, tt.concurrent...), series(t0, tt.throttles...), 100, tt.reserved)
+			if stats.Cause != tt.want || stats.AtLimitMinutes != tt.atLimit {
+				t.Errorf("cause = %s (%d at limit), want %s (%d)", stats.Cause, stats.AtLimitMinutes, tt.want, tt.atLimit)
+			}
+		})
+	}
+
+	stats := resource.AnalyzeConcurrency(series(t0, 10, 100, 95), series(t0, 0, 12, 3), 100, true)
+	if stats.PeakConcurrent != 100 || !stats.PeakAt.Equal(t0.Add(time.Minute)) {
+		t.Errorf("peak = %v at %v", stats.PeakConcurrent, stats.PeakAt)
+	}
+	if stats.Throttles != 15 || stats.ThrottledMinutes != 2 || !stats.LastThrottle.Equal(t0.Add(2*time.Minute)) {
+		t.Errorf("throttles = %v in %d minutes, last %v", stats.Throttles, stats.ThrottledMinutes, stats.LastThrottle)
+	}
+}
+
+func TestParseConcurrencyInput(t *testing.T) {
+	if reserved, err := resource.ParseReservedConcurrency(" 25 "); err != nil || reserved == nil || *reserved != 25 {
+		t.Errorf("ParseReservedConcurrency(25) = %v, %v", reserved, err)
+	}
+	for _, text := range []string{"", "none", "NONE"} {
+		if reserved, err := resource.ParseReservedConcurrency(text); err != nil || reserved != nil {
+			t.Errorf("ParseReservedConcurrency(%q) = %v, %v; want nil", text, reserved, err)
+		}
+	}
+	for _, text := range []string{"-1", "ten"} {
+		if _, err := resource.ParseReservedConcurrency(text); err == nil {
+			t.Errorf("ParseReservedConcurrency(%q) expected error", text)
+		}
+	}
+
+	qualifier, count, err := resource.ParseProvisionedConcurrency("live 10")
+	if err != nil || qualifier != "live" || count != 10 {
+		t.Errorf("ParseProvisionedConcurrency() = %s %d, %v", qualifier, count, err)
+	}
+	for _, text := range []string{"live", "live -1", "live 10 extra"} {
+		if _, _, err := resource.ParseProvisionedConcurrency(text); err == nil {
+			t.Errorf("ParseProvisionedConcurrency(%q) expected error", text)
+		}
+	}
+}
+
+func TestConcurrencyPanel_Validate(t *testing.T) {
+	panel := resource.ConcurrencyPanel{
+		Account:  models.LambdaAccountConcurrency{Limit: 1000, Unreserved: 250},
+		Reserved: aws.Int32(50),
+		Provisioned: []models.LambdaProvisionedConcurrency{
+			{Qualifier: "live", Requested: 20},
+			{Qualifier: "3", Requested: 10},
+		},
+	}
+	if panel.Limit() != 50 {
+		t.Errorf("Limit() = %d, want reserved 50", panel.Limit())
+	}
+
+	// 目前保留 50、未保留 250：最多可保留 250+50-100 = 200，且不得低於 provisioned 總和 30
+	for reserved, ok := range map[int32]bool{200: true, 201: false, 30: true, 29: false} {
+		if err := panel.ValidateReserved(aws.Int32(reserved)); (err == nil) != ok {
+			t.Errorf("ValidateReserved(%d) = %v", reserved, err)
+		}
+	}
+	if err := panel.ValidateReserved(nil); err != nil {
+		t.Errorf("ValidateReserved(nil) = %v", err)
+	}
+
+	// 有保留並行數時 provisioned 總和不得超過 50（其他 qualifier 已用 10）
+	for count, ok := range map[int32]bool{40: true, 41: false, 0: true} {
+		if err := panel.ValidateProvisioned("live", count); (err == nil) != ok {
+			t.Errorf("ValidateProvisioned(live, %d) = %v", count, err)
+		}
+	}
+	if err := panel.ValidateProvisioned("$LATEST", 1); err == nil {
+		t.Error("ValidateProvisioned($LATEST) expected error")
+	}
+
+	// 未保留時 provisioned 取自未保留的容量：live 目前 20，最多 250+20-100 = 170
+	panel.Reserved = nil
+	if panel.Limit() != 250 {
+		t.Errorf("Limit() = %d, want unreserved 250", panel.Limit())
+	}
+	for count, ok := range map[int32]bool{170: true, 171: false} {
+		if err := panel.ValidateProvisioned("live", count); (err == nil) != ok {
+			t.Errorf("ValidateProvisioned(live, %d) without reserved = %v", count, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package resource_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func lambdaEdit() resource.LambdaConfigEdit {
+	return resource.LambdaConfigEdit{
+		Function: "arn:aws:lambda:us-east-1:123456789012:function:fn",
+		Name:     "fn",
+		Original: models.LambdaConfig{
+			MemoryMB:    128,
+			TimeoutSec:  3,
+			Environment: map[string]string{"LOG_LEVEL": "info", "DB_PASSWORD": "hunter2", "API_KEY": "k-1"},
+		},
+	}
+}
+
+func TestPlanLambdaConfig_KeepsMaskedSecrets(t *testing.T) {
+	edited := `memory_mb: 256
+timeout_sec: 3
+environment:
+  LOG_LEVEL: debug
+  DB_PASSWORD: "********"
+  API_KEY: k-2
+`
+	change, err := resource.PlanLambdaConfig(lambdaEdit(), edited)
+	if err != nil {
+		t.Fatalf("PlanLambdaConfig() error = %v", err)
+	}
+	if change.Update.MemoryMB != 256 || change.Update.TimeoutSec != 0 {
+		t.Errorf("update = %+v, want only memory and environment", change.Update)
+	}
+	if env := change.Update.Environment; env["DB_PASSWORD"] != "hunter2" || env["API_KEY"] != "k-2" || env["LOG_LEVEL"] != "debug" {
+		t.Errorf("environment = %v", env)
+	}
+
+	var text strings.Builder
+	for _, l := range change.Diff {
+		text.WriteString(l.Text + "\n")
+	}
+	for _, secret := range []string{"hunter2", "k-1", "k-2"} {
+		if strings.Contains(text.String(), secret) {
+			t.Errorf("diff leaks secret %q:\n%s", secret, text.String())
+		}
+	}
+	var inserted []string
+	for _, l := range change.Diff {
+		if l.Op == diff.Insert {
+			inserted = append(inserted, strings.TrimSpace(l.Text))
+		}
+	}
+	want := "memory_mb: 256|API_KEY: '******** (changed)'|LOG_LEVEL: debug"
+	if strings.Join(inserted, "|") != want {
+		t.Errorf("inserted = %q, want %q", inserted, want)
+	}
+}
+
+func TestPlanLambdaConfig_NoChangeAndClear(t *testing.T) {
+	edit := lambdaEdit()
+	edit.Text = "memory_mb: 128\ntimeout_sec: 3\nenvironment:\n  LOG_LEVEL: info\n  DB_PASSWORD: '********'\n  API_KEY: '********'\n# comment\n"
+	change, err := resource.PlanLambdaConfig(edit, edit.Text)
+	if err != nil || change.Changed() || diff.Changed(change.Diff) {
+		t.Errorf("unchanged config reported as change: %+v, %v", change, err)
+	}
+
+	// 移除 environment 區段時清除全部環境變數
+	change, err = resource.PlanLambdaConfig(edit, "memory_mb: 128\ntimeout_sec: 3\n")
+	if err != nil || change.Update.Environment == nil || len(change.Update.Environment) != 0 {
+		t.Errorf("clearing environment: %+v, %v", change.Update, err)
+	}
+}
+
+func TestPlanLambdaConfig_Rejects(t *testing.T) {
+	for name, text := range map[string]string{
+		"unknown field":       "memory_mb: 128\ntimeout_sec: 3\nruntime: go1.x\n",
+		"placeholder new key": "memory_mb: 128\ntimeout_sec: 3\nenvironment:\n  NEW_TOKEN: '********'\n",
+		"timeout":             "memory_mb: 128\ntimeout_sec: 0\n",
+	} {
+		if _, err := resource.PlanLambdaConfig(lambdaEdit(), text); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSecretEnvKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"DB_PASSWORD":    true,
+		"GITHUB_TOKEN":   true,
+		"client_secret":  true,
+		"API_KEY":        true,
+		"STRIPE_APIKEY":  true,
+		"SENTRY_DSN":     true,
+		"REDIS_PASS":     true,
+		"LOG_LEVEL":      false,
+		"KEY_PREFIX":     false,
+		"TABLE_NAME":     false,
+		"MONKEY_ENABLED": false,
+	} {
+		if got := resource.SecretEnvKey(key); got != want {
+			t.Errorf("SecretEnvKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package resource_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func TestParseInvokePayload(t *testing.T) {
+	payload, err := resource.ParseInvokePayload("{\n  \"id\": 42,\n  \"tags\": [\"a\"]\n}\n")
+	if err != nil || string(payload) != `{"id":42,"tags":["a"]}` {
+		t.Errorf("ParseInvokePayload() = %s, %v", payload, err)
+	}
+	if payload, err := resource.ParseInvokePayload("  \n"); err != nil || payload != nil {
+		t.Errorf("empty payload = %s, %v; want nil", payload, err)
+	}
+	_, err = resource.ParseInvokePayload("{\n  \"id\": 42,\n  \"tags\": [\"a\",]\n}")
+	if err == nil || !strings.Contains(err.Error(), "line 3, column 16") {
+		t.Errorf("syntax error = %v, want line 3, column 16", err)
+	}
+}
+
+func TestFormatInvokePayload(t *testing.T) {
+	if got := resource.FormatInvokePayload(`{"ok":true}`); got != "{\n  \"ok\": true\n}" {
+		t.Errorf("FormatInvokePayload() = %q", got)
+	}
+	if got := resource.FormatInvokePayload("plain text"); got != "plain text" {
+		t.Errorf("FormatInvokePayload(non-JSON) = %q", got)
+	}
+}
+
+func TestFunctionTarget(t *testing.T) {
+	target := resource.FunctionTarget{Function: "arn:aws:lambda:us-east-1:1:function:orders", Name: "orders", Qualifier: "live"}
+	if target.ARN() != "arn:aws:lambda:us-east-1:1:function:orders:live" || target.Label() != "orders:live" {
+		t.Errorf("target = %s / %s", target.ARN(), target.Label())
+	}
+}
@@ -0,0 +1,45 @@
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func TestParseAliasRouting(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{text: "5", want: "5 (100%)"},
+		{text: "4 5=10", want: "4 (90%) + 5 (10%)"},
+		{text: " 4  5=12.5% ", want: "4 (87.5%) + 5 (12.5%)"},
+		{text: "$LATEST", want: "$LATEST (100%)"},
+		{text: "", wantErr: true},
+		{text: "4 5", wantErr: true},
+		{text: "4 4=10", wantErr: true},
+		{text: "4 5=0", wantErr: true},
+		{text: "4 5=100", wantErr: true},
+		{text: "4 $LATEST=10", wantErr: true},
+		{text: "4 5=x", wantErr: true},
+	}
+	for _, tt := range tests {
+		alias, err := resource.ParseAliasRouting("live", tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAliasRouting(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (alias.Name != "live" || resource.AliasRouting(alias) != tt.want) {
+			t.Errorf("ParseAliasRouting(%q) = %s, want %s", tt.text, resource.AliasRouting(alias), tt.want)
+		}
+	}
+}
+
+func TestAliasRouting_RoundsWeights(t *testing.T) {
+	alias := models.LambdaAlias{Version: "7", AdditionalVersion: "8", AdditionalWeight: 0.3}
+	if got := resource.AliasRouting(alias); got != "7 (70%) + 8 (30%)" {
+		t.Errorf("AliasRouting() = %s", got)
+	}
+}
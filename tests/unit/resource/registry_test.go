@@ -0,0 +1,199 @@
+package resource_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func noopLister(ctx context.Context, s *resource.Service) ([]models.ListItem, map[string]models.DetailView, error) {
+	return nil, nil, nil
+}
+
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		defs    []resource.Definition
+		wantErr bool
+	}{
+		{
+			name: "valid definitions",
+			defs: []resource.Definition{
+				{Kind: "a", Hotkey: '1', List: noopLister},
+				{Kind: "b", List: noopLister},
+				{Kind: "c", List: noopLister},
+			},
+		},
+		{
+			name:    "empty kind",
+			defs:    []resource.Definition{{List: noopLister}},
+			wantErr: true,
+		},
+		{
+			name:    "missing lister",
+			defs:    []resource.Definition{{Kind: "a"}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate kind",
+			defs: []resource.Definition{
+				{Kind: "a", List: noopLister},
+				{Kind: "a", List: noopLister},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate hotkey",
+			defs: []resource.Definition{
+				{Kind: "a", Hotkey: '1', List: noopLister},
+				{Kind: "b", Hotkey: '1', List: noopLister},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := resource.NewRegistry()
+			var err error
+			for _, def := range tt.defs {
+				if err = reg.Register(def); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistry_LookupAndOrder(t *testing.T) {
+	reg := resource.NewRegistry()
+	for _, def := range []resource.Definition{
+		{Kind: "b", Hotkey: '2', List: noopLister},
+		{Kind: "a", Hotkey: '1', List: noopLister},
+		{Kind: "child", List: noopLister},
+	} {
+		if err := reg.Register(def); err != nil {
+			t.Fatalf("Register(%s): %v", def.Kind, err)
+		}
+	}
+
+	if _, ok := reg.Lookup("missing"); ok {
+		t.Error("Lookup(missing) should not be found")
+	}
+	if def, ok := reg.ByHotkey('1'); !ok || def.Kind != "a" {
+		t.Errorf("ByHotkey('1') = %v, %v; want kind a", def.Kind, ok)
+	}
+	if _, ok := reg.ByHotkey(0); ok {
+		t.Error("ByHotkey(0) should never match kinds without a hotkey")
+	}
+
+	defs := reg.Definitions()
+	want := []resource.Kind{"b", "a", "child"}
+	if len(defs) != len(want) {
+		t.Fatalf("Definitions() len = %d, want %d", len(defs), len(want))
+	}
+	for i, def := range defs {
+		if def.Kind != want[i] {
+			t.Errorf("Definitions()[%d] = %s, want %s", i, def.Kind, want[i])
+		}
+	}
+}
+
+func TestRegistry_Command(t *testing.T) {
+	reg := resource.NewRegistry()
+	for _, def := range []resource.Definition{
+		{Kind: "a", List: noopLister, Keys: map[rune]resource.Command{'E': "edit-a"}},
+		{Kind: "b", List: noopLister, Keys: map[rune]resource.Command{'E': "edit-b"}},
+		{Kind: "c", List: noopLister},
+	} {
+		if err := reg.Register(def); err != nil {
+			t.Fatalf("Register(%s): %v", def.Kind, err)
+		}
+	}
+
+	tests := []struct {
+		kind   resource.Kind
+		key    rune
+		want   resource.Command
+		wantOK bool
+	}{
+		{kind: "a", key: 'E', want: "edit-a", wantOK: true},
+		{kind: "b", key: 'E', want: "edit-b", wantOK: true},
+		{kind: "a", key: 'x'},
+		{kind: "c", key: 'E'},
+		{kind: "missing", key: 'E'},
+	}
+	for _, tt := range tests {
+		got, ok := reg.Command(tt.kind, tt.key)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Command(%s, %q) = %q, %v; want %q, %v", tt.kind, tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestDefaultRegistry_BuiltinKinds(t *testing.T) {
+	reg := resource.DefaultRegistry()
+
+	hotkeys := map[rune]resource.Kind{
+		'1': resource.KindEC2,
+		'2': resource.KindRDS,
+		'3': resource.KindS3,
+		'4': resource.KindLambda,
+		'5': resource.KindRoute53,
+	}
+	for key, kind := range hotkeys {
+		def, ok := reg.ByHotkey(key)
+		if !ok || def.Kind != kind {
+			t.Errorf("ByHotkey(%q) = %s, want %s", key, def.Kind, kind)
+		}
+	}
+
+	for _, kind := range []resource.Kind{resource.KindS3Objects, resource.KindRoute53Records} {
+		def, ok := reg.Lookup(kind)
+		if !ok {
+			t.Errorf("%s not registered", kind)
+			continue
+		}
+		if def.Hotkey != 0 {
+			t.Errorf("%s should only be reachable by navigation, got hotkey %q", kind, def.Hotkey)
+		}
+	}
+
+	if def, _ := reg.Lookup(resource.KindLambda); len(def.Actions) == 0 || def.Execute == nil {
+		t.Error("lambda should register actions and an executor")
+	}
+	if def, _ := reg.Lookup(resource.KindRDS); def.Precheck == nil {
+		t.Error("rds should register a stop precheck")
+	}
+
+	keys := []struct {
+		kind resource.Kind
+		key  rune
+		want resource.Command
+	}{
+		{resource.KindS3Objects, 'd', resource.CommandDownload},
+		{resource.KindLambda, 'd', resource.CommandCodeDownload},
+		{resource.KindLambda, 'E', resource.CommandConfigEdit},
+		{resource.KindLambdaVersions, 'E', resource.CommandAliasRouting},
+		{resource.KindRoute53Records, 'E', resource.CommandRecordEdit},
+		{resource.KindRoute53Records, 'D', resource.CommandRecordDelete},
+		{resource.KindRoute53ZoneVPCs, 'D', resource.CommandVPCDisassociate},
+	}
+	for _, tt := range keys {
+		if got, _ := reg.Command(tt.kind, tt.key); got != tt.want {
+			t.Errorf("Command(%s, %q) = %q, want %q", tt.kind, tt.key, got, tt.want)
+		}
+	}
+	for _, def := range reg.Definitions() {
+		for key := range def.Keys {
+			if other, ok := reg.ByHotkey(key); ok {
+				t.Errorf("%s key %q is shadowed by the hotkey of %s", def.Kind, key, other.Kind)
+			}
+		}
+	}
+}
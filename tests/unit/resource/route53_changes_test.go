@@ -0,0 +1,206 @@
+package resource_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+	"github.com/vincent119/awsGUITools/internal/zonefile"
+)
+
+func TestBuildRecordChanges(t *testing.T) {
+	live := []models.Route53Record{
+		{Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}},
+		{Name: "old.example.com.", Type: "CNAME", TTL: 300, Values: []string{"www.example.com."}},
+		{Name: "same.example.com.", Type: "TXT", TTL: 60, Values: []string{`"v"`}},
+	}
+	edits := map[string]*models.Route53Record{
+		"www.example.com._A":     {Name: "www.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1", "192.0.2.2"}},
+		"old.example.com._CNAME": nil,
+		"new.example.com._AAAA":  {Name: "new.example.com.", Type: "AAAA", TTL: 300, Values: []string{"2001:db8::1"}},
+		"same.example.com._TXT":  {Name: "same.example.com.", Type: "TXT", TTL: 60, Values: []string{`"v"`}},
+		"gone.example.com._A":    nil,
+	}
+
+	changes, lines := resource.BuildRecordChanges(live, edits)
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Action+" "+change.Record.Name)
+	}
+	want := []string{"DELETE old.example.com.", "CREATE new.example.com.", "UPSERT www.example.com."}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+	if changes[0].Record.TTL != 300 || changes[0].Record.Values[0] != "www.example.com." {
+		t.Errorf("DELETE must use the live record, got %+v", changes[0].Record)
+	}
+
+	inserted, deleted := diff.Stats(lines)
+	if inserted != 3 || deleted != 2 {
+		t.Errorf("diff stats = +%d -%d, want +3 -2: %v", inserted, deleted, lines)
+	}
+}
+
+func TestBuildRecordChanges_NothingToDo(t *testing.T) {
+	live := []models.Route53Record{{Name: "a.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}}}
+	edits := map[string]*models.Route53Record{
+		"a.example.com._A": {Name: "a.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}},
+		"b.example.com._A": nil,
+	}
+	changes, lines := resource.BuildRecordChanges(live, edits)
+	if len(changes) != 0 || diff.Changed(lines) {
+		t.Errorf("expected no changes, got %v %v", changes, lines)
+	}
+}
+
+func TestBuildRecordChanges_RoutingSets(t *testing.T) {
+	weight := func(w int64) *int64 { return &w }
+	blue := models.Route53Record{Name: "w.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "blue", Weight: weight(10)}
+	green := models.Route53Record{Name: "w.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.2"}, SetIdentifier: "green", Weight: weight(0)}
+	live := []models.Route53Record{blue, green}
+
+	shifted := green
+	shifted.Weight = weight(10)
+	edits := map[string]*models.Route53Record{
+		"w.example.com._A_blue":  &blue,
+		"w.example.com._A_green": &shifted,
+	}
+	changes, _ := resource.BuildRecordChanges(live, edits)
+	if len(changes) != 1 || changes[0].Action != "UPSERT" || changes[0].Record.SetIdentifier != "green" {
+		t.Fatalf("changes = %+v, want a single UPSERT of set green", changes)
+	}
+
+	changes, _ = resource.BuildRecordChanges(live, map[string]*models.Route53Record{"w.example.com._A_blue": nil})
+	if len(changes) != 1 || changes[0].Action != "DELETE" || changes[0].Record.SetIdentifier != "blue" {
+		t.Fatalf("changes = %+v, want a single DELETE of set blue", changes)
+	}
+}
+
+func TestBuildRecordChanges_RoutingLocations(t *testing.T) {
+	bias := func(b int32) *int32 { return &b }
+	office := models.Route53Record{Name: "ip.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "office",
+		CidrRouting: &models.Route53CidrRouting{CollectionID: "c-1", LocationName: "office"}}
+	east := models.Route53Record{Name: "geo.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.2"}, SetIdentifier: "east",
+		GeoProximity: &models.Route53GeoProximity{AWSRegion: "us-east-1", Bias: bias(0)}}
+	live := []models.Route53Record{office, east}
+
+	same := east
+	same.GeoProximity = &models.Route53GeoProximity{AWSRegion: "us-east-1", Bias: bias(0)}
+	if changes, _ := resource.BuildRecordChanges(live, map[string]*models.Route53Record{"geo.example.com._A_east": &same}); len(changes) != 0 {
+		t.Errorf("unchanged geoproximity record produced %+v", changes)
+	}
+
+	shifted := east
+	shifted.GeoProximity = &models.Route53GeoProximity{AWSRegion: "us-east-1", Bias: bias(25)}
+	changes, _ := resource.BuildRecordChanges(live, map[string]*models.Route53Record{
+		"geo.example.com._A_east":  &shifted,
+		"ip.example.com._A_office": nil,
+	})
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v, want DELETE of office and UPSERT of east", changes)
+	}
+	for _, c := range changes {
+		switch c.Action {
+		case "DELETE":
+			if c.Record.CidrRouting == nil || c.Record.CidrRouting.LocationName != "office" {
+				t.Errorf("DELETE lost the CIDR location: %+v", c.Record)
+			}
+		case "UPSERT":
+			if *c.Record.GeoProximity.Bias != 25 {
+				t.Errorf("UPSERT bias = %d, want 25", *c.Record.GeoProximity.Bias)
+			}
+		}
+	}
+}
+
+func TestRecordLines(t *testing.T) {
+	alias := resource.RecordLines(models.Route53Record{Name: "cdn.example.com.", Type: "A", AliasTarget: "d1.cloudfront.net.", AliasZoneID: "Z2", EvaluateTargetHealth: true})
+	if want := []string{"cdn.example.com. ALIAS A d1.cloudfront.net. (zone Z2, evaluate health)"}; !reflect.DeepEqual(alias, want) {
+		t.Errorf("alias lines = %v, want %v", alias, want)
+	}
+	plain := resource.RecordLines(models.Route53Record{Name: "a.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1", "192.0.2.2"}})
+	if want := []string{"a.example.com. 60 A 192.0.2.1", "a.example.com. 60 A 192.0.2.2"}; !reflect.DeepEqual(plain, want) {
+		t.Errorf("lines = %v, want %v", plain, want)
+	}
+	weight := int64(10)
+	weighted := resource.RecordLines(models.Route53Record{Name: "w.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "blue", Weight: &weight, HealthCheckID: "hc-1"})
+	if want := []string{"w.example.com. 60 A 192.0.2.1 (set blue; weight 10; health check hc-1)"}; !reflect.DeepEqual(weighted, want) {
+		t.Errorf("weighted lines = %v, want %v", weighted, want)
+	}
+}
+
+func TestBuildImportChanges(t *testing.T) {
+	live := []models.Route53Record{
+		{Name: "example.com.", Type: "SOA", TTL: 900, Values: []string{"ns-1. host. 1 2 3 4 5"}},
+		{Name: "example.com.", Type: "NS", TTL: 172800, Values: []string{"ns-1.awsdns-01.org."}},
+		{Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}},
+		{Name: "old.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.9"}},
+		{Name: "same.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.5"}},
+	}
+	imported := []models.Route53Record{
+		{Name: "example.com.", Type: "SOA", TTL: 60, Values: []string{"other. host. 9 9 9 9 9"}},
+		{Name: "www.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}},
+		{Name: "same.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.5"}},
+		{Name: "new.example.com.", Type: "CNAME", TTL: 300, Values: []string{"www.example.com."}},
+	}
+
+	changes, _, err := resource.BuildImportChanges("Example.com", live, imported)
+	if err != nil {
+		t.Fatalf("BuildImportChanges() error = %v", err)
+	}
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Action+" "+change.Record.Name)
+	}
+	want := []string{"DELETE old.example.com.", "UPSERT new.example.com.", "UPSERT www.example.com."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+
+	weight := int64(10)
+	routed := append(live, models.Route53Record{Name: "w.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.1"}, SetIdentifier: "blue", Weight: &weight})
+	if changes, _, err = resource.BuildImportChanges("example.com.", routed, imported); err != nil || len(changes) != len(want) {
+		t.Errorf("routing records must be left unchanged, got %v %v", changes, err)
+	}
+
+	outside := []models.Route53Record{{Name: "www.example.net.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}}}
+	if _, _, err := resource.BuildImportChanges("example.com.", live, outside); err == nil {
+		t.Error("expected error for record outside the zone")
+	}
+	invalid := []models.Route53Record{{Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"not-an-ip"}}}
+	if _, _, err := resource.BuildImportChanges("example.com.", live, invalid); err == nil {
+		t.Error("expected validation error")
+	}
+}
+
+func TestBuildImportChanges_HealthCheckRoundTrip(t *testing.T) {
+	live := []models.Route53Record{
+		{Name: "example.com.", Type: "SOA", TTL: 900, Values: []string{"ns-1. host. 1 2 3 4 5"}},
+		{Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}, HealthCheckID: "hc-1"},
+		{Name: "api.example.com.", Type: "A", AliasTarget: "lb.example.net.", AliasZoneID: "Z2", EvaluateTargetHealth: true, HealthCheckID: "hc-2"},
+		{Name: "mail.example.com.", Type: "MX", TTL: 300, Values: []string{"10 mx.example.com."}},
+	}
+
+	// 匯出後原樣匯入不應產生任何變更（不能以 UPSERT 移除 health check）
+	var buf bytes.Buffer
+	if err := zonefile.Write(&buf, "example.com.", live); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	imported, err := zonefile.Parse(bytes.NewReader(buf.Bytes()), "example.com.")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	changes, _, err := resource.BuildImportChanges("example.com.", live, imported)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("round trip changes = %+v, %v; want none\n%s", changes, err, buf.String())
+	}
+
+	// 檔案中同名同類型的 record 也不會覆蓋關聯 health check 的 record
+	edited := append(imported, models.Route53Record{Name: "www.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.8"}})
+	if changes, _, err = resource.BuildImportChanges("example.com.", live, edited); err != nil || len(changes) != 0 {
+		t.Errorf("health-checked record was changed: %+v, %v", changes, err)
+	}
+}
@@ -0,0 +1,27 @@
+package resource_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func TestResolverRulesForZone(t *testing.T) {
+	rules := []models.ResolverRule{
+		{ID: "root", DomainName: "."},
+		{ID: "parent", DomainName: "example.com"},
+		{ID: "same", DomainName: "Corp.Example.com."},
+		{ID: "child", DomainName: "dev.corp.example.com."},
+		{ID: "sibling", DomainName: "xcorp.example.com."},
+		{ID: "other", DomainName: "example.net."},
+	}
+	var got []string
+	for _, rule := range resource.ResolverRulesForZone("corp.example.com.", rules) {
+		got = append(got, rule.ID)
+	}
+	if want := []string{"child", "same", "parent"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rules = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package resource_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/diff"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+const lifecycleYAML = `rules:
+  - id: archive
+    status: Enabled
+    prefix: logs/
+    expiration_days: 365
+`
+
+func TestPlanBucketConfig_Diff(t *testing.T) {
+	edited := `# 只改天數
+rules:
+  - id: archive
+    status: Enabled
+    prefix: logs/
+    transitions:
+      - {days: 30, storage_class: STANDARD_IA}
+    expiration_days: 180
+`
+	change, err := resource.PlanBucketConfig("bucket", resource.BucketConfigLifecycle, lifecycleYAML, edited)
+	if err != nil {
+		t.Fatalf("PlanBucketConfig() error = %v", err)
+	}
+	if change.Rules != 1 || strings.Contains(change.Edited, "#") {
+		t.Errorf("edited config not normalized: %q", change.Edited)
+	}
+	var inserted, deleted []string
+	for _, l := range change.Diff {
+		switch l.Op {
+		case diff.Insert:
+			inserted = append(inserted, strings.TrimSpace(l.Text))
+		case diff.Delete:
+			deleted = append(deleted, strings.TrimSpace(l.Text))
+		}
+	}
+	wantInserted := []string{"transitions:", "- days: 30", "storage_class: STANDARD_IA", "expiration_days: 180"}
+	if strings.Join(inserted, "|") != strings.Join(wantInserted, "|") || strings.Join(deleted, "|") != "expiration_days: 365" {
+		t.Errorf("diff inserted=%q deleted=%q", inserted, deleted)
+	}
+}
+
+func TestPlanBucketConfig_NoChangeAndDelete(t *testing.T) {
+	change, err := resource.PlanBucketConfig("bucket", resource.BucketConfigLifecycle, lifecycleYAML, lifecycleYAML+"# comment\n")
+	if err != nil || diff.Changed(change.Diff) {
+		t.Errorf("unchanged config reported as change: %v, %v", change.Diff, err)
+	}
+
+	// 只剩註解時視為刪除全部規則
+	change, err = resource.PlanBucketConfig("bucket", resource.BucketConfigCORS, "rules: []\n", resource.BucketConfigTemplate(resource.BucketConfigCORS))
+	if err != nil || change.Rules != 0 || change.Edited != "rules: []\n" || diff.Changed(change.Diff) {
+		t.Errorf("template only: %+v, %v", change, err)
+	}
+}
+
+func TestPlanBucketConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config resource.BucketConfig
+		text   string
+		want   string
+	}{
+		{"syntax", resource.BucketConfigLifecycle, "rules: [", "invalid YAML"},
+		{"unknown field", resource.BucketConfigLifecycle, "rules:\n  - id: a\n    status: Enabled\n    expire_days: 3\n", "expire_days"},
+		{"validation", resource.BucketConfigLifecycle, "rules:\n  - id: a\n    status: Enabled\n", "at least one action"},
+		{"cors method", resource.BucketConfigCORS, "rules:\n  - allowed_origins: ['*']\n    allowed_methods: [PATCH]\n", "PATCH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resource.PlanBucketConfig("bucket", tt.config, "rules: []\n", tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("PlanBucketConfig() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
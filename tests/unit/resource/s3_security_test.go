@@ -0,0 +1,39 @@
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func TestPolicyFindingSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		finding models.S3PolicyFinding
+		want    string
+	}{
+		{
+			name:    "wildcard principal",
+			finding: models.S3PolicyFinding{Sid: "All", Actions: []string{"s3:GetObject"}},
+			want:    "All: Principal * s3:GetObject",
+		},
+		{
+			name:    "NotPrincipal",
+			finding: models.S3PolicyFinding{Sid: "Not", Actions: []string{"s3:GetObject"}, NotPrincipal: true, Excluded: []string{"arn:aws:iam::1:root"}},
+			want:    "Not: NotPrincipal [arn:aws:iam::1:root] s3:GetObject",
+		},
+		{
+			name:    "conditional without Sid",
+			finding: models.S3PolicyFinding{Actions: []string{"s3:*"}, Conditional: true},
+			want:    "(no Sid): Principal * s3:* (conditional)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resource.PolicyFindingSummary(tt.finding); got != tt.want {
+				t.Errorf("PolicyFindingSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package resource_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/service/resource"
+)
+
+func usageTree() *models.S3PrefixUsage {
+	logs := &models.S3PrefixUsage{
+		Prefix:         "logs/",
+		S3StorageUsage: models.S3StorageUsage{Size: 300, Objects: 2},
+		Direct:         models.S3StorageUsage{Size: 300, Objects: 2},
+		ByClass: map[string]models.S3StorageUsage{
+			"GLACIER":  {Size: 200, Objects: 1},
+			"STANDARD": {Size: 100, Objects: 1},
+		},
+	}
+	return &models.S3PrefixUsage{
+		Prefix:         "",
+		S3StorageUsage: models.S3StorageUsage{Size: 310, Objects: 3},
+		Direct:         models.S3StorageUsage{Size: 10, Objects: 1},
+		ByClass: map[string]models.S3StorageUsage{
+			"GLACIER":  {Size: 200, Objects: 1},
+			"STANDARD": {Size: 110, Objects: 2},
+		},
+		Children: []*models.S3PrefixUsage{logs},
+	}
+}
+
+func TestWriteUsageCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := resource.WriteUsageCSV(&buf, "bucket", usageTree()); err != nil {
+		t.Fatalf("WriteUsageCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"bucket,prefix,depth,bytes,objects,direct_bytes,direct_objects,GLACIER_bytes,GLACIER_objects,STANDARD_bytes,STANDARD_objects",
+		"bucket,,0,310,3,10,1,200,1,110,2",
+		"bucket,logs/,1,300,2,300,2,200,1,100,1",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteUsageJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := resource.WriteUsageJSON(&buf, "bucket", usageTree()); err != nil {
+		t.Fatalf("WriteUsageJSON() error = %v", err)
+	}
+	var got struct {
+		Bucket   string `json:"bucket"`
+		Size     int64  `json:"size"`
+		Children []struct {
+			Prefix  string                           `json:"prefix"`
+			ByClass map[string]models.S3StorageUsage `json:"by_class"`
+		} `json:"children"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got.Bucket != "bucket" || got.Size != 310 || len(got.Children) != 1 || got.Children[0].ByClass["GLACIER"].Size != 200 {
+		t.Errorf("decoded = %+v", got)
+	}
+}
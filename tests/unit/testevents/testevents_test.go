@@ -0,0 +1,85 @@
+package testevents_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/testevents"
+)
+
+func TestStore_SaveListLoadDelete(t *testing.T) {
+	dir := t.TempDir()
+	store := testevents.NewStore(dir)
+	const fn = "arn:aws:lambda:us-east-1:123456789012:function:orders"
+
+	if names, err := store.List(fn); err != nil || len(names) != 0 {
+		t.Fatalf("List() on empty store = %v, %v", names, err)
+	}
+	if err := store.Save(fn, "paid", `{"status":"paid"}`); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// 版本或 alias 限定的 ARN 與函式共用事件
+	if err := store.Save(fn+":live", "cancelled", `{"status":"cancelled"}`); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "123456789012", "us-east-1", "orders", "paid.json")); err != nil {
+		t.Errorf("event file not under account/region/function: %v", err)
+	}
+
+	names, err := store.List(fn)
+	if err != nil || !slices.Equal(names, []string{"cancelled", "paid"}) {
+		t.Fatalf("List() = %v, %v", names, err)
+	}
+	payload, err := store.Load(fn, "paid")
+	if err != nil || payload != "{\"status\":\"paid\"}\n" {
+		t.Errorf("Load() = %q, %v", payload, err)
+	}
+	if err := store.Delete(fn, "paid"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if names, _ := store.List(fn); !slices.Equal(names, []string{"cancelled"}) {
+		t.Errorf("List() after delete = %v", names)
+	}
+	if names, _ := store.List("arn:aws:lambda:eu-west-1:123456789012:function:orders"); len(names) != 0 {
+		t.Errorf("events leaked across regions: %v", names)
+	}
+}
+
+func TestStore_RejectsInvalidInput(t *testing.T) {
+	store := testevents.NewStore(t.TempDir())
+	tests := []struct {
+		name     string
+		function string
+		event    string
+		payload  string
+	}{
+		{name: "invalid JSON", function: "orders", event: "bad", payload: `{"a":`},
+		{name: "path in event name", function: "orders", event: "../escape", payload: `{}`},
+		{name: "hidden event name", function: "orders", event: ".hidden", payload: `{}`},
+		{name: "path in function", function: "../orders", event: "ok", payload: `{}`},
+		{name: "malformed ARN", function: "arn:aws:lambda:us-east-1:../x:function:orders", event: "ok", payload: `{}`},
+	}
+	for _, tt := range tests {
+		if err := store.Save(tt.function, tt.event, tt.payload); err == nil {
+			t.Errorf("%s: Save() expected error", tt.name)
+		}
+	}
+	if err := testevents.NewStore("").Save("orders", "ok", `{}`); err == nil {
+		t.Error("Save() without config dir expected error")
+	}
+}
+
+func TestTemplates_AreValidJSON(t *testing.T) {
+	templates := testevents.Templates()
+	if len(templates) != 4 {
+		t.Fatalf("Templates() = %d, want 4", len(templates))
+	}
+	for _, tmpl := range templates {
+		if !json.Valid([]byte(tmpl.Payload)) {
+			t.Errorf("template %s is not valid JSON", tmpl.Name)
+		}
+	}
+}
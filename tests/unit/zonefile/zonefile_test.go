@@ -0,0 +1,141 @@
+package zonefile_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vincent119/awsGUITools/internal/models"
+	"github.com/vincent119/awsGUITools/internal/zonefile"
+)
+
+const sampleZone = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns-1.awsdns-01.org. awsdns-hostmaster.amazon.com. (
+		1 7200 900 1209600 86400 ) ; serial refresh retry expire minimum
+@	172800	IN	NS	ns-1.awsdns-01.org.
+		172800	IN	NS	ns-2.awsdns-02.com.
+www	300	IN	A	192.0.2.1
+	300	IN	A	192.0.2.2
+www	300	IN	A	192.0.2.1 ; duplicate is dropped
+mail	MX	10 mx1
+	MX	20 mx2.example.net.
+@	60	TXT	"v=spf1 include:_spf.example.net ~all" ; trailing comment
+*.dev	IN	CNAME	www
+_sip._tcp	SRV	1 10 5060 sip
+cdn	IN	A	AWS ALIAS d111111abcdef8.cloudfront.net. Z2FDTNDATAQYW2 true
+$ORIGIN sub.example.com.
+api	1d	IN	AAAA	2001:db8::1
+`
+
+func TestParse(t *testing.T) {
+	records, err := zonefile.Parse(strings.NewReader(sampleZone), "ignored.com")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := make(map[string]models.Route53Record, len(records))
+	for _, record := range records {
+		got[record.Name+" "+record.Type] = record
+	}
+
+	want := map[string]models.Route53Record{
+		"example.com. SOA":            {Name: "example.com.", Type: "SOA", TTL: 3600, Values: []string{"ns-1.awsdns-01.org. awsdns-hostmaster.amazon.com. 1 7200 900 1209600 86400"}},
+		"example.com. NS":             {Name: "example.com.", Type: "NS", TTL: 172800, Values: []string{"ns-1.awsdns-01.org.", "ns-2.awsdns-02.com."}},
+		"www.example.com. A":          {Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1", "192.0.2.2"}},
+		"mail.example.com. MX":        {Name: "mail.example.com.", Type: "MX", TTL: 3600, Values: []string{"10 mx1.example.com.", "20 mx2.example.net."}},
+		"example.com. TXT":            {Name: "example.com.", Type: "TXT", TTL: 60, Values: []string{`"v=spf1 include:_spf.example.net ~all"`}},
+		`\052.dev.example.com. CNAME`: {Name: `\052.dev.example.com.`, Type: "CNAME", TTL: 3600, Values: []string{"www.example.com."}},
+		"_sip._tcp.example.com. SRV":  {Name: "_sip._tcp.example.com.", Type: "SRV", TTL: 3600, Values: []string{"1 10 5060 sip.example.com."}},
+		"cdn.example.com. A":          {Name: "cdn.example.com.", Type: "A", AliasTarget: "d111111abcdef8.cloudfront.net.", AliasZoneID: "Z2FDTNDATAQYW2", EvaluateTargetHealth: true},
+		"api.sub.example.com. AAAA":   {Name: "api.sub.example.com.", Type: "AAAA", TTL: 86400, Values: []string{"2001:db8::1"}},
+	}
+	if len(got) != len(want) {
+		t.Errorf("parsed %d records, want %d: %v", len(got), len(want), records)
+	}
+	for key, w := range want {
+		if g, ok := got[key]; !ok || !reflect.DeepEqual(g, w) {
+			t.Errorf("%s = %+v, want %+v", key, g, w)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"no ttl":            "www IN A 192.0.2.1\n",
+		"no owner":          "  300 IN A 192.0.2.1\n",
+		"ttl mismatch":      "$TTL 300\nwww A 192.0.2.1\nwww 60 A 192.0.2.2\n",
+		"alias with values": "$TTL 300\ncdn A AWS ALIAS d1.cloudfront.net. Z2\ncdn A 192.0.2.1\n",
+		"unbalanced":        "$TTL 300\n@ SOA ns. host. ( 1 2 3 4 5\n",
+		"unterminated":      "$TTL 300\n@ TXT \"abc\n",
+		"include":           "$INCLUDE other.zone\n",
+		"class":             "$TTL 300\nwww CH A 192.0.2.1\n",
+		"missing data":      "$TTL 300\nwww A\n",
+	}
+	for name, text := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := zonefile.Parse(strings.NewReader(text), "example.com."); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestWriteParseRoundTrip(t *testing.T) {
+	records := []models.Route53Record{
+		{Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1", "192.0.2.2"}},
+		{Name: `\052.example.com.`, Type: "TXT", TTL: 60, Values: []string{`"a b" "c;d"`}},
+		{Name: "example.com.", Type: "NS", TTL: 172800, Values: []string{"ns-1.awsdns-01.org."}},
+		{Name: "example.com.", Type: "SOA", TTL: 900, Values: []string{"ns-1.awsdns-01.org. awsdns-hostmaster.amazon.com. 1 7200 900 1209600 86400"}},
+		{Name: "cdn.example.com.", Type: "AAAA", AliasTarget: "d1.cloudfront.net.", AliasZoneID: "Z2FDTNDATAQYW2"},
+		{Name: "other.net.", Type: "CNAME", TTL: 300, Values: []string{"www.example.com."}},
+	}
+	var buf bytes.Buffer
+	if err := zonefile.Write(&buf, "example.com", records); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	text := buf.String()
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if lines[0] != "$ORIGIN example.com." || !strings.HasPrefix(lines[1], "@\t900\tIN\tSOA") || !strings.HasPrefix(lines[2], "@\t172800\tIN\tNS") {
+		t.Errorf("unexpected header order:\n%s", text)
+	}
+	if !strings.Contains(text, "*\t60\tIN\tTXT") || !strings.Contains(text, "cdn\tIN\tAAAA\tAWS ALIAS d1.cloudfront.net. Z2FDTNDATAQYW2 false") {
+		t.Errorf("unexpected output:\n%s", text)
+	}
+
+	parsed, err := zonefile.Parse(strings.NewReader(text), "")
+	if err != nil {
+		t.Fatalf("Parse() error = %v\n%s", err, text)
+	}
+	if len(parsed) != len(records) {
+		t.Fatalf("round trip returned %d records, want %d", len(parsed), len(records))
+	}
+	byKey := make(map[string]models.Route53Record, len(parsed))
+	for _, record := range parsed {
+		byKey[record.Name+record.Type] = record
+	}
+	for _, record := range records {
+		if got := byKey[record.Name+record.Type]; !reflect.DeepEqual(got, record) {
+			t.Errorf("round trip %s %s = %+v, want %+v", record.Name, record.Type, got, record)
+		}
+	}
+}
+
+func TestWriteSkipsRoutingRecords(t *testing.T) {
+	weight := int64(10)
+	records := []models.Route53Record{
+		{Name: "www.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}},
+		{Name: "w.example.com.", Type: "A", TTL: 60, Values: []string{"192.0.2.2"}, SetIdentifier: "blue", Weight: &weight},
+	}
+	var buf bytes.Buffer
+	if err := zonefile.Write(&buf, "example.com.", records); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	parsed, err := zonefile.Parse(&buf, "")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(parsed) != 1 || parsed[0].Name != "www.example.com." {
+		t.Errorf("parsed = %+v, want only the simple record", parsed)
+	}
+}